@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envPrefix is prepended to every flag name to form its environment variable
+const envPrefix = "MILVUS_STRESS_"
+
+// envVarName maps a flag name to its environment variable, e.g. milvus-addr -> MILVUS_STRESS_MILVUS_ADDR
+func envVarName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// applyEnvOverrides fills every flag of fs that was not given on the command line
+// from its MILVUS_STRESS_* environment variable, so command-line flags always win.
+// It must be called after fs.Parse.
+func applyEnvOverrides(fs *flag.FlagSet) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || explicit[f.Name] {
+			return
+		}
+		value, ok := os.LookupEnv(envVarName(f.Name))
+		if !ok {
+			return
+		}
+		if setErr := fs.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid value %q for %s: %w", value, envVarName(f.Name), setErr)
+		}
+	})
+	return err
+}
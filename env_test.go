@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnvVarName(t *testing.T) {
+	if got, want := envVarName("server-metrics-interval"), "MILVUS_STRESS_SERVER_METRICS_INTERVAL"; got != want {
+		t.Errorf("envVarName() = %q, want %q", got, want)
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		pressure string
+		duration time.Duration
+		wantErr  string // substring of the error, "" for none
+	}{
+		{"defaults", nil, nil, "medium", 30 * time.Second, ""},
+		{"environment", nil, map[string]string{"MILVUS_STRESS_PRESSURE": "high", "MILVUS_STRESS_DURATION": "1m"}, "high", time.Minute, ""},
+		{"flag over environment", []string{"--pressure", "low"}, map[string]string{"MILVUS_STRESS_PRESSURE": "high", "MILVUS_STRESS_DURATION": "1m"}, "low", time.Minute, ""},
+		{"invalid environment value", nil, map[string]string{"MILVUS_STRESS_DURATION": "soon"}, "", 0, `invalid value "soon" for MILVUS_STRESS_DURATION`},
+		{"invalid value hidden by a flag", []string{"--duration", "2m"}, map[string]string{"MILVUS_STRESS_DURATION": "soon"}, "medium", 2 * time.Minute, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			cfg, _, err := parseConfig("insert", tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parseConfig() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseConfig() = %v", err)
+			}
+			if cfg.Pressure != tt.pressure || cfg.Duration != tt.duration {
+				t.Errorf("pressure, duration = %s, %s, want %s, %s", cfg.Pressure, cfg.Duration, tt.pressure, tt.duration)
+			}
+		})
+	}
+}
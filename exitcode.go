@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+
+	"github.com/ariswibono/milvus-stress-test/loadtest"
+)
+
+// Exit codes, one per failure category, so pipelines can branch on the status
+const (
+	exitOK         = 0
+	exitRuntime    = 1 // an operation failed during the run
+	exitConfig     = 2 // invalid flags or configuration, or a collection that does not match it
+	exitConnection = 3 // Milvus could not be reached
+	exitSLA        = 4 // the run completed but missed an SLA assertion
+	exitBudget     = 5 // --max-runtime stopped the run
+)
+
+// exitCode maps a run error to its exit code
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, loadtest.ErrConfig):
+		return exitConfig
+	case errors.Is(err, loadtest.ErrConnect):
+		return exitConnection
+	case errors.Is(err, loadtest.ErrBudgetExceeded):
+		return exitBudget
+	default:
+		return exitRuntime
+	}
+}
+
+// fatal logs v and exits with code
+func fatal(code int, v ...any) {
+	log.Print(v...)
+	os.Exit(code)
+}
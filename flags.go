@@ -0,0 +1,182 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ariswibono/milvus-stress-test/loadtest"
+)
+
+// cliOptions are the flags that only concern the command-line tool, not a run
+type cliOptions struct {
+	gomaxprocs int
+	cpuProfile string
+	memProfile string
+	jsonPath   string
+	jsonOnly   bool
+	webhookURL string
+	showHelp   bool
+
+	// --preset loads the flags the command line and environment leave unset
+	preset      string
+	presetsDir  string
+	listPresets bool
+}
+
+// newFlagSet registers the command-line flags for the named subcommand. Every
+// flag defaults to the corresponding loadtest.DefaultConfig setting.
+func newFlagSet(name string) (*flag.FlagSet, *loadtest.Config, *cliOptions) {
+	cfg := loadtest.DefaultConfig()
+	cfg.Command = name
+	opts := &cliOptions{}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&cfg.MilvusAddr, "milvus-addr", cfg.MilvusAddr, "Milvus server address (host:port), or a comma-separated list to fail over along")
+	fs.DurationVar(&cfg.Duration, "duration", cfg.Duration, "Test duration (e.g., 30s, 2m, 1h)")
+	fs.StringVar(&cfg.Pressure, "pressure", cfg.Pressure, "Load intensity: low, medium, high, extreme")
+	fs.BoolVar(&cfg.RampUp, "ramp-up", cfg.RampUp, "Gradually increase load from --ramp-start-pct to 100% over duration")
+	fs.Float64Var(&cfg.BatchJitter, "batch-jitter", cfg.BatchJitter, "Draw every insert batch size at random within this percentage around the target (0 = fixed)")
+	fs.Float64Var(&cfg.RampStartPct, "ramp-start-pct", cfg.RampStartPct, "Load at the start of --ramp-up, in percent of the workers and batch size")
+	fs.DurationVar(&cfg.CoolDown, "cool-down", cfg.CoolDown, "End the search phase by ramping its workers from full load down to none over this long and report the latency trajectory (0 = off)")
+	fs.Float64Var(&cfg.FaultRate, "fault-injection-rate", cfg.FaultRate, "Send this many malformed batches per insert batch, 0 to 1, and check Milvus rejects them (0 = off)")
+	fs.BoolVar(&cfg.RealTime, "real-time", cfg.RealTime, "Display real-time throughput metrics")
+	fs.IntVar(&cfg.Retries, "retries", cfg.Retries, "Max retries per failed insert/search on retryable errors")
+	fs.IntVar(&cfg.DDLRetries, "ddl-retries", cfg.DDLRetries, "Max retries of a collection existence check, create or drop that failed transiently or raced another client")
+	fs.DurationVar(&cfg.RetryBackoff, "retry-backoff", cfg.RetryBackoff, "Initial backoff between retries, doubled on every attempt")
+	fs.BoolVar(&cfg.ProjectionBench, "projection-bench", cfg.ProjectionBench, "Compare search latency returning only IDs vs all output fields")
+	fs.IntVar(&cfg.ProjectionQueries, "projection-queries", cfg.ProjectionQueries, "Number of queries per variant in the projection benchmark")
+	fs.BoolVar(&cfg.WireStats, "wire-stats", cfg.WireStats, "Count RPCs and bytes sent/received on the wire")
+	fs.StringVar(&cfg.Compression, "compression", cfg.Compression, "Compress every gRPC call: none or gzip")
+	fs.StringVar(&cfg.Transport, "transport", cfg.Transport, "Send inserts and searches over grpc or rest")
+	fs.StringVar(&cfg.MetricsURL, "metrics-url", cfg.MetricsURL, "Milvus Prometheus metrics endpoint (default: http://<milvus-host>:9091/metrics)")
+	fs.DurationVar(&cfg.ServerMetrics, "server-metrics-interval", cfg.ServerMetrics, "Scrape --metrics-url at this interval during the run and report server CPU, memory, queue depth and compaction backlog (0 = off)")
+	fs.Float64Var(&cfg.TargetQPS, "target-qps", cfg.TargetQPS, "Pace searches to this many queries per second across all workers (0 = unpaced)")
+	fs.BoolVar(&cfg.Burst, "burst", cfg.Burst, "Periodically spike the search rate from --target-qps to --burst-qps")
+	fs.Float64Var(&cfg.BurstQPS, "burst-qps", cfg.BurstQPS, "Search rate during bursts")
+	fs.DurationVar(&cfg.BurstDuration, "burst-duration", cfg.BurstDuration, "Length of each burst")
+	fs.DurationVar(&cfg.BurstInterval, "burst-interval", cfg.BurstInterval, "Time at baseline rate between bursts")
+	fs.BoolVar(&cfg.FlushCompare, "flush-compare", cfg.FlushCompare, "Search before flush (growing segments) and after flush/index/load (sealed segments) and compare latency")
+	fs.IntVar(&cfg.CompareQueries, "compare-queries", cfg.CompareQueries, "Number of queries in each --flush-compare search batch")
+	fs.IntVar(&cfg.TTLSeconds, "ttl", cfg.TTLSeconds, "Collection data TTL in seconds, set at creation (0 = no expiry)")
+	fs.StringVar(&cfg.Consistency, "consistency", cfg.Consistency, "Default collection consistency level: strong, bounded, session, eventually")
+	fs.DurationVar(&cfg.ThrottleBackoff, "throttle-backoff", cfg.ThrottleBackoff, "Pause a worker for this long (doubling while throttled) after rate-limit/quota errors (0 = off)")
+	fs.BoolVar(&cfg.AutoID, "auto-id", cfg.AutoID, "Let Milvus generate primary keys (false = the tool assigns sequential keys)")
+	fs.StringVar(&cfg.PKStrategy, "pk-strategy", cfg.PKStrategy, "How primary keys are assigned with --auto-id=false: sequential, random, hashed")
+	fs.StringVar(&cfg.Checkpoint, "checkpoint", cfg.Checkpoint, "Record the progress of an 'insert' run in this file, so --resume can continue it")
+	fs.BoolVar(&cfg.Resume, "resume", cfg.Resume, "Continue the 'insert' run recorded in --checkpoint instead of starting over")
+	fs.IntVar(&cfg.IDPoolSize, "id-pool-size", cfg.IDPoolSize, "Number of recently inserted primary keys kept for by-key operations (0 = off)")
+	fs.IntVar(&opts.gomaxprocs, "gomaxprocs", 0, "Number of OS threads running Go code in the client (0 = Go runtime default)")
+	fs.StringVar(&cfg.QueryFile, "query-file", cfg.QueryFile, "Read search query vectors from this .fvecs file instead of generating random ones")
+	fs.StringVar(&cfg.GroundTruthFile, "ground-truth-file", cfg.GroundTruthFile, "Ground-truth neighbour IDs (.ivecs) of the --query-file queries, enables recall reporting")
+	fs.StringVar(&cfg.ExportVectors, "export-vectors", cfg.ExportVectors, "Write the inserted IDs and vectors to this file as newline-delimited JSON")
+	fs.StringVar(&cfg.EventStream, "event-stream", cfg.EventStream, "Write newline-delimited JSON progress events to this file (\"-\" = stdout)")
+	fs.IntVar(&cfg.Replicas, "replicas", cfg.Replicas, "Number of in-memory replicas to load the collection with")
+	fs.IntVar(&cfg.Connections, "connections", cfg.Connections, "Number of gRPC connections the insert and search workers are spread across round-robin")
+	fs.BoolVar(&cfg.Chart, "chart", cfg.Chart, "Draw insert and search throughput over time as a sparkline after the summary")
+	fs.IntVar(&cfg.FlushEvery, "flush-every-n-batches", cfg.FlushEvery, "Flush the test collections after every N insert batches across all workers (0 = only at the end)")
+	fs.StringVar(&cfg.BatchSweep, "batch-sweep", cfg.BatchSweep, "After the insert phase, insert at each of these comma-separated batch sizes into a fresh collection and report the fastest, e.g. 500,1000,2000,5000,10000")
+	fs.DurationVar(&cfg.BatchSweepTime, "batch-sweep-duration", cfg.BatchSweepTime, "How long --batch-sweep inserts at each batch size")
+	fs.DurationVar(&cfg.HotSegment, "hot-segment", cfg.HotSegment, "After the insert phase, insert into a single segment and into a spread-out collection for this long each and compare the throughput (0 = skip)")
+	fs.StringVar(&cfg.LoadOrder, "load-order", cfg.LoadOrder, "Order of the flush, index and load steps of the full command; load must follow index")
+	fs.StringVar(&cfg.FlushStrategy, "flush-strategy", cfg.FlushStrategy, "Flush the test collections with one request each (per-collection) or a single request for all (batched)")
+	fs.DurationVar(&cfg.LoadTimeout, "load-timeout", cfg.LoadTimeout, "Stop waiting for the load after this long and search what is loaded, with a warning")
+	fs.DurationVar(&cfg.FlushTimeout, "flush-timeout", cfg.FlushTimeout, "Give up if the flush has not persisted every sealed segment within this time")
+	fs.StringVar(&cfg.ScalarFields, "scalar-fields", cfg.ScalarFields, "Extra scalar fields filled with random values, as name:type[:key=value...],... (types: int64, float, varchar; varchar needs max_length)")
+	fs.StringVar(&cfg.ScalarGen, "scalar-gen", cfg.ScalarGen, "Value distributions of --scalar-fields fields, as field=kind[:args];... (enum:a,b,c, uniform:low,high, normal:mean,stddev, zipf[:s,n])")
+	fs.StringVar(&cfg.ScalarIndex, "scalar-index", cfg.ScalarIndex, "Build scalar indexes on --scalar-fields fields, as field:type,... (INVERTED, STL_SORT, TRIE, BITMAP), and compare filtered search before and after")
+	fs.IntVar(&cfg.DistinctVectors, "distinct-vectors", cfg.DistinctVectors, "Draw inserted vectors, with repetition, from this many distinct random vectors (0 = every vector random)")
+	fs.Float64Var(&cfg.MaxDataMB, "max-data-mb", cfg.MaxDataMB, "Insert until this much data (MB) is written instead of for --duration (0 = time bounded)")
+	fs.StringVar(&cfg.Metric, "metric", cfg.Metric, "Distance metric of the index and searches: L2, IP, COSINE")
+	fs.StringVar(&cfg.Normalize, "normalize", cfg.Normalize, "Insert unit-length vectors: auto (on for IP and COSINE), on, off")
+	fs.IntVar(&cfg.RecallCheck, "recall-check", cfg.RecallCheck, "After the search phase, verify this many queries against exact client-side search (0 = off)")
+	fs.IntVar(&cfg.Collections, "collections", cfg.Collections, "Number of test collections, with insert and search workers spread across them")
+	fs.IntVar(&cfg.AdminConcurrency, "admin-concurrency", cfg.AdminConcurrency, "Maximum number of collections flushed, indexed or loaded concurrently")
+	fs.StringVar(&cfg.VectorParams, "vector-params", cfg.VectorParams, "Extra type params of the embedding field, as key=value,...")
+	fs.StringVar(&cfg.IndexType, "index-type", cfg.IndexType, "Vector index built on the embedding field: IVF_FLAT or IVF_SQ8")
+	fs.StringVar(&cfg.IndexMix, "index-mix", cfg.IndexMix, "Index types spread over the --collections by weight, as type=weight,... (e.g. IVF_FLAT=1,IVF_SQ8=1)")
+	fs.StringVar(&cfg.IndexParams, "index-params", cfg.IndexParams, "Index build params overriding the defaults, as key=value,... (e.g. nlist=128)")
+	fs.IntVar(&cfg.Shards, "shards", cfg.Shards, "Shards per collection (0 = server default)")
+	fs.StringVar(&cfg.CollectionProps, "collection-properties", cfg.CollectionProps, "Extra collection properties set at creation, as key=value,...")
+	fs.StringVar(&cfg.Sweep, "sweep", cfg.Sweep, "After the search phase, search paced at each of these ascending rates (qps,qps,...) and report the latency curve")
+	fs.DurationVar(&cfg.SweepStep, "sweep-step", cfg.SweepStep, "Time spent at each --sweep rate and --topk-sweep value")
+	fs.StringVar(&cfg.TopKSweep, "topk-sweep", cfg.TopKSweep, "After the search phase, search with each of these ascending topK values (k,k,...) and report where latency climbs")
+	fs.BoolVar(&cfg.FindMaxQPS, "find-max-qps", cfg.FindMaxQPS, "After the search phase, search for the highest paced rate whose p99 stays within --find-max-qps-p99 without errors")
+	fs.DurationVar(&cfg.MaxQPSP99, "find-max-qps-p99", cfg.MaxQPSP99, "p99 latency bound of --find-max-qps")
+	fs.StringVar(&cfg.Percentiles, "percentiles", cfg.Percentiles, "Ascending insert and search latency percentiles to report (p,p,...), empty for none")
+	fs.BoolVar(&cfg.Iterator, "iterator", cfg.Iterator, "After the search phase, page through the results of --compare-queries query vectors with the search iterator")
+	fs.IntVar(&cfg.IteratorPageSize, "iterator-page-size", cfg.IteratorPageSize, "Hits per --iterator page")
+	fs.IntVar(&cfg.IteratorTotal, "iterator-total", cfg.IteratorTotal, "Hits --iterator fetches per query vector, fewer if the iterator runs out")
+	fs.StringVar(&cfg.SweepCSV, "sweep-csv", cfg.SweepCSV, "Also write the --sweep curve to this CSV file")
+	fs.StringVar(&cfg.Alias, "alias", cfg.Alias, "Create this alias for the test collection and route inserts and searches through it")
+	fs.BoolVar(&cfg.RebuildIndex, "rebuild-index", cfg.RebuildIndex, "Drop and recreate the index a third into the search phase and compare search latency before, during and after")
+	fs.IntVar(&cfg.FreshnessProbes, "freshness", cfg.FreshnessProbes, "After the search phase, insert this many probe rows one by one and measure how long each takes to become searchable (0 = off)")
+	fs.BoolVar(&cfg.TimeToSearchable, "time-to-searchable", cfg.TimeToSearchable, "After the bulk insert, insert a sentinel row and time how long until search returns it once flushed, indexed and loaded")
+	fs.BoolVar(&cfg.DDLStress, "ddl-stress", cfg.DDLStress, "Create and drop small collections for --duration instead of running the pipeline")
+	fs.StringVar(&cfg.Mix, "mix", cfg.Mix, "Run a weighted operation mix instead of the search phase, as op=weight,... (ops: insert, search, query, delete, upsert)")
+	fs.StringVar(&cfg.TimestampField, "timestamp-field", cfg.TimestampField, "Add an Int64 field holding each row's insert time in unix milliseconds")
+	fs.DurationVar(&cfg.TimestampSpread, "timestamp-spread", cfg.TimestampSpread, "Spread the --timestamp-field values at random over this much of the past (0 = exact insert time)")
+	fs.DurationVar(&cfg.TimeWindow, "time-window", cfg.TimeWindow, "After load, count the rows whose --timestamp-field falls in this window before the end of the inserts")
+	fs.DurationVar(&cfg.MaxRuntime, "max-runtime", cfg.MaxRuntime, "Hard ceiling on the whole run: stop the current phase, clean up and print a partial summary (0 = none)")
+	fs.Float64Var(&cfg.CostPerHour, "cost-per-hour", cfg.CostPerHour, "Hourly price of the Milvus deployment; the summary estimates the cost per million inserts and searches from it (0 = none)")
+	fs.IntVar(&cfg.LoadRepeats, "load-repeats", cfg.LoadRepeats, "After the first load, release and reload the collection this many times and report the load time distribution")
+	fs.IntVar(&cfg.SearchWarmup, "search-warmup", cfg.SearchWarmup, "Run this many unmeasured searches before the measured search phase (0 = none)")
+	fs.DurationVar(&cfg.PreSearchDelay, "pre-search-delay", cfg.PreSearchDelay, "Wait this long after the load before the search phase, the longest wait with --pre-search-settle (0 = none)")
+	fs.IntVar(&cfg.CanaryQueries, "canary-queries", cfg.CanaryQueries, "Fixed query vectors searched every --canary-interval during the search phase, their latency tracked apart (0 = none)")
+	fs.DurationVar(&cfg.CanaryInterval, "canary-interval", cfg.CanaryInterval, "How often each --canary-queries vector is searched")
+	fs.StringVar(&cfg.CanaryCSV, "canary-csv", cfg.CanaryCSV, "Also write the canary latency series to this CSV file")
+	fs.BoolVar(&cfg.PreSearchSettle, "pre-search-settle", cfg.PreSearchSettle, "End the --pre-search-delay wait once the index covers every row and the collection is fully loaded")
+	fs.DurationVar(&cfg.MaxSearchP99, "max-search-p99", cfg.MaxSearchP99, "SLA assertion: fail the run when the search p99 latency is above this (0 = none)")
+	fs.Float64Var(&cfg.MinSearchQPS, "min-search-qps", cfg.MinSearchQPS, "SLA assertion: fail the run when the search throughput is below this (0 = none)")
+	fs.StringVar(&cfg.VerifyFields, "verify-fields", cfg.VerifyFields, "Scalar fields every search returns, comma-separated or all, checked against the values the tool generates")
+	fs.BoolVar(&cfg.ValidateResults, "validate-results", cfg.ValidateResults, "Check every search returned hits, topK where the collection holds enough rows, and count those that did not")
+	fs.BoolVar(&cfg.Strict, "strict", cfg.Strict, "With --validate-results, fail the run when more than 1% of searches returned no results")
+	fs.StringVar(&cfg.ListFilter, "list-filter", cfg.ListFilter, "Glob of the collection names the list and cleanup-orphans commands consider, e.g. 'go_high_throughput_collection*' for the tool's own (default: all)")
+	fs.DurationVar(&cfg.OrphanAge, "orphan-age", cfg.OrphanAge, "cleanup-orphans only drops collections created longer ago than this")
+	fs.BoolVar(&cfg.Confirm, "confirm", cfg.Confirm, "Let cleanup-orphans drop the collections instead of only listing them")
+	fs.StringVar(&cfg.CleanupMode, "cleanup-mode", cfg.CleanupMode, "What cleanup does with the collection: drop, release (free memory, keep data) or none")
+	fs.StringVar(&opts.cpuProfile, "cpuprofile", "", "Write a CPU profile of the run to this file")
+	fs.StringVar(&opts.memProfile, "memprofile", "", "Write a heap profile to this file at the end of the run")
+	fs.StringVar(&opts.jsonPath, "summary-json", "", "Also write the run result as JSON to this file (\"-\" = stdout)")
+	fs.BoolVar(&opts.jsonOnly, "json-only", false, "Print only the run result as JSON on stdout, everything else goes to stderr")
+	fs.StringVar(&opts.webhookURL, "webhook-url", "", "POST the run result as JSON, with a pass/fail status, to this URL when the run finishes")
+	fs.StringVar(&opts.preset, "preset", "", "Fill the options not given on the command line or in the environment from this named preset in --presets-dir")
+	fs.StringVar(&opts.presetsDir, "presets-dir", defaultPresetsDir, "Directory holding the --preset files, one <name>.conf each")
+	fs.BoolVar(&opts.listPresets, "list-presets", false, "List the presets in --presets-dir and exit")
+	fs.BoolVar(&opts.showHelp, "help", false, "Show detailed help information")
+	return fs, &cfg, opts
+}
+
+// parseConfig parses the arguments of a subcommand and applies environment
+// overrides, then the --preset
+func parseConfig(name string, args []string) (*loadtest.Config, *cliOptions, error) {
+	fs, cfg, opts := newFlagSet(name)
+	// Parse errors are reported by the caller, -h falls back to the detailed help
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	if err := applyEnvOverrides(fs); err != nil {
+		return nil, nil, fmt.Errorf("invalid environment configuration: %w", err)
+	}
+	if err := applyPreset(fs, opts.presetsDir, opts.preset); err != nil {
+		return nil, nil, fmt.Errorf("invalid preset: %w", err)
+	}
+	if opts.gomaxprocs < 0 {
+		return nil, nil, fmt.Errorf("invalid --gomaxprocs %d: must be >= 0", opts.gomaxprocs)
+	}
+	if opts.jsonOnly && opts.jsonPath == "-" {
+		return nil, nil, fmt.Errorf("--summary-json - cannot be combined with --json-only, which already writes the JSON to stdout")
+	}
+	if opts.webhookURL != "" {
+		if err := checkWebhookURL(opts.webhookURL); err != nil {
+			return nil, nil, fmt.Errorf("invalid --webhook-url: %w", err)
+		}
+	}
+	if opts.jsonOnly && cfg.EventStream == "-" {
+		return nil, nil, fmt.Errorf("--event-stream - cannot be combined with --json-only, stdout only carries the result")
+	}
+	return cfg, opts, nil
+}
@@ -0,0 +1,102 @@
+package loadtest
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// aggregatorBuffer is how many results the workers can queue ahead of the aggregator
+const aggregatorBuffer = 1024
+
+// insertResult is what an insert worker reports about one batch
+type insertResult struct {
+	rows        int
+	acked       int   // rows Milvus acknowledged, fewer than rows on a partial insert
+	first       int64 // row number of the first key assigned, without AutoID
+	scalarBytes int64
+	attempts    int
+	throttled   int
+	paused      time.Duration // throttle pause taken after the batch
+	at          time.Duration // since the start of the phase
+	addr        int           // index of the --milvus-addr that served the batch
+	worker      int
+	conn        int        // index of the connection the batch went through
+	took        workerTime // generating the batch and inserting it
+	fault       int        // 1 + the kind of a --fault-injection-rate batch, 0 for a regular one
+	err         error
+}
+
+// searchResult is what a search worker reports about one search
+type searchResult struct {
+	conn       int
+	collection int // index of the collection searched, in lt.collections
+	start      time.Time
+	latency    time.Duration
+	slot       time.Duration // pacing slot, places the search in the burst schedule
+	attempts   int
+	throttled  int
+	paused     time.Duration
+	addr       int
+	err        error
+	queryIndex int
+	ids        []int64 // top hits, only for recall against the ground truth
+	hits       int     // results returned, only with --validate-results
+
+	fieldChecks []FieldCheck // output field values checked, only with --verify-fields
+}
+
+// aggregator owns the metrics of a running phase. Workers send it one result per
+// operation and a single goroutine folds them into the metrics, so the hot path
+// takes no lock and every counter, latency list and progress line of the phase is
+// maintained in one place. The same goroutine samples the totals for the event
+// stream, --chart and the throughput stability. The totals are also published for workers that need a
+// live view of the phase, such as ramp-up progress.
+type aggregator[T any] struct {
+	results    chan T
+	done       chan struct{}
+	operations atomic.Int64
+	failures   atomic.Int64
+}
+
+// startAggregator starts the aggregator goroutine. apply folds one result into the
+// metrics and returns the phase's running operation and failure totals; sample, if
+// not nil, is called with them every sampleInterval.
+func startAggregator[T any](apply func(T) (operations, failures int64), sample func(now time.Time, operations, failures int64)) *aggregator[T] {
+	a := &aggregator[T]{results: make(chan T, aggregatorBuffer), done: make(chan struct{})}
+	go func() {
+		defer close(a.done)
+		var ticks <-chan time.Time
+		if sample != nil {
+			ticker := time.NewTicker(sampleInterval)
+			defer ticker.Stop()
+			ticks = ticker.C
+		}
+		var operations, failures int64
+		for {
+			select {
+			case result, ok := <-a.results:
+				if !ok {
+					return
+				}
+				operations, failures = apply(result)
+				a.operations.Store(operations)
+				a.failures.Store(failures)
+			case now := <-ticks:
+				sample(now, operations, failures)
+			}
+		}
+	}()
+	return a
+}
+
+// send hands one operation result to the aggregator
+func (a *aggregator[T]) send(result T) {
+	a.results <- result
+}
+
+// close waits for the aggregator to apply every result sent. Only call it once
+// all workers are done sending; the metrics belong to the caller again afterwards.
+func (a *aggregator[T]) close() {
+	close(a.results)
+	<-a.done
+}
@@ -0,0 +1,70 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// aliasCleanupTimeout bounds dropping the alias when the run ends
+const aliasCleanupTimeout = 10 * time.Second
+
+// dataTarget returns the name a worker's inserts and searches go through: the
+// --alias when set, otherwise the worker's collection
+func (lt *loadTest) dataTarget(worker int) string {
+	if lt.cfg.Alias != "" {
+		return lt.cfg.Alias
+	}
+	return lt.collections[worker%len(lt.collections)]
+}
+
+// createAlias points the --alias at the test collection, replacing an alias of
+// the same name left over by an earlier run
+func (lt *loadTest) createAlias(ctx context.Context) error {
+	if lt.cfg.Alias == "" {
+		return nil
+	}
+	_ = lt.client.DropAlias(ctx, lt.cfg.Alias) // usually does not exist
+	if err := lt.client.CreateAlias(ctx, lt.collections[0], lt.cfg.Alias); err != nil {
+		return fmt.Errorf("failed to create alias '%s': %w", lt.cfg.Alias, err)
+	}
+	lt.aliasCreated = true
+	fmt.Printf("✅ Alias '%s' -> '%s' created, inserts and searches go through the alias.\n", lt.cfg.Alias, lt.collections[0])
+	return nil
+}
+
+// dropAlias removes the alias created by createAlias, if any
+func (lt *loadTest) dropAlias(ctx context.Context) error {
+	if !lt.aliasCreated {
+		return nil
+	}
+	if err := lt.client.DropAlias(ctx, lt.cfg.Alias); err != nil {
+		return fmt.Errorf("failed to drop alias '%s': %w", lt.cfg.Alias, err)
+	}
+	lt.aliasCreated = false
+	fmt.Printf("Alias '%s' dropped.\n", lt.cfg.Alias)
+	return nil
+}
+
+// aliasCompare times the same search batch through the collection name and
+// through the alias, to show what the alias redirection costs (step 7e)
+func (lt *loadTest) aliasCompare(ctx context.Context) error {
+	if lt.cfg.Alias == "" {
+		return nil
+	}
+	fmt.Printf("\n--- Step 7e: Search through collection name vs alias (%d queries each) ---\n", lt.cfg.CompareQueries)
+	var err error
+	if lt.m.directLatency, err = timeSearchBatch(ctx, lt.client, lt.collections[0], lt.cfg.metricType, lt.cfg.CompareQueries); err != nil {
+		return fmt.Errorf("failed to search by collection name: %w", err)
+	}
+	if lt.m.aliasLatency, err = timeSearchBatch(ctx, lt.client, lt.cfg.Alias, lt.cfg.metricType, lt.cfg.CompareQueries); err != nil {
+		return fmt.Errorf("failed to search through alias: %w", err)
+	}
+	direct, alias := lt.m.directLatency, lt.m.aliasLatency
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Target", "Avg", "p50", "p95", "p99")
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Collection", direct.avg, direct.p50, direct.p95, direct.p99)
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Alias", alias.avg, alias.p50, alias.p95, alias.p99)
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Overhead", alias.avg-direct.avg, alias.p50-direct.p50, alias.p95-direct.p95, alias.p99-direct.p99)
+	fmt.Println("✅ Alias comparison complete.")
+	return nil
+}
@@ -0,0 +1,26 @@
+package loadtest
+
+import "fmt"
+
+// assertionFailures checks the completed run against the --max-search-p99 and
+// --min-search-qps limits, the empty result rate under --strict and the
+// malformed batches of --fault-injection-rate Milvus accepted, and describes
+// every one it missed
+func (r *Result) assertionFailures(cfg *Config) []string {
+	var failures []string
+	if cfg.MaxSearchP99 > 0 && r.SearchLatencyP99 > cfg.MaxSearchP99 {
+		failures = append(failures, fmt.Sprintf("search p99 %s exceeds --max-search-p99 %s", r.SearchLatencyP99, cfg.MaxSearchP99))
+	}
+	if cfg.MinSearchQPS > 0 && r.SearchesPerSec < cfg.MinSearchQPS {
+		failures = append(failures, fmt.Sprintf("search throughput %.2f/s is below --min-search-qps %g", r.SearchesPerSec, cfg.MinSearchQPS))
+	}
+	if cfg.Strict && r.EmptyResultRate > maxEmptyResultRate {
+		failures = append(failures, fmt.Sprintf("%.2f%% of searches returned no results, above the --strict limit of %g%%", r.EmptyResultRate, maxEmptyResultRate))
+	}
+	for _, f := range r.Faults {
+		if f.Accepted > 0 {
+			failures = append(failures, fmt.Sprintf("Milvus accepted %d of %d malformed %s batches of --fault-injection-rate", f.Accepted, f.Injected, f.Kind))
+		}
+	}
+	return failures
+}
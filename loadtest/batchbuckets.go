@@ -0,0 +1,81 @@
+package loadtest
+
+import (
+	"maps"
+	"math/bits"
+	"slices"
+	"time"
+)
+
+// BatchSizeBucket is the insert latency of the batches whose size fell in
+// [MinRows, MaxRows], a power-of-two range
+type BatchSizeBucket struct {
+	MinRows    int           `json:"min_rows"`
+	MaxRows    int           `json:"max_rows"`
+	Batches    int           `json:"batches"`
+	RowsPerSec float64       `json:"rows_per_sec"` // per Insert call, rows over the time spent in it
+	LatencyAvg time.Duration `json:"latency_avg_ns"`
+	LatencyP50 time.Duration `json:"latency_p50_ns"`
+	LatencyP99 time.Duration `json:"latency_p99_ns"`
+}
+
+// batchBucket collects the insert latencies of one batch-size range
+type batchBucket struct {
+	rows      int64
+	latencies []time.Duration
+}
+
+// batchBucketFloor returns the smallest batch size of the bucket rows falls in:
+// the largest power of two not above it
+func batchBucketFloor(rows int) int {
+	if rows < 1 {
+		return 0
+	}
+	return 1 << (bits.Len(uint(rows)) - 1)
+}
+
+// addBatchLatency records the time an inserted batch of rows spent in Insert calls
+func (m *metrics) addBatchLatency(rows int, latency time.Duration) {
+	if m.batchBuckets == nil {
+		m.batchBuckets = make(map[int]*batchBucket)
+	}
+	floor := batchBucketFloor(rows)
+	b := m.batchBuckets[floor]
+	if b == nil {
+		b = &batchBucket{}
+		m.batchBuckets[floor] = b
+	}
+	b.rows += int64(rows)
+	b.latencies = append(b.latencies, latency)
+}
+
+// insertPercentiles returns the --percentiles of the insert latency of all batches
+func (m *metrics) insertPercentiles(ps []float64) []LatencyPercentile {
+	var latencies []time.Duration
+	for _, b := range m.batchBuckets {
+		latencies = append(latencies, b.latencies...)
+	}
+	return percentilesOf(latencies, ps)
+}
+
+// batchSizeBuckets returns the insert latency per batch-size bucket, smallest first
+func (m *metrics) batchSizeBuckets() []BatchSizeBucket {
+	var buckets []BatchSizeBucket
+	for _, floor := range slices.Sorted(maps.Keys(m.batchBuckets)) {
+		b := m.batchBuckets[floor]
+		latency := summarizeLatencies(b.latencies)
+		bucket := BatchSizeBucket{
+			MinRows:    floor,
+			MaxRows:    2*floor - 1,
+			Batches:    latency.count,
+			LatencyAvg: latency.avg,
+			LatencyP50: latency.p50,
+			LatencyP99: latency.p99,
+		}
+		if total := latency.avg * time.Duration(latency.count); total > 0 {
+			bucket.RowsPerSec = float64(b.rows) / total.Seconds()
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
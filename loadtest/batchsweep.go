@@ -0,0 +1,120 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// batchSweepCollection is the collection --batch-sweep inserts into, created
+// afresh for every batch size
+const batchSweepCollection = collectionName + "_batch_sweep"
+
+// BatchSweepResult is the insert throughput --batch-sweep measured at one batch size
+type BatchSweepResult struct {
+	BatchSize       int           `json:"batch_size"`
+	VectorsInserted int64         `json:"vectors_inserted"`
+	VectorsPerSec   float64       `json:"vectors_per_sec"`
+	FailedBatches   int64         `json:"failed_batches"`
+	LatencyP50      time.Duration `json:"latency_p50_ns"`
+	LatencyP99      time.Duration `json:"latency_p99_ns"`
+	Best            bool          `json:"best"` // the highest vectors/sec of the sweep
+}
+
+// parseBatchSweep parses --batch-sweep, a comma-separated list of at least two
+// distinct batch sizes, kept in the order given
+func parseBatchSweep(spec string) ([]int, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var sizes []int
+	for _, part := range strings.Split(spec, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("batch size %q: must be an integer > 0", part)
+		}
+		if slices.Contains(sizes, size) {
+			return nil, fmt.Errorf("batch size %d given twice", size)
+		}
+		sizes = append(sizes, size)
+	}
+	if len(sizes) < 2 {
+		return nil, fmt.Errorf("want at least two batch sizes to compare, got %q", spec)
+	}
+	return sizes, nil
+}
+
+// batchSweep finds the insert batch size with the highest throughput: after the
+// insert phase, all insert workers insert for --batch-sweep-duration at each
+// --batch-sweep batch size, every size into a fresh collection with the run's
+// schema and shards, so earlier sizes leave no segments behind to slow later
+// ones down (step 4d)
+func (lt *loadTest) batchSweep(ctx context.Context) error {
+	cfg := lt.cfg
+	if len(cfg.batchSweep) == 0 {
+		return nil
+	}
+	fmt.Printf("\n--- Step 4d: Batch size sweep: %d sizes, %s each, %d workers ---\n", len(cfg.batchSweep), cfg.BatchSweepTime, cfg.numWorkers)
+	shards := entity.DefaultShardNumber
+	if cfg.Shards > 0 {
+		shards = int32(cfg.Shards)
+	}
+	lt.phaseStart("batch-sweep")
+	start := time.Now()
+	for _, size := range cfg.batchSweep {
+		drop, err := lt.freshCollection(ctx, batchSweepCollection, int(shards))
+		if err != nil {
+			return fmt.Errorf("failed the --batch-sweep batch size %d: %w", size, err)
+		}
+		inserted, elapsed, snapshot := lt.insertBurst(ctx, "batch-sweep", "Sweep", batchSweepCollection, []string{""}, size, cfg.BatchSweepTime)
+		drop()
+		result := BatchSweepResult{
+			BatchSize:       size,
+			VectorsInserted: inserted,
+			VectorsPerSec:   float64(inserted) / elapsed.Seconds(),
+			FailedBatches:   snapshot.Failures,
+			LatencyP50:      snapshot.Latency.P50,
+			LatencyP99:      snapshot.Latency.P99,
+		}
+		lt.m.batchSweep = append(lt.m.batchSweep, result)
+		fmt.Printf("   -> batch %-6d %.2f vectors/s, batch p50 %s, p99 %s, %d failed batches\n",
+			size, result.VectorsPerSec, result.LatencyP50, result.LatencyP99, result.FailedBatches)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	lt.phaseEnd("batch-sweep", time.Since(start))
+	best := lt.m.markBestBatchSize()
+	fmt.Printf("\n   %-10s %14s %12s %12s %10s\n", "Batch", "Vectors/sec", "p50", "p99", "Failed")
+	for _, r := range lt.m.batchSweep {
+		mark := ""
+		if r.Best {
+			mark = "  <- best"
+		}
+		fmt.Printf("   %-10d %14.2f %12s %12s %10d%s\n", r.BatchSize, r.VectorsPerSec, r.LatencyP50, r.LatencyP99, r.FailedBatches, mark)
+	}
+	fmt.Printf("✅ Batch size sweep complete: %d rows per insert is the fastest at %.2f vectors/s.\n", best.BatchSize, best.VectorsPerSec)
+	return nil
+}
+
+// markBestBatchSize flags and returns the --batch-sweep result with the highest
+// throughput; ties go to the smaller batch, which has the lower latency
+func (m *metrics) markBestBatchSize() BatchSweepResult {
+	best := -1
+	for i, r := range m.batchSweep {
+		if best < 0 || r.VectorsPerSec > m.batchSweep[best].VectorsPerSec ||
+			(r.VectorsPerSec == m.batchSweep[best].VectorsPerSec && r.BatchSize < m.batchSweep[best].BatchSize) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return BatchSweepResult{}
+	}
+	m.batchSweep[best].Best = true
+	return m.batchSweep[best]
+}
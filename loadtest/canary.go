@@ -0,0 +1,157 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// CanarySample is one search of a --canary-queries vector
+type CanarySample struct {
+	Canary  int           `json:"canary"`
+	Elapsed time.Duration `json:"elapsed_ns"` // since the start of the search phase
+	Latency time.Duration `json:"latency_ns"`
+	Failed  bool          `json:"failed"`
+}
+
+// CanaryResult summarizes the searches of one canary vector. Drift is the median
+// latency of the last quarter of its searches over that of the first quarter: 1
+// for a stable server, above 1 when the same query got slower during the run.
+type CanaryResult struct {
+	Canary     int           `json:"canary"`
+	Searches   int           `json:"searches"`
+	Failures   int           `json:"failures"`
+	LatencyP50 time.Duration `json:"latency_p50_ns"`
+	LatencyP99 time.Duration `json:"latency_p99_ns"`
+	LatencyMax time.Duration `json:"latency_max_ns"`
+	Drift      float64       `json:"drift"` // 0 with fewer than 4 searches
+}
+
+// canaryVectors returns the --canary-queries query vectors: the first of the
+// --query-file when there is one, random otherwise. They are drawn once, so every
+// canary search of the run sends the same vector.
+func (lt *loadTest) canaryVectors() [][]entity.Vector {
+	vectors := make([][]entity.Vector, lt.cfg.CanaryQueries)
+	for i := range vectors {
+		if lt.queries != nil && i < len(lt.queries.vectors) {
+			vectors[i] = []entity.Vector{entity.FloatVector(lt.queries.vectors[i])}
+		} else {
+			vectors[i] = randomQueryVector()
+		}
+	}
+	return vectors
+}
+
+// canaries searches each canary vector in turn every --canary-interval until end,
+// the start of a --cool-down, next to the search workers and on the first
+// connection, recording every latency.
+// The canaries run one at a time and without retries, so their latency is that of
+// the server under the phase's load, free of client queuing.
+func (lt *loadTest) canaries(ctx context.Context, start, end time.Time) {
+	cfg := lt.cfg
+	vectors := lt.canaryVectors()
+	collection := lt.dataTarget(0)
+	ticker := time.NewTicker(cfg.CanaryInterval)
+	defer ticker.Stop()
+	for {
+		for i, vector := range vectors {
+			searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+			conn, ref := lt.conn(0)
+			opStart := time.Now()
+			_, err := conn.Search(ctx, collection, []string{}, "", []string{}, vector, embeddingField, cfg.metricType, searchTopK, searchParams)
+			latency := time.Since(opStart)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				lt.failover(ctx, ref, err)
+			}
+			lt.m.canarySeries = append(lt.m.canarySeries, CanarySample{Canary: i, Elapsed: opStart.Sub(start), Latency: latency, Failed: err != nil})
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if !now.Before(end) {
+				return
+			}
+		}
+	}
+}
+
+// summarizeCanaries folds the canary series into one result per canary
+func (m *metrics) summarizeCanaries(canaries int) {
+	latencies := make([][]time.Duration, canaries)
+	failures := make([]int, canaries)
+	for _, s := range m.canarySeries {
+		if s.Failed {
+			failures[s.Canary]++
+			continue
+		}
+		latencies[s.Canary] = append(latencies[s.Canary], s.Latency)
+	}
+	m.canaryResults = make([]CanaryResult, canaries)
+	for i, l := range latencies {
+		r := CanaryResult{Canary: i, Failures: failures[i]}
+		// the quarters are taken in search order, before summarizing sorts l
+		if quarter := len(l) / 4; quarter > 0 {
+			first, last := summarizeLatencies(slices.Clone(l[:quarter])), summarizeLatencies(slices.Clone(l[len(l)-quarter:]))
+			if first.p50 > 0 {
+				r.Drift = float64(last.p50) / float64(first.p50)
+			}
+		}
+		summary := summarizeLatencies(l)
+		r.Searches, r.LatencyP50, r.LatencyP99, r.LatencyMax = summary.count, summary.p50, summary.p99, summary.max
+		m.canaryResults[i] = r
+	}
+}
+
+// printCanaries summarizes and prints the canary latencies of the search phase
+// and writes their series to --canary-csv
+func (lt *loadTest) printCanaries() {
+	cfg := lt.cfg
+	lt.m.summarizeCanaries(cfg.CanaryQueries)
+	fmt.Printf("   %-8s %10s %10s %12s %12s %12s %8s\n", "Canary", "Searches", "Failures", "p50", "p99", "Max", "Drift")
+	for _, r := range lt.m.canaryResults {
+		fmt.Printf("   %-8d %10d %10d %12s %12s %12s %7.2fx\n", r.Canary, r.Searches, r.Failures, r.LatencyP50, r.LatencyP99, r.LatencyMax, r.Drift)
+	}
+	if cfg.CanaryCSV == "" {
+		return
+	}
+	if err := writeCanaryCSV(cfg.CanaryCSV, lt.m.canarySeries); err != nil {
+		fmt.Printf("⚠️  --canary-csv: writing %s failed: %v\n", cfg.CanaryCSV, err)
+		return
+	}
+	fmt.Printf("   -> Canary series written to %s\n", cfg.CanaryCSV)
+}
+
+// writeCanaryCSV writes the canary series, one row per search, with times in
+// seconds since the start of the search phase and latencies in milliseconds
+func writeCanaryCSV(path string, series []CanarySample) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.Write([]string{"elapsed_s", "canary", "latency_ms", "failed"})
+	for _, s := range series {
+		w.Write([]string{
+			strconv.FormatFloat(s.Elapsed.Seconds(), 'f', 3, 64),
+			strconv.Itoa(s.Canary),
+			strconv.FormatFloat(float64(s.Latency)/float64(time.Millisecond), 'f', 3, 64),
+			strconv.FormatBool(s.Failed),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
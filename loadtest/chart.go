@@ -0,0 +1,131 @@
+package loadtest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// sampleInterval is how often running insert and search phases are sampled
+const sampleInterval = time.Second
+
+// chartWidth is the maximum number of columns of a sparkline
+const chartWidth = 60
+
+// sparkLevels are the bar heights of a sparkline, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// sampler returns the sample function of a phase aggregator: it emits snapshot
+// events, records the throughput series drawn by --chart and summarized by its
+// coefficient of variation, and the operation count the server metrics scraper
+// correlates with
+func (lt *loadTest) sampler(phase string, start time.Time, series *throughputSeries) func(now time.Time, operations, failures int64) {
+	return func(now time.Time, operations, failures int64) {
+		lt.clientOps.Store(operations)
+		lt.events.snapshot(phase, now, now.Sub(start), operations, failures)
+		series.add(operations)
+	}
+}
+
+// throughputSeries is the per-interval rate of a monotonically growing counter
+type throughputSeries struct {
+	rates []float64
+	last  int64
+}
+
+// add records the counter's current total
+func (s *throughputSeries) add(total int64) {
+	s.rates = append(s.rates, float64(total-s.last)/sampleInterval.Seconds())
+	s.last = total
+}
+
+// The coefficient of variation below which a phase's throughput is steady, and
+// at or above which it is unstable; in between it is variable
+const (
+	steadyCV   = 0.1
+	unstableCV = 0.25
+)
+
+// cv returns the coefficient of variation of the per-interval rates, their
+// population standard deviation over their mean, and false with fewer than two
+// intervals or none with any throughput
+func (s *throughputSeries) cv() (float64, bool) {
+	if len(s.rates) < 2 {
+		return 0, false
+	}
+	mean := 0.0
+	for _, r := range s.rates {
+		mean += r
+	}
+	mean /= float64(len(s.rates))
+	if mean == 0 {
+		return 0, false
+	}
+	variance := 0.0
+	for _, r := range s.rates {
+		variance += (r - mean) * (r - mean)
+	}
+	return math.Sqrt(variance/float64(len(s.rates))) / mean, true
+}
+
+// stability describes the throughput stability of the series for the summary
+func (s *throughputSeries) stability() string {
+	cv, ok := s.cv()
+	if !ok {
+		return "n/a (under two intervals)"
+	}
+	verdict := "variable"
+	switch {
+	case cv < steadyCV:
+		verdict = "steady"
+	case cv >= unstableCV:
+		verdict = "unstable"
+	}
+	return fmt.Sprintf("%.3f CV, %s (%d intervals of %s)", cv, verdict, len(s.rates), sampleInterval)
+}
+
+// sparkline renders rates as a single line of block characters scaled between
+// their minimum and maximum, averaging neighbouring samples into at most width columns
+func sparkline(rates []float64, width int) string {
+	if len(rates) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			from, to := i*len(rates)/width, (i+1)*len(rates)/width
+			sum := 0.0
+			for _, r := range rates[from:to] {
+				sum += r
+			}
+			buckets[i] = sum / float64(to-from)
+		}
+		rates = buckets
+	}
+
+	low, high := rates[0], rates[0]
+	for _, r := range rates {
+		low, high = math.Min(low, r), math.Max(high, r)
+	}
+	var b strings.Builder
+	for _, r := range rates {
+		level := len(sparkLevels) - 1
+		if high > low {
+			level = int((r - low) / (high - low) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// printChart prints the throughput sparkline of a phase
+func printChart(title, unit string, series *throughputSeries, phaseTime time.Duration) {
+	if len(series.rates) == 0 {
+		return
+	}
+	low, high := series.rates[0], series.rates[0]
+	for _, r := range series.rates {
+		low, high = math.Min(low, r), math.Max(high, r)
+	}
+	fmt.Printf("\n%s (%s, %s):\n", title, unit, phaseTime.Round(time.Second))
+	fmt.Printf("  %s\n", sparkline(series.rates, chartWidth))
+	fmt.Printf("  min %.1f  max %.1f\n", low, high)
+}
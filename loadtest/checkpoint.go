@@ -0,0 +1,153 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// checkpointInterval is how often the insert phase rewrites the --checkpoint file
+const checkpointInterval = 5 * time.Second
+
+// checkpoint is the --checkpoint file: how far an insert run got, so a --resume
+// run assigns the keys that follow instead of starting over
+type checkpoint struct {
+	Collections   []string  `json:"collections"`
+	PKStrategy    string    `json:"pk_strategy"`
+	NextRow       int64     `json:"next_row"`       // every row numbered below was attempted
+	RowsInserted  int64     `json:"rows_inserted"`  // of which were inserted
+	BytesInserted int64     `json:"bytes_inserted"` // raw vector and scalar bytes of those rows
+	Updated       time.Time `json:"updated"`
+}
+
+// readCheckpoint loads the checkpoint at path
+func readCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cp, nil
+}
+
+// write replaces the checkpoint at path. The file is written aside and renamed
+// over the old one, so an interruption never leaves a truncated checkpoint.
+func (cp *checkpoint) write(path string) error {
+	cp.Updated = time.Now()
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// rowWatermark follows the insert batches, which complete out of order, and
+// advances the checkpoint past the rows that were all attempted
+type rowWatermark struct {
+	cp   checkpoint
+	done map[int64]batchOutcome // completed batches above NextRow, by first row
+	last time.Time              // last checkpoint write
+}
+
+// batchOutcome is how one batch of rows ended
+type batchOutcome struct {
+	rows     int64
+	inserted bool
+	bytes    int64
+}
+
+// complete records the batch of rows numbered from first
+func (w *rowWatermark) complete(first int64, outcome batchOutcome) {
+	w.done[first] = outcome
+	for {
+		next, ok := w.done[w.cp.NextRow]
+		if !ok {
+			return
+		}
+		delete(w.done, w.cp.NextRow)
+		w.cp.NextRow += next.rows
+		if next.inserted {
+			w.cp.RowsInserted += next.rows
+			w.cp.BytesInserted += next.bytes
+		}
+	}
+}
+
+// save writes the checkpoint if checkpointInterval has passed, or always if force
+func (w *rowWatermark) save(path string, force bool) {
+	if !force && time.Since(w.last) < checkpointInterval {
+		return
+	}
+	w.last = time.Now()
+	if err := w.cp.write(path); err != nil {
+		fmt.Printf("⚠️  Failed to write checkpoint %s: %v\n", path, err)
+	}
+}
+
+// newRowWatermark starts the checkpoint of this run, from a resumed one if any
+func (lt *loadTest) newRowWatermark() *rowWatermark {
+	w := &rowWatermark{
+		cp:   checkpoint{Collections: lt.collections, PKStrategy: lt.cfg.PKStrategy},
+		done: map[int64]batchOutcome{},
+		last: time.Now(),
+	}
+	if lt.resumed != nil {
+		w.cp.NextRow = lt.resumed.NextRow
+		w.cp.RowsInserted = lt.resumed.RowsInserted
+		w.cp.BytesInserted = lt.resumed.BytesInserted
+	}
+	return w
+}
+
+// volumeInserted returns the raw bytes inserted towards --max-data-mb, including
+// those of the run this one resumed
+func (lt *loadTest) volumeInserted() int64 {
+	if lt.resumed == nil {
+		return lt.m.insertedBytes()
+	}
+	return lt.m.insertedBytes() + lt.resumed.BytesInserted
+}
+
+// resumeInsert continues the insert run recorded in the --checkpoint file: it
+// reuses the collections and assigns keys from the first row not yet attempted,
+// then flushes and indexes as usual
+func (lt *loadTest) resumeInsert(ctx context.Context) error {
+	cp, err := readCheckpoint(lt.cfg.Checkpoint)
+	if err != nil {
+		return withCategory(ErrConfig, fmt.Errorf("invalid --resume: %w", err))
+	}
+	if !slices.Equal(cp.Collections, lt.collections) || cp.PKStrategy != lt.cfg.PKStrategy {
+		return withCategory(ErrConfig, fmt.Errorf("invalid --resume: %s records collections %v with --pk-strategy %s, this run has %v with %s",
+			lt.cfg.Checkpoint, cp.Collections, cp.PKStrategy, lt.collections, lt.cfg.PKStrategy))
+	}
+	if err := lt.useExisting(ctx); err != nil {
+		return err
+	}
+	if primaryKeyAutoID(lt.schema) {
+		return withCategory(ErrConfig, fmt.Errorf("invalid --resume: collection '%s' uses AutoID, its keys cannot be continued", lt.collections[0]))
+	}
+	lt.resumed = cp
+	lt.nextID.Store(cp.NextRow)
+	fmt.Printf("\n⏯️  RESUMING from %s: %d rows inserted, keys continue from row %d (checkpoint of %s)\n",
+		lt.cfg.Checkpoint, cp.RowsInserted, cp.NextRow, cp.Updated.Format(time.RFC3339))
+
+	if target := int64(lt.cfg.MaxDataMB * 1024 * 1024); target > 0 && cp.BytesInserted >= target {
+		fmt.Printf("Data volume of %.2f MB already reached, nothing left to insert.\n", lt.cfg.MaxDataMB)
+	} else {
+		lt.insert(ctx)
+	}
+	if err := lt.flush(ctx); err != nil {
+		return err
+	}
+	return lt.createIndex(ctx)
+}
@@ -0,0 +1,39 @@
+package loadtest
+
+import "testing"
+
+func TestRowWatermarkOutOfOrder(t *testing.T) {
+	w := (&loadTest{cfg: &Config{}}).newRowWatermark()
+	steps := []struct {
+		first                   int64
+		outcome                 batchOutcome
+		nextRow, rows, byteSize int64
+	}{
+		{100, batchOutcome{rows: 100, inserted: true, bytes: 1000}, 0, 0, 0}, // waits for 0
+		{300, batchOutcome{rows: 50, inserted: true, bytes: 500}, 0, 0, 0},   // waits for 0 and 200
+		{0, batchOutcome{rows: 100, inserted: true, bytes: 1000}, 200, 200, 2000},
+		{200, batchOutcome{rows: 100, inserted: false, bytes: 1000}, 350, 250, 2500}, // failed, attempted only
+		{350, batchOutcome{rows: 10, inserted: false, bytes: 100}, 360, 250, 2500},
+		{400, batchOutcome{rows: 20, inserted: true, bytes: 200}, 360, 250, 2500}, // waits for 360
+		{360, batchOutcome{rows: 40, inserted: true, bytes: 400}, 420, 310, 3100},
+	}
+	for _, s := range steps {
+		w.complete(s.first, s.outcome)
+		if w.cp.NextRow != s.nextRow || w.cp.RowsInserted != s.rows || w.cp.BytesInserted != s.byteSize {
+			t.Fatalf("after the batch from row %d: NextRow, RowsInserted, BytesInserted = %d, %d, %d, want %d, %d, %d",
+				s.first, w.cp.NextRow, w.cp.RowsInserted, w.cp.BytesInserted, s.nextRow, s.rows, s.byteSize)
+		}
+	}
+	if len(w.done) != 0 {
+		t.Errorf("%d batches left above NextRow, want none", len(w.done))
+	}
+}
+
+func TestRowWatermarkResumes(t *testing.T) {
+	resumed := &checkpoint{NextRow: 500, RowsInserted: 450, BytesInserted: 4500}
+	w := (&loadTest{cfg: &Config{}, resumed: resumed}).newRowWatermark()
+	w.complete(500, batchOutcome{rows: 100, inserted: true, bytes: 1000})
+	if w.cp.NextRow != 600 || w.cp.RowsInserted != 550 || w.cp.BytesInserted != 5500 {
+		t.Errorf("NextRow, RowsInserted, BytesInserted = %d, %d, %d, want 600, 550, 5500", w.cp.NextRow, w.cp.RowsInserted, w.cp.BytesInserted)
+	}
+}
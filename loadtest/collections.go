@@ -0,0 +1,68 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// collectionNames returns the names of the n test collections. A single
+// collection keeps the historical name so existing 'insert'/'search' setups
+// still find it.
+func collectionNames(n int) []string {
+	if n == 1 {
+		return []string{collectionName}
+	}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s_%d", collectionName, i)
+	}
+	return names
+}
+
+// forEachCollection runs fn for every test collection, at most
+// --admin-concurrency at a time, and returns how long each call took, indexed
+// like lt.collections. All calls run to completion; the first error is returned.
+func (lt *loadTest) forEachCollection(ctx context.Context, fn func(ctx context.Context, name string) error) ([]time.Duration, error) {
+	timings := make([]time.Duration, len(lt.collections))
+	errs := make([]error, len(lt.collections))
+	slots := make(chan struct{}, lt.cfg.AdminConcurrency)
+	var wg sync.WaitGroup
+	for i, name := range lt.collections {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			start := time.Now()
+			errs[i] = fn(ctx, name)
+			timings[i] = time.Since(start)
+		}(i, name)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			if len(lt.collections) > 1 {
+				return timings, fmt.Errorf("collection '%s': %w", lt.collections[i], err)
+			}
+			return timings, err
+		}
+	}
+	return timings, nil
+}
+
+// printCollectionTimings prints how long a phase took on each collection when
+// there is more than one
+func (lt *loadTest) printCollectionTimings(timings []time.Duration) {
+	if len(lt.collections) < 2 {
+		return
+	}
+	var sum time.Duration
+	fmt.Printf("   %-40s %12s\n", "Collection", "Time")
+	for i, name := range lt.collections {
+		fmt.Printf("   %-40s %12s\n", name, timings[i].Round(time.Millisecond))
+		sum += timings[i]
+	}
+	fmt.Printf("   %-40s %12s\n", "Sum (sequential equivalent)", sum.Round(time.Millisecond))
+}
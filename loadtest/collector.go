@@ -0,0 +1,81 @@
+package loadtest
+
+import (
+	"sync"
+	"time"
+)
+
+// Collector accumulates the outcome of a stream of operations: how many ran, how
+// many failed and the latency of those that succeeded. It is the bookkeeping the
+// load test phases use, exposed so the metric math can be driven with synthetic
+// samples, in tests or by another harness, without a Milvus client. It is safe
+// for concurrent use.
+type Collector struct {
+	mu         sync.Mutex
+	operations int64
+	failures   int64
+	latencies  []time.Duration
+}
+
+// NewCollector returns an empty Collector
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Record adds one operation. A non-nil err counts it as a failure and its
+// latency is left out of the distribution.
+func (c *Collector) Record(latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.operations++
+	if err != nil {
+		c.failures++
+		return
+	}
+	c.latencies = append(c.latencies, latency)
+}
+
+// Snapshot summarizes the operations recorded so far, with throughput taken over
+// elapsed. Recording can continue afterwards.
+func (c *Collector) Snapshot(elapsed time.Duration) Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := Snapshot{
+		Operations: c.operations,
+		Failures:   c.failures,
+		Latency:    summarizeLatencies(c.latencies).stats(),
+	}
+	if elapsed > 0 {
+		s.Throughput = float64(c.operations-c.failures) / elapsed.Seconds()
+	}
+	if c.operations > 0 {
+		s.ErrorRate = 100 * float64(c.failures) / float64(c.operations)
+	}
+	return s
+}
+
+// Snapshot is the state of a Collector at one point in time
+type Snapshot struct {
+	Operations int64
+	Failures   int64
+	Throughput float64 // successful operations per second
+	ErrorRate  float64 // percentage of operations that failed
+	Latency    LatencyStats
+}
+
+// LatencyStats is the distribution of the successful operations' latencies.
+// Percentiles use the nearest-rank method.
+type LatencyStats struct {
+	Count int
+	Avg   time.Duration
+	Min   time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// stats returns the exported form of the summary
+func (s latencySummary) stats() LatencyStats {
+	return LatencyStats{Count: s.count, Avg: s.avg, Min: s.min, P50: s.p50, P95: s.p95, P99: s.p99, Max: s.max}
+}
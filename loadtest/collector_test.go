@@ -0,0 +1,105 @@
+package loadtest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCollectorSnapshot(t *testing.T) {
+	c := NewCollector()
+	// 1ms..100ms in reverse, so the percentiles depend on the sort
+	for i := 100; i >= 1; i-- {
+		c.Record(time.Duration(i)*time.Millisecond, nil)
+	}
+	for i := 0; i < 25; i++ {
+		c.Record(time.Hour, errors.New("timeout")) // failed latencies must not count
+	}
+
+	got := c.Snapshot(10 * time.Second)
+	want := Snapshot{
+		Operations: 125,
+		Failures:   25,
+		Throughput: 10,
+		ErrorRate:  20,
+		Latency: LatencyStats{
+			Count: 100,
+			Avg:   50500 * time.Microsecond,
+			Min:   time.Millisecond,
+			P50:   50 * time.Millisecond,
+			P95:   95 * time.Millisecond,
+			P99:   99 * time.Millisecond,
+			Max:   100 * time.Millisecond,
+		},
+	}
+	if got != want {
+		t.Errorf("Snapshot(10s) = %+v, want %+v", got, want)
+	}
+}
+
+func TestCollectorNearestRank(t *testing.T) {
+	tests := []struct {
+		name          string
+		samples       []time.Duration
+		p50, p95, p99 time.Duration
+	}{
+		{"single sample", []time.Duration{7}, 7, 7, 7},
+		{"two samples", []time.Duration{2, 1}, 1, 2, 2},
+		{"ten samples", []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5, 10, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector()
+			for _, s := range tt.samples {
+				c.Record(s, nil)
+			}
+			got := c.Snapshot(time.Second).Latency
+			if got.P50 != tt.p50 || got.P95 != tt.p95 || got.P99 != tt.p99 {
+				t.Errorf("p50/p95/p99 = %d/%d/%d, want %d/%d/%d", got.P50, got.P95, got.P99, tt.p50, tt.p95, tt.p99)
+			}
+		})
+	}
+}
+
+func TestCollectorEmpty(t *testing.T) {
+	got := NewCollector().Snapshot(0)
+	if got != (Snapshot{}) {
+		t.Errorf("empty Snapshot(0) = %+v, want the zero Snapshot", got)
+	}
+}
+
+func TestCollectorAllFailed(t *testing.T) {
+	c := NewCollector()
+	c.Record(time.Second, errors.New("unavailable"))
+	c.Record(time.Second, errors.New("unavailable"))
+	got := c.Snapshot(time.Second)
+	if got.Throughput != 0 || got.ErrorRate != 100 || got.Latency.Count != 0 {
+		t.Errorf("Snapshot = %+v, want no throughput, 100%% errors and no latencies", got)
+	}
+}
+
+func TestCollectorConcurrentRecord(t *testing.T) {
+	const workers, perWorker = 8, 1000
+	c := NewCollector()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				var err error
+				if i%10 == 0 {
+					err = errors.New("failed")
+				}
+				c.Record(time.Millisecond, err)
+			}
+		}(w)
+		c.Snapshot(time.Second) // snapshots may interleave with recording
+	}
+	wg.Wait()
+	got := c.Snapshot(time.Second)
+	if got.Operations != workers*perWorker || got.Failures != workers*perWorker/10 {
+		t.Errorf("Operations/Failures = %d/%d, want %d/%d", got.Operations, got.Failures, workers*perWorker, workers*perWorker/10)
+	}
+}
@@ -0,0 +1,231 @@
+package loadtest
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// generationCheckInterval is how many rows are generated between context checks,
+// so cancelling a run stays responsive however large the batches are
+const generationCheckInterval = 1024
+
+// Column builders append values into typed slices that are kept between batches:
+// reset truncates them without freeing the memory, so a worker inserting batch
+// after batch allocates its buffers once instead of on every batch. The column
+// returned by column() aliases the builder's memory and is only valid until the
+// next reset, which is fine since Insert serializes the data before returning.
+
+// int64Column builds an Int64 column
+type int64Column struct {
+	name string
+	data []int64
+}
+
+func newInt64Column(name string, capacity int) *int64Column {
+	return &int64Column{name: name, data: make([]int64, 0, capacity)}
+}
+
+func (c *int64Column) reset()                { c.data = c.data[:0] }
+func (c *int64Column) append(v int64)        { c.data = append(c.data, v) }
+func (c *int64Column) column() entity.Column { return entity.NewColumnInt64(c.name, c.data) }
+
+// varCharColumn builds a VarChar column
+type varCharColumn struct {
+	name      string
+	maxLength int // longest value appendRandom generates
+	data      []string
+}
+
+func newVarCharColumn(name string, maxLength, capacity int) *varCharColumn {
+	return &varCharColumn{name: name, maxLength: maxLength, data: make([]string, 0, capacity)}
+}
+
+func (c *varCharColumn) reset()                { c.data = c.data[:0] }
+func (c *varCharColumn) append(v string)       { c.data = append(c.data, v) }
+func (c *varCharColumn) column() entity.Column { return entity.NewColumnVarChar(c.name, c.data) }
+
+// floatColumn builds a Float column
+type floatColumn struct {
+	name string
+	data []float32
+}
+
+func newFloatColumn(name string, capacity int) *floatColumn {
+	return &floatColumn{name: name, data: make([]float32, 0, capacity)}
+}
+
+func (c *floatColumn) reset()                { c.data = c.data[:0] }
+func (c *floatColumn) append(v float32)      { c.data = append(c.data, v) }
+func (c *floatColumn) column() entity.Column { return entity.NewColumnFloat(c.name, c.data) }
+
+// floatVectorColumn builds a FloatVector column. All rows are carved out of one
+// flat backing array, so a batch costs no per-vector allocation.
+type floatVectorColumn struct {
+	name string
+	dim  int
+	flat []float32
+	rows [][]float32
+}
+
+func newFloatVectorColumn(name string, dim, capacity int) *floatVectorColumn {
+	return &floatVectorColumn{
+		name: name,
+		dim:  dim,
+		flat: make([]float32, 0, dim*capacity),
+		rows: make([][]float32, 0, capacity),
+	}
+}
+
+func (c *floatVectorColumn) reset() {
+	c.flat = c.flat[:0]
+	c.rows = c.rows[:0]
+}
+
+// next appends a row and returns it for the caller to fill in place
+func (c *floatVectorColumn) next() []float32 {
+	if len(c.flat)+c.dim > cap(c.flat) {
+		// Rows handed out so far keep pointing at the old array, which stays valid
+		c.flat = make([]float32, 0, 2*cap(c.flat)+c.dim)
+	}
+	start := len(c.flat)
+	c.flat = c.flat[:start+c.dim]
+	row := c.flat[start : start+c.dim : start+c.dim]
+	c.rows = append(c.rows, row)
+	return row
+}
+
+func (c *floatVectorColumn) column() entity.Column {
+	return entity.NewColumnFloatVector(c.name, c.dim, c.rows)
+}
+
+// fillRandomVectors appends n random rows to c, scaled to unit length if normalize
+// is set. It gives up and returns false as soon as ctx is done, leaving a partial
+// batch that must not be inserted.
+func fillRandomVectors(ctx context.Context, c *floatVectorColumn, n int, normalize bool) bool {
+	for k := 0; k < n; k++ {
+		if k%generationCheckInterval == 0 && ctx.Err() != nil {
+			return false
+		}
+		vec := c.next()
+		for l := range vec {
+			vec[l] = rand.Float32()
+		}
+		if normalize {
+			normalizeVector(vec)
+		}
+	}
+	return true
+}
+
+// normalizeVector scales vec to unit length in place, leaving a zero vector as is.
+// Query vectors need no normalizing: scaling a query scales every score alike, so
+// only the stored vectors decide an IP ranking.
+func normalizeVector(vec []float32) {
+	norm := math.Sqrt(dot(vec, vec))
+	if norm == 0 {
+		return
+	}
+	for l := range vec {
+		vec[l] = float32(float64(vec[l]) / norm)
+	}
+}
+
+// batchBuilder generates insert batches of random rows for the test schema,
+// reusing its column buffers from batch to batch
+type batchBuilder struct {
+	lt          *loadTest
+	autoID      bool
+	embeddings  *floatVectorColumn
+	primaryKeys *int64Column
+	scalars     []columnBuilder
+	first       int64 // row number of the first key of the last batch
+}
+
+// newBatchBuilder returns a builder for batches of up to capacity rows; without
+// autoID it assigns primary keys following --pk-strategy
+func (lt *loadTest) newBatchBuilder(autoID bool, capacity int) *batchBuilder {
+	b := &batchBuilder{
+		lt:          lt,
+		autoID:      autoID,
+		embeddings:  newFloatVectorColumn(embeddingField, embeddingDim, capacity),
+		primaryKeys: newInt64Column(primaryKeyField, capacity),
+		scalars:     make([]columnBuilder, len(lt.cfg.scalars)),
+	}
+	for f, field := range lt.cfg.scalars {
+		b.scalars[f] = field.newBuilder(capacity)
+	}
+	return b
+}
+
+// build generates n rows and returns their columns, the keys it assigned (nil
+// with AutoID) and the size of the scalar values. It returns false if ctx is
+// done before the batch is complete.
+func (b *batchBuilder) build(ctx context.Context, n int) ([]entity.Column, []int64, int64, bool) {
+	columns, scalarBytes, ok := b.fill(ctx, n)
+	if !ok {
+		return nil, nil, 0, false
+	}
+	if b.autoID {
+		return columns, nil, scalarBytes, true
+	}
+	b.first = b.lt.nextID.Add(int64(n)) - int64(n)
+	b.primaryKeys.reset()
+	for k := 0; k < n; k++ {
+		b.primaryKeys.append(primaryKey(b.lt.cfg.PKStrategy, b.first+int64(k)))
+	}
+	return append(columns, b.primaryKeys.column()), b.primaryKeys.data, scalarBytes, true
+}
+
+// buildFor generates new rows for existing keys, the columns of an upsert
+func (b *batchBuilder) buildFor(ctx context.Context, keys []int64) ([]entity.Column, bool) {
+	columns, _, ok := b.fill(ctx, len(keys))
+	if !ok {
+		return nil, false
+	}
+	return append(columns, entity.NewColumnInt64(primaryKeyField, keys)), true
+}
+
+// fill generates n rows of the embedding and scalar columns and returns them with
+// the size of the scalar values
+func (b *batchBuilder) fill(ctx context.Context, n int) ([]entity.Column, int64, bool) {
+	b.embeddings.reset()
+	if pool := b.lt.vectorPool; pool != nil {
+		if !pool.fill(ctx, b.embeddings, n) {
+			return nil, 0, false
+		}
+	} else if !fillRandomVectors(ctx, b.embeddings, n, b.lt.cfg.normalize) {
+		return nil, 0, false
+	}
+	columns := []entity.Column{b.embeddings.column()}
+	var scalarBytes int64
+	for _, scalar := range b.scalars {
+		scalar.reset()
+		if !fillRandom(ctx, scalar, n) {
+			return nil, 0, false
+		}
+		columns = append(columns, scalar.column())
+		scalarBytes += scalar.sizeBytes()
+	}
+	return columns, scalarBytes, true
+}
+
+// track records the keys of the last built batch, once inserted, in the primary
+// key pool and the --export-vectors file; with AutoID the generated keys only
+// come back in the insert result
+func (b *batchBuilder) track(keys []int64, inserted entity.Column) {
+	if b.lt.ids == nil && b.lt.exporter == nil {
+		return
+	}
+	if keys == nil {
+		if generated, ok := inserted.(*entity.ColumnInt64); ok {
+			keys = generated.Data()
+		}
+	}
+	if b.lt.ids != nil {
+		b.lt.ids.add(keys, b.embeddings.rows)
+	}
+	b.lt.exporter.add(keys, b.embeddings.rows)
+}
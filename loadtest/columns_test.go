@@ -0,0 +1,117 @@
+package loadtest
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// extremeBatchSize is the batch size of the extreme pressure level
+const extremeBatchSize = 10000
+
+// BenchmarkInsertBatchFresh builds every batch from freshly allocated slices
+func BenchmarkInsertBatchFresh(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		vectors := make([][]float32, extremeBatchSize)
+		keys := make([]int64, extremeBatchSize)
+		for k := range vectors {
+			vec := make([]float32, embeddingDim)
+			for l := range vec {
+				vec[l] = rand.Float32()
+			}
+			vectors[k] = vec
+			keys[k] = int64(k)
+		}
+		_ = []entity.Column{
+			entity.NewColumnFloatVector(embeddingField, embeddingDim, vectors),
+			entity.NewColumnInt64(primaryKeyField, keys),
+		}
+	}
+}
+
+// BenchmarkInsertBatchBuilders builds every batch with the reusable column builders
+func BenchmarkInsertBatchBuilders(b *testing.B) {
+	b.ReportAllocs()
+	embeddings := newFloatVectorColumn(embeddingField, embeddingDim, extremeBatchSize)
+	primaryKeys := newInt64Column(primaryKeyField, extremeBatchSize)
+	for i := 0; i < b.N; i++ {
+		embeddings.reset()
+		primaryKeys.reset()
+		for k := 0; k < extremeBatchSize; k++ {
+			vec := embeddings.next()
+			for l := range vec {
+				vec[l] = rand.Float32()
+			}
+			primaryKeys.append(int64(k))
+		}
+		_ = []entity.Column{embeddings.column(), primaryKeys.column()}
+	}
+}
+
+func TestFloatVectorColumnGrowKeepsRows(t *testing.T) {
+	c := newFloatVectorColumn(embeddingField, 2, 1)
+	for i := 0; i < 5; i++ {
+		row := c.next()
+		row[0], row[1] = float32(i), float32(-i)
+	}
+	if len(c.rows) != 5 {
+		t.Fatalf("got %d rows, want 5", len(c.rows))
+	}
+	for i, row := range c.rows {
+		if row[0] != float32(i) || row[1] != float32(-i) {
+			t.Fatalf("row %d = %v, want [%d %d]", i, row, i, -i)
+		}
+	}
+
+	c.reset()
+	if row := c.next(); len(row) != 2 || len(c.rows) != 1 {
+		t.Fatalf("after reset got row %v and %d rows", row, len(c.rows))
+	}
+}
+
+func TestFillRandomVectorsNormalize(t *testing.T) {
+	const rows = 200
+	c := newFloatVectorColumn(embeddingField, embeddingDim, rows)
+	if !fillRandomVectors(context.Background(), c, rows, true) {
+		t.Fatal("fillRandomVectors gave up with a live context")
+	}
+	for i, vec := range c.rows {
+		if norm := math.Sqrt(dot(vec, vec)); math.Abs(norm-1) > 1e-5 {
+			t.Fatalf("row %d has length %g, want 1", i, norm)
+		}
+	}
+
+	// On unit vectors IP ranks like COSINE, so every row is its own nearest neighbour
+	keys := make([]int64, rows)
+	for i := range keys {
+		keys[i] = int64(i)
+	}
+	for i, vec := range c.rows {
+		ip := exactNeighbors(entity.IP, vec, keys, c.rows, 3)
+		if ip[0] != keys[i] {
+			t.Fatalf("IP nearest neighbour of row %d = %d, want itself", i, ip[0])
+		}
+		if cosine := exactNeighbors(entity.COSINE, vec, keys, c.rows, 3); !reflect.DeepEqual(ip, cosine) {
+			t.Fatalf("row %d: IP neighbours %v differ from COSINE neighbours %v", i, ip, cosine)
+		}
+	}
+}
+
+func TestUnnormalizedIPFavoursLength(t *testing.T) {
+	// A long vector pointing away from the query beats the query's own direction
+	rows := [][]float32{{1, 0}, {5, 5}}
+	if got := exactNeighbors(entity.IP, []float32{1, 0}, []int64{1, 2}, rows, 1); got[0] != 2 {
+		t.Fatalf("IP nearest neighbour of raw vectors = %d, want the long vector 2", got[0])
+	}
+	for _, vec := range rows {
+		normalizeVector(vec)
+	}
+	if got := exactNeighbors(entity.IP, []float32{1, 0}, []int64{1, 2}, rows, 1); got[0] != 1 {
+		t.Fatalf("IP nearest neighbour of normalized vectors = %d, want 1", got[0])
+	}
+}
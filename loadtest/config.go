@@ -0,0 +1,861 @@
+package loadtest
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// Config holds every setting of a run. Start from DefaultConfig and override what
+// differs; the CLI maps every field to a command-line flag of the same name.
+type Config struct {
+	Command           string // full, insert, search, cleanup, list or cleanup-orphans
+	MilvusAddr        string // one address, or a comma-separated list tried in order for failover
+	Duration          time.Duration
+	Pressure          string
+	RampUp            bool
+	RampStartPct      float64 // load at the start of a --ramp-up, in percent of the maximum
+	BatchJitter       float64 // spread of the insert batch sizes around the target, in percent
+	FaultRate         float64 // malformed batches sent per insert batch, 0 to 1
+	RealTime          bool
+	CoolDown          time.Duration // end the search phase ramping its workers from full load to none over this long
+	Retries           int
+	RetryBackoff      time.Duration
+	DDLRetries        int // retries of a collection existence check, create or drop racing other clients
+	ProjectionBench   bool
+	ProjectionQueries int
+	WireStats         bool
+	Compression       string // gRPC compressor of every call: none or gzip
+	Transport         string // API inserts and searches go through: grpc or rest
+	MetricsURL        string
+	ServerMetrics     time.Duration // interval MetricsURL is scraped at during the run, 0 = not scraped
+	TargetQPS         float64
+	Burst             bool
+	BurstQPS          float64
+	BurstDuration     time.Duration
+	BurstInterval     time.Duration
+	FlushCompare      bool
+	CompareQueries    int
+	TTLSeconds        int
+	Consistency       string
+	ThrottleBackoff   time.Duration
+	AutoID            bool
+	PKStrategy        string // sequential, random or hashed: how keys are assigned without AutoID
+	Checkpoint        string // file recording the insert progress of the 'insert' command
+	Resume            bool   // continue the insert run recorded in Checkpoint
+	IDPoolSize        int
+	QueryFile         string
+	GroundTruthFile   string
+	EventStream       string
+	ExportVectors     string // newline-delimited JSON file the inserted IDs and vectors are written to
+	Replicas          int
+	Connections       int
+	Chart             bool
+	FlushTimeout      time.Duration
+	LoadTimeout       time.Duration // search a collection only partly loaded after this long
+	HotSegment        time.Duration
+	BatchSweep        string // insert batch sizes compared after the insert phase, comma-separated
+	BatchSweepTime    time.Duration
+	FlushStrategy     string // per-collection or batched flush requests
+	LoadOrder         string // flush, index and load steps of the full command, in this order
+	FlushEvery        int    // flush during the insert phase after this many batches, 0 = only at the end
+	ScalarFields      string
+	ScalarIndex       string // scalar indexes compared on filtered search, as field:type,...
+	ScalarGen         string // value distributions of --scalar-fields fields, as field=kind[:args];...
+	MaxDataMB         float64
+	DistinctVectors   int    // draw inserted vectors from this many distinct ones, 0 for all random
+	Metric            string // L2, IP or COSINE
+	Normalize         string // auto (on for IP and COSINE), on or off: insert unit-length vectors
+	RecallCheck       int    // queries verified against exact search, 0 to skip
+	Collections       int
+	AdminConcurrency  int    // collections flushed, indexed or loaded at once
+	VectorParams      string // extra embedding field type params, as key=value,...
+	IndexType         string // vector index built on the embedding field, IVF_FLAT or IVF_SQ8
+	IndexMix          string // index types spread over the collections by weight, as type=weight,...
+	IndexParams       string // extra index build params, as key=value,...
+	Shards            int    // shards per collection, 0 for the server default
+	CollectionProps   string // extra collection properties set at creation, as key=value,...
+	Sweep             string // ascending target search rates, as qps,qps,...
+	SweepStep         time.Duration
+	SweepCSV          string
+	TopKSweep         string // ascending search topK values, as k,k,...
+	FindMaxQPS        bool   // search for the highest rate meeting MaxQPSP99 after the search phase
+	MaxQPSP99         time.Duration
+	Iterator          bool // page through search results with the SDK search iterator after the search phase
+	IteratorPageSize  int
+	IteratorTotal     int    // hits fetched per query vector
+	Percentiles       string // ascending insert and search latency percentiles to report, as p,p,...
+	Alias             string // route inserts and searches through this collection alias
+	RebuildIndex      bool   // drop and recreate the index a third into the search phase
+	FreshnessProbes   int    // rows inserted one at a time to measure insert-to-visible lag
+	TimeToSearchable  bool   // time until a row inserted after the bulk insert is returned by search
+	Mix               string // weighted operation mix replacing the search phase, as op=weight,...
+	DDLStress         bool   // create and drop collections instead of running the pipeline
+	TimestampField    string // Int64 field holding the insert time in unix milliseconds
+	TimestampSpread   time.Duration
+	TimeWindow        time.Duration // range filtered on TimestampField after load
+	MaxSearchP99      time.Duration // SLA: fail the run when search p99 latency is above, 0 to skip
+	MinSearchQPS      float64       // SLA: fail the run when search throughput is below, 0 to skip
+	ValidateResults   bool          // count searches that return no hits or fewer than expected
+	VerifyFields      string        // scalar fields searches return and check against their generated values
+	Strict            bool          // fail the run when more than maxEmptyResultRate of searches are empty
+	CleanupMode       string        // drop, release or none: what step 8 does with the collection
+	ListFilter        string        // glob of the collection names the list command shows, "" for all
+	OrphanAge         time.Duration // cleanup-orphans drops the collections created longer ago than this
+	Confirm           bool          // cleanup-orphans drops instead of printing what it would drop
+	SearchWarmup      int           // unmeasured searches run before the search phase
+	PreSearchDelay    time.Duration // wait before the search phase, at most this long with PreSearchSettle
+	PreSearchSettle   bool          // end the wait once indexing and loading are complete
+	CanaryQueries     int           // fixed query vectors searched every CanaryInterval during the search phase
+	CanaryInterval    time.Duration
+	CanaryCSV         string        // file the canary latency series is written to
+	LoadRepeats       int           // release and reload cycles timed after the first load
+	MaxRuntime        time.Duration // hard ceiling on the whole run, 0 for none
+	CostPerHour       float64       // hourly price of the deployment, for the cost estimates
+
+	// Derived from the settings above by resolve
+	addrs            []string
+	pressureLevel    string
+	numWorkers       int
+	batchSize        int
+	batchSweep       []int // --batch-sweep sizes
+	retry            retryPolicy
+	consistencyLevel entity.ConsistencyLevel
+	scalars          []scalarField
+	scalarIndexes    []scalarIndex
+	verifyFields     []scalarField // --verify-fields
+	metricType       entity.MetricType
+	indexType        entity.IndexType
+	indexMix         []indexShare
+	indexPlan        []entity.IndexType // index type of each collection, built by full and insert
+	normalize        bool
+	vectorParams     map[string]string
+	indexParams      map[string]string
+	collectionProps  map[string]string
+	sweepLevels      []float64
+	topKLevels       []int
+	percentiles      []float64
+	loadOrder        []string
+	mix              []*mixOp
+}
+
+// DefaultConfig returns the default settings, those of the CLI without flags
+func DefaultConfig() Config {
+	return Config{
+		Command:           "full",
+		MilvusAddr:        "localhost:19530",
+		Duration:          30 * time.Second,
+		Pressure:          "medium",
+		RampStartPct:      10,
+		RetryBackoff:      100 * time.Millisecond,
+		DDLRetries:        3,
+		ProjectionQueries: 100,
+		Compression:       "none",
+		Transport:         "grpc",
+		BurstDuration:     5 * time.Second,
+		BurstInterval:     20 * time.Second,
+		CompareQueries:    100,
+		Consistency:       "bounded",
+		CleanupMode:       "drop",
+		AutoID:            true,
+		PKStrategy:        "sequential",
+		IDPoolSize:        100000,
+		Replicas:          1,
+		Connections:       1,
+		FlushTimeout:      10 * time.Minute,
+		LoadTimeout:       30 * time.Minute,
+		FlushStrategy:     "per-collection",
+		LoadOrder:         "flush,index,load",
+		Metric:            "L2",
+		IndexType:         "IVF_FLAT",
+		Normalize:         "auto",
+		Collections:       1,
+		AdminConcurrency:  4,
+		SweepStep:         10 * time.Second,
+		IteratorPageSize:  1000,
+		IteratorTotal:     10000,
+		Percentiles:       "50,90,99,99.9",
+		TimeWindow:        10 * time.Second,
+		CanaryInterval:    time.Second,
+		BatchSweepTime:    10 * time.Second,
+		OrphanAge:         time.Hour,
+	}
+}
+
+// consistencyLevels maps the --consistency values to their SDK level
+var consistencyLevels = map[string]entity.ConsistencyLevel{
+	"strong":     entity.ClStrong,
+	"bounded":    entity.ClBounded,
+	"session":    entity.ClSession,
+	"eventually": entity.ClEventually,
+}
+
+// resolve validates the settings and derives the pressure level settings
+func (cfg *Config) resolve() error {
+	if findCommand(cfg.Command) == nil {
+		return fmt.Errorf("unknown command %q", cfg.Command)
+	}
+	addrs, err := splitAddrs(cfg.MilvusAddr)
+	if err != nil {
+		return fmt.Errorf("invalid --milvus-addr %q: %w", cfg.MilvusAddr, err)
+	}
+	cfg.addrs = addrs
+	if cfg.FlushCompare && cfg.Command != "full" {
+		return errors.New("--flush-compare is only supported by the full command")
+	}
+	if cfg.Retries < 0 {
+		return fmt.Errorf("invalid --retries %d: must be >= 0", cfg.Retries)
+	}
+	if cfg.DDLRetries < 0 {
+		return fmt.Errorf("invalid --ddl-retries %d: must be >= 0", cfg.DDLRetries)
+	}
+	if cfg.RetryBackoff < 0 {
+		return fmt.Errorf("invalid --retry-backoff %s: must be >= 0", cfg.RetryBackoff)
+	}
+	if !slices.Contains(compressions, cfg.Compression) {
+		return fmt.Errorf("invalid --compression %q: must be one of %s", cfg.Compression, strings.Join(compressions, ", "))
+	}
+	if !slices.Contains(transports, cfg.Transport) {
+		return fmt.Errorf("invalid --transport %q: must be one of %s", cfg.Transport, strings.Join(transports, ", "))
+	}
+	if cfg.Transport == "rest" && cfg.Compression != "none" {
+		return fmt.Errorf("invalid --compression %s: --transport rest sends inserts and searches uncompressed", cfg.Compression)
+	}
+	if cfg.Transport == "rest" && cfg.CompareQueries <= 0 && (cfg.Command == "full" || cfg.Command == "search") {
+		return fmt.Errorf("invalid --compare-queries %d: must be > 0", cfg.CompareQueries)
+	}
+	if cfg.ProjectionBench && cfg.ProjectionQueries <= 0 {
+		return fmt.Errorf("invalid --projection-queries %d: must be > 0", cfg.ProjectionQueries)
+	}
+	if cfg.Duration <= 0 {
+		return errors.New("invalid --duration: must be > 0")
+	}
+	if cfg.TargetQPS < 0 {
+		return fmt.Errorf("invalid --target-qps %g: must be >= 0", cfg.TargetQPS)
+	}
+	if cfg.Burst {
+		if cfg.TargetQPS <= 0 {
+			return errors.New("--burst requires --target-qps as the baseline rate")
+		}
+		if cfg.BurstQPS <= cfg.TargetQPS {
+			return fmt.Errorf("invalid --burst-qps %g: must be greater than --target-qps %g", cfg.BurstQPS, cfg.TargetQPS)
+		}
+		if cfg.BurstDuration <= 0 || cfg.BurstInterval <= 0 {
+			return errors.New("invalid burst schedule: --burst-duration and --burst-interval must be > 0")
+		}
+	}
+	if cfg.FlushCompare && cfg.CompareQueries <= 0 {
+		return fmt.Errorf("invalid --compare-queries %d: must be > 0", cfg.CompareQueries)
+	}
+	if cfg.ThrottleBackoff < 0 {
+		return errors.New("invalid --throttle-backoff: must be >= 0")
+	}
+	if cfg.ServerMetrics < 0 {
+		return errors.New("invalid --server-metrics-interval: must be >= 0")
+	}
+	if cfg.GroundTruthFile != "" && cfg.QueryFile == "" {
+		return errors.New("invalid --ground-truth-file: requires --query-file")
+	}
+	scalarFields, err := parseScalarFields(cfg.ScalarFields)
+	if err != nil {
+		return fmt.Errorf("invalid --scalar-fields: %w", err)
+	}
+	cfg.scalars = scalarFields
+	if err := parseScalarGens(cfg.ScalarGen, scalarFields); err != nil {
+		return fmt.Errorf("invalid --scalar-gen: %w", err)
+	}
+	if cfg.scalarIndexes, err = parseScalarIndexes(cfg.ScalarIndex, scalarFields); err != nil {
+		return fmt.Errorf("invalid --scalar-index: %w", err)
+	}
+	if len(cfg.scalarIndexes) > 0 && cfg.Command != "full" {
+		return fmt.Errorf("--scalar-index compares filtered search before and after the index is built, the %s command does not build indexes", cfg.Command)
+	}
+	if len(cfg.scalarIndexes) > 0 && cfg.CompareQueries <= 0 {
+		return fmt.Errorf("invalid --compare-queries %d: must be > 0", cfg.CompareQueries)
+	}
+	if cfg.TimestampField != "" {
+		for _, field := range cfg.scalars {
+			if field.name == cfg.TimestampField {
+				return fmt.Errorf("invalid --timestamp-field %q: already declared by --scalar-fields", cfg.TimestampField)
+			}
+		}
+		if cfg.TimestampField == primaryKeyField || cfg.TimestampField == embeddingField {
+			return fmt.Errorf("invalid --timestamp-field %q: field name already in use", cfg.TimestampField)
+		}
+		if cfg.TimestampSpread < 0 || cfg.TimeWindow <= 0 {
+			return errors.New("invalid timestamp settings: --timestamp-spread must be >= 0 and --time-window > 0")
+		}
+		cfg.scalars = append(cfg.scalars, scalarField{name: cfg.TimestampField, dataType: entity.FieldTypeInt64, timestamp: true, spread: cfg.TimestampSpread})
+	}
+	if cfg.verifyFields, err = parseVerifyFields(cfg.VerifyFields, cfg.scalars); err != nil {
+		return fmt.Errorf("invalid --verify-fields: %w", err)
+	}
+	if len(cfg.verifyFields) > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("invalid --verify-fields: the %s command has no search phase", cfg.Command)
+	}
+	if len(cfg.verifyFields) > 0 && cfg.Transport == "rest" {
+		return errors.New("invalid --verify-fields: --transport rest does not decode output fields")
+	}
+	if cfg.vectorParams, err = parseParams(cfg.VectorParams, ","); err != nil {
+		return fmt.Errorf("invalid --vector-params: %w", err)
+	}
+	if err := embeddingType.checkDim(embeddingDim); err != nil {
+		return err
+	}
+	if _, ok := cfg.vectorParams[typeParamDim]; ok {
+		return fmt.Errorf("invalid --vector-params: %s is fixed at %d", typeParamDim, embeddingDim)
+	}
+	if cfg.indexParams, err = parseParams(cfg.IndexParams, ","); err != nil {
+		return fmt.Errorf("invalid --index-params: %w", err)
+	}
+	if cfg.collectionProps, err = parseParams(cfg.CollectionProps, ","); err != nil {
+		return fmt.Errorf("invalid --collection-properties: %w", err)
+	}
+	if _, ok := cfg.collectionProps[collectionTTLProperty]; ok {
+		return fmt.Errorf("invalid --collection-properties: set %s with --ttl", collectionTTLProperty)
+	}
+	if cfg.Shards < 0 {
+		return fmt.Errorf("invalid --shards %d: must be >= 0", cfg.Shards)
+	}
+	if cfg.sweepLevels, err = parseSweepLevels(cfg.Sweep); err != nil {
+		return fmt.Errorf("invalid --sweep: %w", err)
+	}
+	if len(cfg.sweepLevels) > 0 && cfg.SweepStep <= 0 {
+		return errors.New("invalid --sweep-step: must be > 0")
+	}
+	if cfg.SweepCSV != "" && len(cfg.sweepLevels) == 0 {
+		return errors.New("invalid --sweep-csv: requires --sweep")
+	}
+	if cfg.percentiles, err = parsePercentiles(cfg.Percentiles); err != nil {
+		return fmt.Errorf("invalid --percentiles: %w", err)
+	}
+	if cfg.topKLevels, err = parseTopKLevels(cfg.TopKSweep); err != nil {
+		return fmt.Errorf("invalid --topk-sweep: %w", err)
+	}
+	if len(cfg.topKLevels) > 0 && cfg.SweepStep <= 0 {
+		return errors.New("invalid --sweep-step: must be > 0")
+	}
+	if len(cfg.topKLevels) > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("invalid --topk-sweep: the %s command has no search phase", cfg.Command)
+	}
+	if cfg.FindMaxQPS && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("invalid --find-max-qps: the %s command has no search phase", cfg.Command)
+	}
+	if cfg.FindMaxQPS && (cfg.MaxQPSP99 <= 0 || cfg.SweepStep <= 0) {
+		return errors.New("invalid --find-max-qps: --find-max-qps-p99 and --sweep-step must be > 0")
+	}
+	if cfg.Iterator && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("invalid --iterator: the %s command has no search phase", cfg.Command)
+	}
+	if cfg.Iterator && (cfg.IteratorPageSize <= 0 || cfg.IteratorTotal <= 0 || cfg.CompareQueries <= 0) {
+		return errors.New("invalid --iterator: --iterator-page-size, --iterator-total and --compare-queries must be > 0")
+	}
+	if cfg.CoolDown < 0 {
+		return fmt.Errorf("invalid --cool-down %s: must be >= 0", cfg.CoolDown)
+	}
+	if cfg.CoolDown > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("invalid --cool-down: the %s command has no search phase", cfg.Command)
+	}
+	if cfg.RampStartPct <= 0 || cfg.RampStartPct > 100 {
+		return fmt.Errorf("invalid --ramp-start-pct %g: must be > 0 and <= 100", cfg.RampStartPct)
+	}
+	if cfg.FaultRate < 0 || cfg.FaultRate > 1 {
+		return fmt.Errorf("invalid --fault-injection-rate %g: must be >= 0 and <= 1", cfg.FaultRate)
+	}
+	if cfg.FaultRate > 0 && cfg.Command != "full" && cfg.Command != "insert" {
+		return fmt.Errorf("invalid --fault-injection-rate: the %s command has no insert phase", cfg.Command)
+	}
+	if cfg.BatchJitter < 0 || cfg.BatchJitter >= 100 {
+		return fmt.Errorf("invalid --batch-jitter %g: must be >= 0 and < 100", cfg.BatchJitter)
+	}
+	if cfg.DistinctVectors < 0 {
+		return fmt.Errorf("invalid --distinct-vectors %d: must be >= 0", cfg.DistinctVectors)
+	}
+	if cfg.MaxDataMB < 0 {
+		return fmt.Errorf("invalid --max-data-mb %g: must be >= 0", cfg.MaxDataMB)
+	}
+	if cfg.FlushTimeout <= 0 {
+		return errors.New("invalid --flush-timeout: must be > 0")
+	}
+	if cfg.LoadTimeout <= 0 {
+		return errors.New("invalid --load-timeout: must be > 0")
+	}
+	if !slices.Contains(flushStrategies, cfg.FlushStrategy) {
+		return fmt.Errorf("invalid --flush-strategy %q: must be one of %s", cfg.FlushStrategy, strings.Join(flushStrategies, ", "))
+	}
+	if cfg.ExportVectors != "" && cfg.Command != "full" && cfg.Command != "insert" {
+		return fmt.Errorf("--export-vectors writes the insert phase, the %s command has none", cfg.Command)
+	}
+	if cfg.ExportVectors != "" && cfg.DDLStress {
+		return errors.New("--export-vectors cannot be combined with --ddl-stress, which inserts nothing")
+	}
+	if cfg.FlushEvery < 0 {
+		return fmt.Errorf("invalid --flush-every-n-batches %d: must be >= 0", cfg.FlushEvery)
+	}
+	if cfg.FlushEvery > 0 && cfg.Command != "full" && cfg.Command != "insert" {
+		return fmt.Errorf("--flush-every-n-batches flushes during the insert phase, the %s command has none", cfg.Command)
+	}
+	if cfg.HotSegment < 0 {
+		return fmt.Errorf("invalid --hot-segment %s: must be >= 0", cfg.HotSegment)
+	}
+	if cfg.HotSegment > 0 && cfg.Command != "full" && cfg.Command != "insert" {
+		return fmt.Errorf("--hot-segment runs after the insert phase, the %s command has none", cfg.Command)
+	}
+	if cfg.batchSweep, err = parseBatchSweep(cfg.BatchSweep); err != nil {
+		return fmt.Errorf("invalid --batch-sweep: %w", err)
+	}
+	if len(cfg.batchSweep) > 0 && cfg.Command != "full" && cfg.Command != "insert" {
+		return fmt.Errorf("--batch-sweep runs after the insert phase, the %s command has none", cfg.Command)
+	}
+	if len(cfg.batchSweep) > 0 && cfg.BatchSweepTime <= 0 {
+		return fmt.Errorf("invalid --batch-sweep-duration %s: must be > 0", cfg.BatchSweepTime)
+	}
+	if cfg.Replicas < 1 {
+		return fmt.Errorf("invalid --replicas %d: must be >= 1", cfg.Replicas)
+	}
+	if cfg.Connections < 1 {
+		return fmt.Errorf("invalid --connections %d: must be >= 1", cfg.Connections)
+	}
+	if !slices.Contains(pkStrategies, cfg.PKStrategy) {
+		return fmt.Errorf("invalid --pk-strategy %q: must be one of %s", cfg.PKStrategy, strings.Join(pkStrategies, ", "))
+	}
+	if cfg.AutoID && cfg.PKStrategy != pkStrategies[0] {
+		return fmt.Errorf("--pk-strategy %s needs --auto-id=false, Milvus assigns the keys with AutoID", cfg.PKStrategy)
+	}
+	if cfg.Checkpoint != "" {
+		switch {
+		case cfg.Command != "insert":
+			return fmt.Errorf("invalid --checkpoint: only the insert command can be resumed, not %s", cfg.Command)
+		case cfg.AutoID:
+			return errors.New("invalid --checkpoint: needs --auto-id=false, Milvus assigns the keys with AutoID")
+		case cfg.PKStrategy == "random":
+			return errors.New("invalid --checkpoint: --pk-strategy random keys cannot be continued, use sequential or hashed")
+		}
+	}
+	if cfg.Resume && cfg.Checkpoint == "" {
+		return errors.New("invalid --resume: requires --checkpoint")
+	}
+	if cfg.IDPoolSize < 0 {
+		return fmt.Errorf("invalid --id-pool-size %d: must be >= 0", cfg.IDPoolSize)
+	}
+	if cfg.TTLSeconds < 0 {
+		return fmt.Errorf("invalid --ttl %d: must be >= 0", cfg.TTLSeconds)
+	}
+	switch cfg.CleanupMode {
+	case "drop", "release", "none":
+	default:
+		return fmt.Errorf("invalid --cleanup-mode %q: must be one of drop, release, none", cfg.CleanupMode)
+	}
+	if cfg.CleanupMode == "none" && cfg.Command == "cleanup" {
+		return errors.New("--cleanup-mode none leaves the cleanup command nothing to do")
+	}
+	level, ok := consistencyLevels[cfg.Consistency]
+	if !ok {
+		return fmt.Errorf("invalid --consistency %q: must be one of strong, bounded, session, eventually", cfg.Consistency)
+	}
+	cfg.consistencyLevel = level
+	metric, ok := metricTypes[strings.ToUpper(cfg.Metric)]
+	if !ok {
+		return fmt.Errorf("invalid --metric %q: must be one of L2, IP, COSINE", cfg.Metric)
+	}
+	cfg.metricType = metric
+	indexType, ok := indexTypes[strings.ToUpper(cfg.IndexType)]
+	if !ok {
+		return fmt.Errorf("invalid --index-type %q: must be one of IVF_FLAT, IVF_SQ8", cfg.IndexType)
+	}
+	cfg.indexType = indexType
+	if cfg.loadOrder, err = parseLoadOrder(cfg.LoadOrder, cfg.indexType); err != nil {
+		return fmt.Errorf("invalid --load-order %q: %w", cfg.LoadOrder, err)
+	}
+	if !slices.Equal(cfg.loadOrder, defaultLoadOrder) && cfg.Command != "full" {
+		return errors.New("--load-order is only supported by the full command")
+	}
+	if !slices.Equal(cfg.loadOrder, defaultLoadOrder) && cfg.FlushCompare {
+		return errors.New("--load-order cannot be combined with --flush-compare, which indexes and loads before the flush itself")
+	}
+	switch cfg.Normalize {
+	case "auto":
+		cfg.normalize = metric == entity.IP || metric == entity.COSINE
+	case "on", "off":
+		cfg.normalize = cfg.Normalize == "on"
+	default:
+		return fmt.Errorf("invalid --normalize %q: must be one of auto, on, off", cfg.Normalize)
+	}
+	if cfg.RecallCheck < 0 {
+		return fmt.Errorf("invalid --recall-check %d: must be >= 0", cfg.RecallCheck)
+	}
+	if cfg.RecallCheck > 0 && cfg.Command != "full" {
+		return errors.New("--recall-check is only supported by the full command")
+	}
+	if cfg.Collections < 1 {
+		return fmt.Errorf("invalid --collections %d: must be >= 1", cfg.Collections)
+	}
+	if cfg.indexMix, err = parseIndexMix(cfg.IndexMix); err != nil {
+		return fmt.Errorf("invalid --index-mix: %w", err)
+	}
+	if len(cfg.indexMix) > 0 && cfg.Collections < 2 {
+		return errors.New("--index-mix spreads index types over the collections, --collections must be >= 2")
+	}
+	if len(cfg.indexMix) > 0 && cfg.Command != "search" {
+		if cfg.indexPlan, err = assignIndexTypes(cfg.indexMix, cfg.Collections); err != nil {
+			return fmt.Errorf("invalid --index-mix: %w", err)
+		}
+	}
+	if cfg.AdminConcurrency < 1 {
+		return fmt.Errorf("invalid --admin-concurrency %d: must be >= 1", cfg.AdminConcurrency)
+	}
+	if cfg.RebuildIndex && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("--rebuild-index needs a search phase, the %s command has none", cfg.Command)
+	}
+	if cfg.mix, err = parseMix(cfg.Mix); err != nil {
+		return fmt.Errorf("invalid --mix: %w", err)
+	}
+	for _, op := range cfg.mix {
+		if (op.name == "query" || op.name == "delete" || op.name == "upsert") && cfg.IDPoolSize == 0 {
+			return fmt.Errorf("invalid --mix: %s operations need the primary key pool, --id-pool-size must be > 0", op.name)
+		}
+	}
+	if hasMixOp(cfg.mix, "upsert") && cfg.AutoID {
+		return errors.New("invalid --mix: upsert needs --auto-id=false, Milvus rejects upserts into AutoID collections; the keys then follow --pk-strategy")
+	}
+	if hasMixOp(cfg.mix, "upsert") && cfg.RecallCheck > 0 {
+		return errors.New("invalid --mix: upsert replaces the vectors of tracked rows, which --recall-check would compare against")
+	}
+	if len(cfg.mix) > 0 && (cfg.RebuildIndex || cfg.Burst || cfg.CoolDown > 0) {
+		return errors.New("--mix replaces the search phase and cannot be combined with --rebuild-index, --burst or --cool-down")
+	}
+	if _, err := path.Match(cfg.ListFilter, ""); err != nil {
+		return fmt.Errorf("invalid --list-filter %q: %w", cfg.ListFilter, err)
+	}
+	if cfg.ListFilter != "" && cfg.Command != "list" && cfg.Command != "cleanup-orphans" {
+		return fmt.Errorf("--list-filter selects the collections of the list and cleanup-orphans commands, the %s command lists nothing", cfg.Command)
+	}
+	if cfg.OrphanAge < 0 {
+		return fmt.Errorf("invalid --orphan-age %s: must be >= 0", cfg.OrphanAge)
+	}
+	if cfg.Confirm && cfg.Command != "cleanup-orphans" {
+		return fmt.Errorf("--confirm lets the cleanup-orphans command drop collections, the %s command does not need it", cfg.Command)
+	}
+	if cfg.DDLStress && cfg.Command != "full" {
+		return errors.New("--ddl-stress replaces the full pipeline and is only supported by the full command")
+	}
+	if cfg.DDLStress && (len(cfg.mix) > 0 || cfg.Resume) {
+		return errors.New("--ddl-stress cannot be combined with --mix or --resume")
+	}
+	if cfg.FreshnessProbes < 0 {
+		return fmt.Errorf("invalid --freshness %d: must be >= 0", cfg.FreshnessProbes)
+	}
+	if cfg.FreshnessProbes > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("--freshness needs a loaded collection, the %s command does not load one", cfg.Command)
+	}
+	if cfg.TimeToSearchable && cfg.Command != "full" {
+		return errors.New("--time-to-searchable times the full pipeline's flush, index and load and is only supported by the full command")
+	}
+	if cfg.Alias != "" && cfg.Collections > 1 {
+		return errors.New("--alias requires a single collection")
+	}
+	if cfg.Alias != "" && cfg.CompareQueries <= 0 {
+		return fmt.Errorf("invalid --compare-queries %d: must be > 0", cfg.CompareQueries)
+	}
+	if cfg.MaxSearchP99 < 0 || cfg.MinSearchQPS < 0 {
+		return errors.New("invalid SLA assertions: --max-search-p99 and --min-search-qps must be >= 0")
+	}
+	if (cfg.MaxSearchP99 > 0 || cfg.MinSearchQPS > 0) && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("--max-search-p99 and --min-search-qps need a search phase, the %s command has none", cfg.Command)
+	}
+	if cfg.ValidateResults && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("invalid --validate-results: the %s command has no search phase", cfg.Command)
+	}
+	if cfg.Strict && !cfg.ValidateResults {
+		return errors.New("invalid --strict: requires --validate-results")
+	}
+	if cfg.SearchWarmup < 0 {
+		return fmt.Errorf("invalid --search-warmup %d: must be >= 0", cfg.SearchWarmup)
+	}
+	if cfg.PreSearchDelay < 0 {
+		return fmt.Errorf("invalid --pre-search-delay %s: must be >= 0", cfg.PreSearchDelay)
+	}
+	if cfg.PreSearchSettle && cfg.PreSearchDelay == 0 {
+		return errors.New("invalid --pre-search-settle: requires --pre-search-delay, the longest wait")
+	}
+	if cfg.PreSearchDelay > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("--pre-search-delay needs a search phase, the %s command has none", cfg.Command)
+	}
+	if cfg.CanaryQueries < 0 {
+		return fmt.Errorf("invalid --canary-queries %d: must be >= 0", cfg.CanaryQueries)
+	}
+	if cfg.CanaryQueries > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("--canary-queries needs a search phase, the %s command has none", cfg.Command)
+	}
+	if cfg.CanaryQueries > 0 && len(cfg.mix) > 0 {
+		return errors.New("--canary-queries runs next to the search workers, which --mix replaces")
+	}
+	if cfg.CanaryQueries > 0 && cfg.CanaryInterval <= 0 {
+		return fmt.Errorf("invalid --canary-interval %s: must be > 0", cfg.CanaryInterval)
+	}
+	if cfg.CanaryCSV != "" && cfg.CanaryQueries == 0 {
+		return errors.New("invalid --canary-csv: requires --canary-queries")
+	}
+	if cfg.MaxRuntime < 0 {
+		return errors.New("invalid --max-runtime: must be >= 0")
+	}
+	if cfg.CostPerHour < 0 {
+		return fmt.Errorf("invalid --cost-per-hour %g: must be >= 0", cfg.CostPerHour)
+	}
+	if cfg.LoadRepeats < 0 {
+		return fmt.Errorf("invalid --load-repeats %d: must be >= 0", cfg.LoadRepeats)
+	}
+	if cfg.LoadRepeats > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("--load-repeats needs a loaded collection, the %s command does not load one", cfg.Command)
+	}
+	if cfg.SearchWarmup > 0 && cfg.Command != "full" && cfg.Command != "search" {
+		return fmt.Errorf("--search-warmup needs a search phase, the %s command has none", cfg.Command)
+	}
+	if cfg.RecallCheck > 0 && cfg.Collections > 1 {
+		return errors.New("--recall-check requires a single collection")
+	}
+	cfg.retry = retryPolicy{maxRetries: cfg.Retries, backoff: cfg.RetryBackoff}
+
+	// --- Pressure Level Settings ---
+	switch cfg.Pressure {
+	case "low":
+		cfg.pressureLevel = "LOW"
+		cfg.numWorkers = 5
+		cfg.batchSize = 500
+	case "medium":
+		cfg.pressureLevel = "MEDIUM"
+		cfg.numWorkers = 20
+		cfg.batchSize = 2000
+	case "high":
+		cfg.pressureLevel = "HIGH"
+		cfg.numWorkers = 50
+		cfg.batchSize = 5000
+	case "extreme":
+		cfg.pressureLevel = "EXTREME"
+		cfg.numWorkers = 100
+		cfg.batchSize = 10000
+	default:
+		cfg.pressureLevel = "MEDIUM (default)"
+		cfg.numWorkers = 20
+		cfg.batchSize = 2000
+	}
+	return nil
+}
+
+// burstSchedule returns the search burst schedule configured by the --burst flags
+func (cfg *Config) burstSchedule() burstSchedule {
+	return burstSchedule{
+		baselineQPS: cfg.TargetQPS,
+		burstQPS:    cfg.BurstQPS,
+		duration:    cfg.BurstDuration,
+		interval:    cfg.BurstInterval,
+	}
+}
+
+// printConfig prints the test configuration banner
+func (cfg *Config) printConfig() {
+	fmt.Printf(">> Starting Milvus Load Test: %s intensity for %s <<\n", cfg.pressureLevel, cfg.Duration)
+	fmt.Println("\n--- Test Configuration ---")
+	fmt.Printf(" - Command:                         %s\n", cfg.Command)
+	fmt.Printf(" - Milvus Address:                  %s\n", cfg.MilvusAddr)
+	if len(cfg.addrs) > 1 {
+		fmt.Printf(" - Failover Addresses:              %d, tried in order\n", len(cfg.addrs))
+	}
+	fmt.Printf(" - Test Duration:                   %s\n", cfg.Duration)
+	if cfg.MaxDataMB > 0 {
+		fmt.Printf(" - Insert Volume:                   %g MB (insertion is not time bounded)\n", cfg.MaxDataMB)
+	}
+	fmt.Printf(" - Load Intensity:                  %s\n", cfg.pressureLevel)
+	if cfg.RampUp {
+		fmt.Printf(" - Ramp-up:                         from %g%% to 100%%\n", cfg.RampStartPct)
+	}
+	if cfg.FaultRate > 0 {
+		fmt.Printf(" - Fault Injection:                 %g malformed batches per insert batch\n", cfg.FaultRate)
+	}
+	if cfg.CoolDown > 0 {
+		fmt.Printf(" - Cool-down:                       from 100%% to none over %s after the search phase\n", cfg.CoolDown)
+	}
+	fmt.Printf(" - Concurrent Workers:              %d\n", cfg.numWorkers)
+	fmt.Printf(" - Batch Size (Vectors per Insert): %d\n", cfg.batchSize)
+	if cfg.BatchJitter > 0 {
+		lowest, highest := jitterRange(cfg.batchSize, cfg.BatchJitter)
+		fmt.Printf(" - Batch Jitter:                    ±%g%% (%d to %d vectors)\n", cfg.BatchJitter, lowest, highest)
+	}
+	fmt.Printf(" - Consistency Level:               %s\n", cfg.Consistency)
+	if !cfg.AutoID {
+		fmt.Printf(" - Primary Key Strategy:            %s\n", cfg.PKStrategy)
+	}
+	if cfg.Resume {
+		fmt.Printf(" - Checkpoint:                      %s (resuming)\n", cfg.Checkpoint)
+	} else if cfg.Checkpoint != "" {
+		fmt.Printf(" - Checkpoint:                      %s\n", cfg.Checkpoint)
+	}
+	if cfg.Shards > 0 {
+		fmt.Printf(" - Shards per Collection:           %d\n", cfg.Shards)
+	}
+	if len(cfg.collectionProps) > 0 {
+		fmt.Printf(" - Collection Properties:           %s\n", cfg.CollectionProps)
+	}
+	if cfg.MaxRuntime > 0 {
+		fmt.Printf(" - Time Budget:                     %s for the whole run\n", cfg.MaxRuntime)
+	}
+	if cfg.CostPerHour > 0 {
+		fmt.Printf(" - Cost per Hour:                   %g (for the cost estimates)\n", cfg.CostPerHour)
+	}
+	if cfg.LoadRepeats > 0 {
+		fmt.Printf(" - Load Repeats:                    %d release/reload cycles\n", cfg.LoadRepeats)
+	}
+	if cfg.SearchWarmup > 0 {
+		fmt.Printf(" - Search Warm-up:                  %d queries\n", cfg.SearchWarmup)
+	}
+	if cfg.PreSearchSettle {
+		fmt.Printf(" - Pre-Search Delay:                up to %s, until indexing and loading settle\n", cfg.PreSearchDelay)
+	} else if cfg.PreSearchDelay > 0 {
+		fmt.Printf(" - Pre-Search Delay:                %s\n", cfg.PreSearchDelay)
+	}
+	if cfg.CanaryQueries > 0 {
+		fmt.Printf(" - Canary Queries:                  %d, each searched every %s\n", cfg.CanaryQueries, cfg.CanaryInterval)
+	}
+	if cfg.CleanupMode != "drop" {
+		fmt.Printf(" - Cleanup Mode:                    %s\n", cfg.CleanupMode)
+	}
+	if cfg.Compression != "none" {
+		fmt.Printf(" - gRPC Compression:                %s\n", cfg.Compression)
+	}
+	if cfg.Transport != "grpc" {
+		fmt.Printf(" - Transport:                       %s (inserts and searches)\n", cfg.Transport)
+	}
+	fmt.Printf(" - Metric Type:                     %s\n", cfg.metricType)
+	if len(cfg.indexMix) > 0 {
+		fmt.Printf(" - Index Types:                     %s (--index-mix)\n", cfg.formatIndexMix())
+	} else {
+		fmt.Printf(" - Index Type:                      %s\n", cfg.indexType)
+	}
+	fmt.Printf(" - Normalized Vectors:              %t (%s)\n", cfg.normalize, cfg.Normalize)
+	if cfg.DistinctVectors > 0 {
+		fmt.Printf(" - Distinct Vectors:                %d (inserted rows repeat them)\n", cfg.DistinctVectors)
+	}
+	if cfg.metricType == entity.IP && !cfg.normalize {
+		fmt.Println("⚠️  IP on unnormalized vectors ranks the longest vectors first whatever the query; the numbers are not representative")
+	}
+	if cfg.Collections > 1 {
+		fmt.Printf(" - Collections:                     %d (flush/index/load %d at a time)\n", cfg.Collections, cfg.AdminConcurrency)
+		fmt.Printf(" - Flush Strategy:                  %s\n", cfg.FlushStrategy)
+	}
+	if !slices.Equal(cfg.loadOrder, defaultLoadOrder) {
+		fmt.Printf(" - Load Order:                      %s\n", strings.Join(cfg.loadOrder, " -> "))
+	}
+	if cfg.FlushEvery > 0 {
+		fmt.Printf(" - Periodic Flush:                  every %d insert batches\n", cfg.FlushEvery)
+	}
+	if cfg.HotSegment > 0 {
+		fmt.Printf(" - Insert Contention:               %s into one segment, %s into %d shards x %d partitions\n", cfg.HotSegment, cfg.HotSegment, hotSpreadShards, hotSpreadPartitions)
+	}
+	if len(cfg.batchSweep) > 0 {
+		fmt.Printf(" - Batch Size Sweep:                %s rows, %s each\n", cfg.BatchSweep, cfg.BatchSweepTime)
+	}
+	if cfg.TimestampField != "" {
+		spread := "insert time"
+		if cfg.TimestampSpread > 0 {
+			spread = fmt.Sprintf("spread over the last %s", cfg.TimestampSpread)
+		}
+		fmt.Printf(" - Timestamp Field:                 %s (%s, %s window counted)\n", cfg.TimestampField, spread, cfg.TimeWindow)
+	}
+	if cfg.Alias != "" {
+		fmt.Printf(" - Collection Alias:                %s\n", cfg.Alias)
+	}
+	if len(cfg.scalars) > 0 {
+		fmt.Printf(" - Scalar Fields:                   %s\n", cfg.ScalarFields)
+	}
+	if len(cfg.verifyFields) > 0 {
+		fmt.Printf(" - Verified Output Fields:          %s\n", strings.Join(cfg.verifyFieldNames(), ","))
+	}
+	if cfg.ScalarGen != "" {
+		fmt.Printf(" - Scalar Generators:               %s\n", cfg.ScalarGen)
+	}
+	if len(cfg.scalarIndexes) > 0 {
+		fmt.Printf(" - Scalar Indexes:                  %s\n", cfg.ScalarIndex)
+	}
+	if cfg.Replicas > 1 {
+		fmt.Printf(" - Replicas:                        %d\n", cfg.Replicas)
+	}
+	if cfg.Connections > 1 {
+		fmt.Printf(" - Connections:                     %d (insert and search workers round-robin)\n", cfg.Connections)
+	}
+	fmt.Printf(" - Client GOMAXPROCS:               %s\n", gomaxprocsDescription())
+	if cfg.TTLSeconds > 0 {
+		fmt.Printf(" - Collection TTL:                  %ds\n", cfg.TTLSeconds)
+	}
+	fmt.Printf(" - Retries per Operation:           %d (backoff %s)\n", cfg.retry.maxRetries, cfg.retry.backoff)
+	fmt.Printf(" - Retries per DDL Call:            %d\n", cfg.DDLRetries)
+	if cfg.ThrottleBackoff > 0 {
+		fmt.Printf(" - Throttle Backoff:                %s (adaptive)\n", cfg.ThrottleBackoff)
+	}
+	if cfg.ExportVectors != "" {
+		fmt.Printf(" - Export Vectors:                  %s\n", cfg.ExportVectors)
+	}
+	if cfg.EventStream != "" {
+		fmt.Printf(" - Event Stream:                    %s\n", cfg.EventStream)
+	}
+	if cfg.QueryFile != "" {
+		fmt.Printf(" - Query Vectors:                   %s\n", cfg.QueryFile)
+	}
+	if cfg.GroundTruthFile != "" {
+		fmt.Printf(" - Ground Truth:                    %s (recall@%d)\n", cfg.GroundTruthFile, searchTopK)
+	}
+	if cfg.RecallCheck > 0 {
+		fmt.Printf(" - Recall Check:                    %d queries against exact search\n", cfg.RecallCheck)
+	}
+	if cfg.ServerMetrics > 0 {
+		fmt.Printf(" - Server Metrics:                  every %s from %s\n", cfg.ServerMetrics, metricsURL(cfg.addrs[0], cfg.MetricsURL))
+	}
+	if cfg.Burst {
+		fmt.Printf(" - Search Rate:                     %g qps, bursts of %g qps for %s every %s\n",
+			cfg.TargetQPS, cfg.BurstQPS, cfg.BurstDuration, cfg.BurstInterval)
+	} else if cfg.TargetQPS > 0 {
+		fmt.Printf(" - Search Rate:                     %g qps\n", cfg.TargetQPS)
+	}
+	if len(cfg.sweepLevels) > 0 {
+		fmt.Printf(" - Throughput Sweep:                %s qps, %s per level\n", cfg.Sweep, cfg.SweepStep)
+	}
+	if len(cfg.topKLevels) > 0 {
+		fmt.Printf(" - topK Sweep:                      %s, %s per level\n", cfg.TopKSweep, cfg.SweepStep)
+	}
+	if cfg.FindMaxQPS {
+		fmt.Printf(" - Find Max QPS:                    p99 <= %s, %s per probe\n", cfg.MaxQPSP99, cfg.SweepStep)
+	}
+	if len(cfg.percentiles) > 0 {
+		fmt.Printf(" - Latency Percentiles:             %s\n", cfg.Percentiles)
+	}
+	if cfg.Iterator {
+		fmt.Printf(" - Search Iterator:                 %d hits in pages of %d, %d queries\n", cfg.IteratorTotal, cfg.IteratorPageSize, cfg.CompareQueries)
+	}
+	if cfg.DDLStress {
+		fmt.Printf(" - DDL Stress:                      create/drop collections for %s (replaces the pipeline)\n", cfg.Duration)
+	}
+	if len(cfg.mix) > 0 {
+		fmt.Printf(" - Operation Mix:                   %s (replaces the search phase)\n", cfg.Mix)
+	}
+	if cfg.FreshnessProbes > 0 {
+		fmt.Printf(" - Freshness Probes:                %d\n", cfg.FreshnessProbes)
+	}
+	if cfg.TimeToSearchable {
+		fmt.Printf(" - Time to Searchable:              sentinel row after the bulk insert\n")
+	}
+	if cfg.RebuildIndex {
+		fmt.Printf(" - Index Rebuild:                   a third into the search phase\n")
+	}
+	fmt.Printf(" - Test Mode:                       Continuous load until duration expires\n")
+	fmt.Println("----------------------------------------")
+}
+
+// gomaxprocsDescription describes the effective client parallelism against the host's CPUs
+func gomaxprocsDescription() string {
+	return fmt.Sprintf("%d (%d CPUs visible)", runtime.GOMAXPROCS(0), runtime.NumCPU())
+}
@@ -0,0 +1,38 @@
+package loadtest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveMixUpsertNeedsOwnKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		mix         string
+		autoID      bool
+		pkStrategy  string
+		recallCheck int
+		wantErr     string // substring of the error, "" for none
+	}{
+		{"upsert with AutoID", "search=90,upsert=10", true, "sequential", 0, "upsert needs --auto-id=false"},
+		{"upsert with sequential keys", "search=90,upsert=10", false, "sequential", 0, ""},
+		{"upsert with hashed keys", "upsert=1", false, "hashed", 0, ""},
+		{"upsert with random keys", "upsert=1", false, "random", 0, ""},
+		{"upsert with recall check", "search=90,upsert=10", false, "sequential", 10, "--recall-check"},
+		{"zero upsert weight with AutoID", "search=90,upsert=0", true, "sequential", 0, ""},
+		{"no upsert with AutoID", "search=90,delete=10", true, "sequential", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Mix, cfg.AutoID, cfg.PKStrategy, cfg.RecallCheck = tt.mix, tt.autoID, tt.pkStrategy, tt.recallCheck
+			err := cfg.resolve()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("resolve() = %v, want no error", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("resolve() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package loadtest
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConnectionStats is what one of the --connections carried: insert and search
+// workers are spread over the connections round-robin, worker i on connection
+// i % --connections
+type ConnectionStats struct {
+	Connection       int           `json:"connection"`
+	Workers          int           `json:"workers"` // insert workers, and as many search workers
+	VectorsInserted  int64         `json:"vectors_inserted"`
+	InsertsPerSec    float64       `json:"inserts_per_sec"`
+	Searches         int64         `json:"searches"`
+	SearchesPerSec   float64       `json:"searches_per_sec"`
+	SearchLatencyP50 time.Duration `json:"search_latency_p50_ns"`
+	SearchLatencyP99 time.Duration `json:"search_latency_p99_ns"`
+}
+
+// newConnectionStats returns the per-connection counters, nil for a single
+// connection, which the totals already describe
+func newConnectionStats(connections, workers int) []ConnectionStats {
+	if connections < 2 {
+		return nil
+	}
+	stats := make([]ConnectionStats, connections)
+	for i := range stats {
+		stats[i].Connection = i
+		stats[i].Workers = workers / connections
+		if i < workers%connections {
+			stats[i].Workers++
+		}
+	}
+	return stats
+}
+
+// printConnectionInserts prints the insert throughput of every connection
+func (m *metrics) printConnectionInserts() {
+	if len(m.connStats) == 0 {
+		return
+	}
+	fmt.Printf("   %-12s %10s %14s %14s\n", "Connection", "Workers", "Vectors", "Vectors/sec")
+	for i := range m.connStats {
+		c := &m.connStats[i]
+		c.InsertsPerSec = float64(c.VectorsInserted) / m.insertionTime.Seconds()
+		fmt.Printf("   %-12d %10d %14d %14.2f\n", c.Connection, c.Workers, c.VectorsInserted, c.InsertsPerSec)
+	}
+}
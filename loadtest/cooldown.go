@@ -0,0 +1,78 @@
+package loadtest
+
+import (
+	"fmt"
+	"time"
+)
+
+// coolDownSteps is how many equal steps the --cool-down trajectory is reported in
+const coolDownSteps = 10
+
+// CoolDownStep is the search traffic of one step of the --cool-down that ends the
+// search phase, as the workers drop out from full load to none
+type CoolDownStep struct {
+	Start      time.Duration `json:"start_ns"` // into the cool-down
+	Workers    int           `json:"workers"`  // active at the start of the step
+	Searches   int64         `json:"searches"`
+	Failures   int64         `json:"failures"`
+	QPS        float64       `json:"qps"`
+	LatencyP50 time.Duration `json:"latency_p50_ns"`
+	LatencyP99 time.Duration `json:"latency_p99_ns"`
+}
+
+// coolDownStep returns the step a search issued elapsed into the cool-down is
+// accounted to
+func (cfg *Config) coolDownStep(elapsed time.Duration) int {
+	return min(coolDownSteps-1, int(elapsed*coolDownSteps/cfg.CoolDown))
+}
+
+// coolDownStart returns the offset of step into the cool-down
+func (cfg *Config) coolDownStart(step int) time.Duration {
+	return cfg.CoolDown * time.Duration(step) / coolDownSteps
+}
+
+// finishCoolDown sets how long each cool-down step lasted, the last ones cut short
+// or skipped when the search phase stopped early, and summarizes their latencies
+func (lt *loadTest) finishCoolDown(coolDownStart, searchEnd time.Time) {
+	for i := range lt.m.coolDown {
+		w := &lt.m.coolDown[i]
+		from := coolDownStart.Add(lt.cfg.coolDownStart(i))
+		to := coolDownStart.Add(lt.cfg.coolDownStart(i + 1))
+		if searchEnd.Before(to) {
+			to = searchEnd
+		}
+		w.elapsed = max(0, to.Sub(from))
+		w.latency = summarizeLatencies(w.latencies)
+	}
+}
+
+// printCoolDown prints the search latency trajectory of the cool-down
+func (lt *loadTest) printCoolDown() {
+	fmt.Printf("   %-16s %8s %10s %14s %12s %12s %12s\n", "Cool-Down", "Workers", "Searches", "Achieved QPS", "Error Rate", "p50", "p99")
+	for _, s := range lt.m.coolDownTrajectory(lt.cfg) {
+		fmt.Printf("   %-16s %8d %10d %14.2f %11.2f%% %12s %12s\n", "+"+s.Start.Round(time.Millisecond).String(), s.Workers,
+			s.Searches, s.QPS, 100*float64(s.Failures)/float64(max(1, s.Searches)), s.LatencyP50, s.LatencyP99)
+	}
+}
+
+// coolDownTrajectory returns the cool-down steps the search phase reached, nil
+// without --cool-down
+func (m *metrics) coolDownTrajectory(cfg *Config) []CoolDownStep {
+	var steps []CoolDownStep
+	for i, w := range m.coolDown {
+		if w.elapsed == 0 {
+			break
+		}
+		start := cfg.coolDownStart(i)
+		steps = append(steps, CoolDownStep{
+			Start:      start,
+			Workers:    coolDownWorkers(start, cfg.CoolDown, cfg.numWorkers),
+			Searches:   w.searches,
+			Failures:   w.failures,
+			QPS:        w.qps(),
+			LatencyP50: w.latency.p50,
+			LatencyP99: w.latency.p99,
+		})
+	}
+	return steps
+}
@@ -0,0 +1,64 @@
+package loadtest
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// sleepySearchClient answers every search after a fixed latency, so a search
+// phase runs at a throughput set by its workers alone
+type sleepySearchClient struct {
+	client.Client
+	latency time.Duration
+}
+
+func (c sleepySearchClient) Search(ctx context.Context, _ string, _ []string, _ string, _ []string, _ []entity.Vector, _ string, _ entity.MetricType, _ int, _ entity.SearchParam, _ ...client.SearchQueryOptionFunc) ([]client.SearchResult, error) {
+	time.Sleep(c.latency)
+	return nil, ctx.Err()
+}
+
+// searchAtFullLoad runs a search phase of duration against sleepySearchClient
+// with the given cool-down after it
+func searchAtFullLoad(t *testing.T, duration, coolDown time.Duration) *loadTest {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Command, cfg.Pressure, cfg.CoolDown = "search", "low", coolDown
+	if err := cfg.resolve(); err != nil {
+		t.Fatalf("resolve() = %v", err)
+	}
+	fake := sleepySearchClient{latency: 2 * time.Millisecond}
+	lt := &loadTest{cfg: &cfg, client: fake, conns: []client.Client{fake}, collections: []string{"cool_down_test"}}
+	lt.m.addrOps = make([]int64, len(cfg.addrs))
+	lt.search(context.Background(), duration)
+	return lt
+}
+
+func TestCoolDownKeepsHeadlineThroughput(t *testing.T) {
+	const duration = 500 * time.Millisecond
+	base := searchAtFullLoad(t, duration, 0)
+	cooled := searchAtFullLoad(t, duration, duration)
+
+	if got := cooled.m.searchTime; got < duration || got > duration+100*time.Millisecond {
+		t.Errorf("search time with --cool-down = %s, want the %s of full load", got, duration)
+	}
+	// the ramp-down halves the average load of the cool-down; counted in, it would
+	// take a quarter off the throughput
+	if ratio := cooled.m.searchesPerSec / base.m.searchesPerSec; math.Abs(ratio-1) > 0.1 {
+		t.Errorf("throughput with --cool-down = %.1f/s, without %.1f/s: want the same", cooled.m.searchesPerSec, base.m.searchesPerSec)
+	}
+	var coolDownSearches int64
+	for _, s := range cooled.m.coolDownTrajectory(cooled.cfg) {
+		coolDownSearches += s.Searches
+	}
+	if coolDownSearches == 0 {
+		t.Error("no searches accounted to the cool-down steps")
+	}
+	if len(base.m.coolDownTrajectory(base.cfg)) != 0 {
+		t.Error("cool-down steps reported without --cool-down")
+	}
+}
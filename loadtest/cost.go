@@ -0,0 +1,11 @@
+package loadtest
+
+// costPerMillion estimates what a million operations cost at perHour for the
+// deployment, when it sustains opsPerSec: the hourly rate spread over an hour of
+// operations at that throughput. It is 0 when nothing ran.
+func costPerMillion(perHour, opsPerSec float64) float64 {
+	if perHour <= 0 || opsPerSec <= 0 {
+		return 0
+	}
+	return perHour / (opsPerSec * 3600) * 1e6
+}
@@ -0,0 +1,106 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// collectionMissingMessages are fragments of the errors Milvus returns for a
+// collection that does not exist, such as one another client just dropped
+var collectionMissingMessages = []string{
+	"collection not found",
+	"can't find collection",
+	"collection not exist",
+}
+
+// collectionExistsMessages are fragments of the errors Milvus returns when a
+// collection of that name exists, such as one another client just created
+var collectionExistsMessages = []string{
+	"already exist",
+	"duplicate collection",
+}
+
+// ddlBusyMessages are fragments of the errors of a DDL request that collided
+// with another one on the same collection and can be sent again
+var ddlBusyMessages = []string{
+	"is being dropped",
+	"is dropping",
+	"is being created",
+	"in progress",
+}
+
+// ddl runs op, one HasCollection, CreateCollection or DropCollection call on the
+// named collection, and retries it up to --ddl-retries times with the
+// --retry-backoff backoff while it fails transiently or collides with the DDL of
+// another client. Every retry is counted and printed.
+func (lt *loadTest) ddl(ctx context.Context, call, name string, op func() error) error {
+	policy := retryPolicy{maxRetries: lt.cfg.DDLRetries, backoff: lt.cfg.RetryBackoff}
+	for retries := 0; ; retries++ {
+		err := op()
+		if err == nil || retries >= policy.maxRetries || !(isRetryable(err) || containsAny(err, ddlBusyMessages)) {
+			return err
+		}
+		lt.m.ddlRetries++
+		wait := policy.wait(retries)
+		fmt.Printf("⚠️  %s '%s' failed, retrying in %s: %v\n", call, name, wait, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// hasCollection reports whether the named collection exists, retrying as ddl does
+func (lt *loadTest) hasCollection(ctx context.Context, name string) (bool, error) {
+	var has bool
+	err := lt.ddl(ctx, "HasCollection", name, func() error {
+		var err error
+		has, err = lt.client.HasCollection(ctx, name)
+		return err
+	})
+	return has, err
+}
+
+// dropCollection drops the named collection, retrying as ddl does. A collection
+// that is already gone was dropped by another client in the meantime, which is
+// the outcome asked for: the race is counted and the drop succeeds.
+func (lt *loadTest) dropCollection(ctx context.Context, name string) error {
+	err := lt.ddl(ctx, "DropCollection", name, func() error {
+		return lt.client.DropCollection(ctx, name)
+	})
+	if err != nil && containsAny(err, collectionMissingMessages) {
+		lt.m.ddlRaces++
+		fmt.Printf("⚠️  Collection '%s' was dropped by another client meanwhile.\n", name)
+		return nil
+	}
+	return err
+}
+
+// createCollectionRace creates the collection of schema, retrying as ddl does.
+// A collection of the same name that appeared meanwhile was created by another
+// client running the same test; it is used as is when its schema is the one
+// asked for and the race is counted, otherwise the create fails.
+func (lt *loadTest) createCollectionRace(ctx context.Context, schema *entity.Schema, shards int32, opts ...client.CreateCollectionOption) error {
+	name := schema.CollectionName
+	err := lt.ddl(ctx, "CreateCollection", name, func() error {
+		return lt.client.CreateCollection(ctx, schema, shards, opts...)
+	})
+	if err == nil || !containsAny(err, collectionExistsMessages) {
+		return err
+	}
+	coll, describeErr := lt.client.DescribeCollection(ctx, name)
+	if describeErr != nil {
+		return fmt.Errorf("%w (and describing the existing one failed: %v)", err, describeErr)
+	}
+	if diff := schemaDiff(schema, coll.Schema, true); len(diff) > 0 {
+		return fmt.Errorf("collection '%s' was created meanwhile by another client with another schema: %v", name, diff)
+	}
+	lt.m.ddlRaces++
+	fmt.Printf("⚠️  Collection '%s' was created by another client meanwhile with the same schema, using it.\n", name)
+	return nil
+}
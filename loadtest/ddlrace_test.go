@@ -0,0 +1,24 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDDLRetriesWithoutBackoff(t *testing.T) {
+	lt := &loadTest{cfg: &Config{DDLRetries: 3}}
+	busy := errors.New("collection is being dropped")
+	start := time.Now()
+	err := lt.ddl(context.Background(), "DropCollection", "c", func() error { return busy })
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ddl() with --retry-backoff 0 took %s, want its retries at once", elapsed)
+	}
+	if !errors.Is(err, busy) {
+		t.Errorf("ddl() = %v, want %v", err, busy)
+	}
+	if lt.m.ddlRetries != 3 {
+		t.Errorf("ddlRetries = %d, want 3", lt.m.ddlRetries)
+	}
+}
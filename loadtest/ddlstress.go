@@ -0,0 +1,133 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ddlCleanupTimeout bounds dropping the collections a --ddl-stress run left behind
+const ddlCleanupTimeout = time.Minute
+
+// ddlPrefix starts the name of every collection --ddl-stress creates
+const ddlPrefix = collectionName + "_ddl_"
+
+// ddlStress replaces the pipeline with --ddl-stress: every insert worker creates
+// and drops small collections back to back for --duration, which loads the
+// coordinators' metadata handling instead of the data nodes. Interrupting the
+// run stops the workers and the collections left over are still dropped.
+func (lt *loadTest) ddlStress(ctx context.Context) error {
+	cfg := lt.cfg
+	fmt.Printf("\n--- Step 2: DDL stress: create and drop collections for %s with %d workers ---\n", cfg.Duration, cfg.numWorkers)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	lt.phaseStart("ddl-stress")
+	start := time.Now()
+	creates, drops := NewCollector(), NewCollector()
+	prefix := fmt.Sprintf("%s%d_", ddlPrefix, start.Unix())
+	var seq atomic.Int64
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.numWorkers; i++ {
+		wg.Add(1)
+		go func(goroutineID int) {
+			defer wg.Done()
+			cycles := 0
+			for end := start.Add(cfg.Duration); ctx.Err() == nil && time.Now().Before(end); {
+				name := fmt.Sprintf("%s%d", prefix, seq.Add(1))
+				schema := newSchema(true, nil, nil)
+				schema.CollectionName = name
+				opStart := time.Now()
+				err := lt.client.CreateCollection(ctx, schema, 1)
+				creates.Record(time.Since(opStart), err)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Printf("[DDL Worker %d] Failed to create collection '%s': %v", goroutineID, name, err)
+						lt.events.opError("ddl-stress", goroutineID, 0, err)
+					}
+					continue
+				}
+				opStart = time.Now()
+				err = lt.client.DropCollection(ctx, name)
+				drops.Record(time.Since(opStart), err)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Printf("[DDL Worker %d] Failed to drop collection '%s': %v", goroutineID, name, err)
+						lt.events.opError("ddl-stress", goroutineID, 0, err)
+					}
+					continue
+				}
+				cycles++
+			}
+			fmt.Printf("[DDL Worker %d] Finished after %d create/drop cycles.\n", goroutineID, cycles)
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	interrupted := errors.Is(ctx.Err(), context.Canceled)
+	stop() // a second interrupt aborts the cleanup
+	lt.m.ddlCreates, lt.m.ddlDrops = creates.Snapshot(elapsed), drops.Snapshot(elapsed)
+	lt.m.ddlTime = elapsed
+	lt.phaseEnd("ddl-stress", elapsed)
+	if interrupted {
+		fmt.Println("⚠️  Interrupted, dropping the collections left over...")
+	}
+
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ddlCleanupTimeout)
+	defer cancel()
+	leftovers, err := lt.dropDDLCollections(cleanupCtx)
+	lt.m.ddlLeftovers = leftovers
+	if err != nil {
+		return fmt.Errorf("failed to drop the --ddl-stress collections left over: %w", err)
+	}
+
+	c, d := lt.m.ddlCreates, lt.m.ddlDrops
+	fmt.Printf("✅ DDL stress complete in %s.\n", elapsed)
+	fmt.Printf("   -> Creates: %d (%d failed), %.2f/s, p50 %s, p99 %s\n", c.Operations, c.Failures, c.Throughput, c.Latency.P50, c.Latency.P99)
+	fmt.Printf("   -> Drops:   %d (%d failed), %.2f/s, p50 %s, p99 %s\n", d.Operations, d.Failures, d.Throughput, d.Latency.P50, d.Latency.P99)
+	if leftovers > 0 {
+		fmt.Printf("   -> Dropped %d collections left over\n", leftovers)
+	}
+	return nil
+}
+
+// opResult returns the snapshot of op in the form the --mix results use
+func (s Snapshot) opResult(op string) MixOpResult {
+	return MixOpResult{
+		Operation:  op,
+		Operations: s.Operations,
+		Failures:   s.Failures,
+		OpsPerSec:  s.Throughput,
+		LatencyP50: s.Latency.P50,
+		LatencyP99: s.Latency.P99,
+	}
+}
+
+// dropDDLCollections drops every --ddl-stress collection that still exists, also
+// those of earlier runs that were killed before they could clean up, and returns
+// how many it dropped
+func (lt *loadTest) dropDDLCollections(ctx context.Context) (int, error) {
+	collections, err := lt.client.ListCollections(ctx)
+	if err != nil {
+		return 0, err
+	}
+	dropped := 0
+	for _, coll := range collections {
+		if !strings.HasPrefix(coll.Name, ddlPrefix) {
+			continue
+		}
+		if err := lt.dropCollection(ctx, coll.Name); err != nil {
+			return dropped, fmt.Errorf("collection '%s': %w", coll.Name, err)
+		}
+		dropped++
+	}
+	return dropped, nil
+}
@@ -0,0 +1,195 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// loadStateNames names the SDK load states
+var loadStateNames = map[entity.LoadState]string{
+	entity.LoadStateNotExist: "not exist",
+	entity.LoadStateNotLoad:  "not loaded",
+	entity.LoadStateLoading:  "loading",
+	entity.LoadStateLoaded:   "loaded",
+}
+
+// CollectionInfo is a test collection as Milvus describes it once loaded, so the
+// run record shows what was actually searched
+type CollectionInfo struct {
+	Name        string            `json:"name"`
+	ID          int64             `json:"id"`
+	Shards      int32             `json:"shards"`
+	Consistency string            `json:"consistency"`
+	LoadState   string            `json:"load_state"`
+	Properties  map[string]string `json:"properties"`
+	Fields      []FieldInfo       `json:"fields"`
+	Indexes     []IndexInfo       `json:"indexes"`
+	Mismatches  []string          `json:"mismatches"` // differences from the configuration, nil if none
+}
+
+// FieldInfo is one field of the collection schema
+type FieldInfo struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	PrimaryKey bool              `json:"primary_key"`
+	AutoID     bool              `json:"auto_id"`
+	TypeParams map[string]string `json:"type_params"`
+}
+
+// IndexInfo is one index of the embedding field
+type IndexInfo struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Params map[string]string `json:"params"`
+}
+
+// describeCollections records and prints the description, indexes and load state
+// of every test collection, and reports where they differ from the configuration
+// (step 6f)
+func (lt *loadTest) describeCollections(ctx context.Context) error {
+	fmt.Println("\n--- Step 6f: Describe the loaded collections ---")
+	for _, name := range lt.collections {
+		info, err := lt.describeCollection(ctx, name)
+		if err != nil {
+			return fmt.Errorf("collection '%s': %w", name, err)
+		}
+		lt.m.collectionInfo = append(lt.m.collectionInfo, info)
+		fmt.Printf("   -> '%s' (id %d): %d shards, %s consistency, %s\n", info.Name, info.ID, info.Shards, info.Consistency, info.LoadState)
+		for _, field := range info.Fields {
+			fmt.Printf("      field %-20s %s%s\n", field.Name, field.Type, fieldFlags(field))
+		}
+		for _, index := range info.Indexes {
+			fmt.Printf("      index %-20s %s %s\n", index.Name, index.Type, formatParams(index.Params))
+		}
+		if len(info.Properties) > 0 {
+			fmt.Printf("      properties %s\n", formatParams(info.Properties))
+		}
+		for _, mismatch := range info.Mismatches {
+			fmt.Printf("⚠️  '%s': %s\n", name, mismatch)
+		}
+		if err := lt.checkSearchMetric(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSearchMetric fails the run before the search phase when the collection is
+// indexed with another metric than --metric, the one every search uses. 'full'
+// builds the index with --metric, but a 'search' run reuses a collection that an
+// earlier 'insert' run may have indexed differently, and Milvus rejects or
+// misranks searches whose metric does not match the index.
+func (lt *loadTest) checkSearchMetric(info CollectionInfo) error {
+	for _, index := range info.Indexes {
+		metric := index.Params["metric_type"]
+		if metric != "" && !strings.EqualFold(metric, string(lt.cfg.metricType)) {
+			return withCategory(ErrConfig, fmt.Errorf("collection '%s' is indexed with metric %s but --metric %s would be used to search it; rerun with --metric %s or rebuild the collection",
+				info.Name, metric, lt.cfg.metricType, metric))
+		}
+	}
+	return nil
+}
+
+// describeCollection collects the description of one collection
+func (lt *loadTest) describeCollection(ctx context.Context, name string) (CollectionInfo, error) {
+	coll, err := lt.client.DescribeCollection(ctx, name)
+	if err != nil {
+		return CollectionInfo{}, fmt.Errorf("failed to describe collection: %w", err)
+	}
+	indexes, err := lt.client.DescribeIndex(ctx, name, embeddingField)
+	if err != nil {
+		return CollectionInfo{}, fmt.Errorf("failed to describe index: %w", err)
+	}
+	state, err := lt.client.GetLoadState(ctx, name, nil)
+	if err != nil {
+		return CollectionInfo{}, fmt.Errorf("failed to get load state: %w", err)
+	}
+
+	info := CollectionInfo{
+		Name:        coll.Name,
+		ID:          coll.ID,
+		Shards:      coll.ShardNum,
+		Consistency: consistencyName(coll.ConsistencyLevel),
+		LoadState:   loadStateNames[state],
+		Properties:  coll.Properties,
+	}
+	for _, field := range coll.Schema.Fields {
+		info.Fields = append(info.Fields, FieldInfo{
+			Name:       field.Name,
+			Type:       field.DataType.Name(),
+			PrimaryKey: field.PrimaryKey,
+			AutoID:     field.AutoID,
+			TypeParams: field.TypeParams,
+		})
+	}
+	for _, index := range indexes {
+		info.Indexes = append(info.Indexes, IndexInfo{Name: index.Name(), Type: string(index.IndexType()), Params: index.Params()})
+	}
+	info.Mismatches = lt.cfg.mismatches(info)
+	return info, nil
+}
+
+// mismatches lists where a described collection differs from what the
+// configuration asked for, such as a 'search' run against a collection populated
+// with other settings
+func (cfg *Config) mismatches(info CollectionInfo) []string {
+	var mismatches []string
+	if len(info.Indexes) == 0 {
+		mismatches = append(mismatches, fmt.Sprintf("no index on field '%s'", embeddingField))
+	}
+	for _, index := range info.Indexes {
+		if len(cfg.indexMix) > 0 && cfg.indexPlan == nil {
+			// a 'search' run cannot tell which type the 'insert' run picked for the collection
+			if !slices.ContainsFunc(cfg.indexMix, func(s indexShare) bool { return string(s.indexType) == index.Type }) {
+				mismatches = append(mismatches, fmt.Sprintf("index %s, --index-mix is %s", index.Type, cfg.formatIndexMix()))
+			}
+		} else if expected := cfg.indexTypeOf(info.Name); index.Type != string(expected) {
+			mismatches = append(mismatches, fmt.Sprintf("index %s, --index-type is %s", index.Type, expected))
+		}
+		if metric := index.Params["metric_type"]; metric != "" && !strings.EqualFold(metric, string(cfg.metricType)) {
+			mismatches = append(mismatches, fmt.Sprintf("index metric %s, --metric is %s", metric, cfg.metricType))
+		}
+	}
+	if cfg.Shards > 0 && info.Shards != int32(cfg.Shards) {
+		mismatches = append(mismatches, fmt.Sprintf("%d shards, --shards is %d", info.Shards, cfg.Shards))
+	}
+	if info.Consistency != cfg.Consistency {
+		mismatches = append(mismatches, fmt.Sprintf("%s consistency, --consistency is %s", info.Consistency, cfg.Consistency))
+	}
+	if info.LoadState != loadStateNames[entity.LoadStateLoaded] {
+		mismatches = append(mismatches, "collection is "+info.LoadState)
+	}
+	return mismatches
+}
+
+// consistencyName returns the --consistency value of an SDK level
+func consistencyName(level entity.ConsistencyLevel) string {
+	for name, l := range consistencyLevels {
+		if l == level {
+			return name
+		}
+	}
+	return fmt.Sprintf("level %d", level)
+}
+
+// fieldFlags describes the key flags of a field, if any
+func fieldFlags(field FieldInfo) string {
+	var flags []string
+	if field.PrimaryKey {
+		flags = append(flags, "primary key")
+	}
+	if field.AutoID {
+		flags = append(flags, "auto id")
+	}
+	if len(field.TypeParams) > 0 {
+		flags = append(flags, formatParams(field.TypeParams))
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(flags, ", ") + ")"
+}
@@ -0,0 +1,30 @@
+package loadtest
+
+import "errors"
+
+// Failure categories of a run. Errors returned by NewRunner and Runner.Run match
+// at most one of them with errors.Is; any other error is a runtime failure.
+var (
+	ErrConfig  = errors.New("invalid configuration")
+	ErrConnect = errors.New("connection failed")
+	// ErrBudgetExceeded is returned by Runner.Run, together with the partial
+	// Result, when the run hit --max-runtime
+	ErrBudgetExceeded = errors.New("time budget exceeded")
+)
+
+// categoryError tags err with its failure category without changing its message
+type categoryError struct {
+	category error
+	err      error
+}
+
+func (e *categoryError) Error() string   { return e.err.Error() }
+func (e *categoryError) Unwrap() []error { return []error{e.category, e.err} }
+
+// withCategory tags err with category, nil stays nil
+func withCategory(category, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &categoryError{category: category, err: err}
+}
@@ -0,0 +1,114 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Event types written to the --event-stream
+const (
+	eventPhaseStart = "phase-start"
+	eventPhaseEnd   = "phase-end"
+	eventSnapshot   = "snapshot"
+	eventError      = "error"
+
+	eventServerMetrics = "server-metrics"
+)
+
+// eventHeader is common to every event: type is the discriminator, phase the step
+// of the run (connect, insert, flush, index, load, search, cleanup) it belongs to
+type eventHeader struct {
+	Type  string    `json:"type"`
+	Time  time.Time `json:"time"`
+	Phase string    `json:"phase"`
+}
+
+// phaseEndEvent reports how long a phase took
+type phaseEndEvent struct {
+	eventHeader
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// snapshotEvent reports the progress of a running insert or search phase.
+// Operations counts inserted vectors for the insert phase and searches for the search phase.
+type snapshotEvent struct {
+	eventHeader
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	Operations     int64   `json:"operations"`
+	Failures       int64   `json:"failures"`
+	OpsPerSecond   float64 `json:"ops_per_second"`
+}
+
+// errorEvent reports an operation that failed for good, after its retries
+type errorEvent struct {
+	eventHeader
+	Worker   int    `json:"worker"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+}
+
+// eventStream writes newline-delimited JSON events for external tooling. A nil
+// *eventStream is valid and discards everything, so callers need no checks.
+type eventStream struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	out io.Closer // nil for stdout
+}
+
+// newEventStream opens the stream at path, "-" meaning stdout
+func newEventStream(path string) (*eventStream, error) {
+	if path == "-" {
+		return &eventStream{enc: json.NewEncoder(os.Stdout)}, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &eventStream{enc: json.NewEncoder(f), out: f}, nil
+}
+
+// close closes the underlying file
+func (s *eventStream) close() {
+	if s != nil && s.out != nil {
+		s.out.Close()
+	}
+}
+
+// emit writes one event. Write errors are ignored: the stream must never fail the run.
+func (s *eventStream) emit(event any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.enc.Encode(event)
+}
+
+// phaseStart reports that phase began
+func (s *eventStream) phaseStart(phase string) {
+	s.emit(eventHeader{Type: eventPhaseStart, Time: time.Now(), Phase: phase})
+}
+
+// phaseEnd reports that phase completed in took
+func (s *eventStream) phaseEnd(phase string, took time.Duration) {
+	s.emit(phaseEndEvent{eventHeader{Type: eventPhaseEnd, Time: time.Now(), Phase: phase}, took.Seconds()})
+}
+
+// opError reports an operation of phase that failed after attempts retries
+func (s *eventStream) opError(phase string, worker, attempts int, err error) {
+	s.emit(errorEvent{eventHeader{Type: eventError, Time: time.Now(), Phase: phase}, worker, attempts, err.Error()})
+}
+
+// snapshot reports the counters of a running phase, sampled at now
+func (s *eventStream) snapshot(phase string, now time.Time, elapsed time.Duration, operations, failures int64) {
+	s.emit(snapshotEvent{
+		eventHeader:    eventHeader{Type: eventSnapshot, Time: now, Phase: phase},
+		ElapsedSeconds: elapsed.Seconds(),
+		Operations:     operations,
+		Failures:       failures,
+		OpsPerSecond:   float64(operations) / elapsed.Seconds(),
+	})
+}
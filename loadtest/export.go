@@ -0,0 +1,120 @@
+package loadtest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// exportQueue is how many encoded batches may wait for the export file before
+// the insert workers block on it, which bounds the memory the export takes
+const exportQueue = 16
+
+// exportBufferSize is the write buffer of the export file
+const exportBufferSize = 1 << 20
+
+// vectorExporter streams the inserted rows to the --export-vectors file as
+// newline-delimited JSON, one {"id":..,"embedding":[..]} object per row. Workers
+// encode their batch and hand it to a single writer goroutine, so a slow disk
+// only slows the inserts once exportQueue batches are pending.
+type vectorExporter struct {
+	path    string
+	f       *os.File
+	batches chan []byte
+	done    chan struct{}
+	once    sync.Once
+	rows    atomic.Int64
+	stalled atomic.Int64 // nanoseconds workers waited for a free queue slot
+	bytes   int64        // written, owned by the writer
+	err     error        // first write error, owned by the writer
+}
+
+// newVectorExporter creates the export file and starts its writer
+func newVectorExporter(path string) (*vectorExporter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	e := &vectorExporter{path: path, f: f, batches: make(chan []byte, exportQueue), done: make(chan struct{})}
+	go e.run()
+	return e, nil
+}
+
+// run writes the batches in the order they were queued. After a write error the
+// rest are dropped so the workers never block on a broken file.
+func (e *vectorExporter) run() {
+	defer close(e.done)
+	w := bufio.NewWriterSize(e.f, exportBufferSize)
+	for batch := range e.batches {
+		if e.err != nil {
+			continue
+		}
+		n, err := w.Write(batch)
+		e.bytes += int64(n)
+		e.err = err
+	}
+	if e.err == nil {
+		e.err = w.Flush()
+	}
+	if err := e.f.Close(); e.err == nil {
+		e.err = err
+	}
+}
+
+// add queues a batch of inserted rows: ids[i] is the primary key of vectors[i]
+func (e *vectorExporter) add(ids []int64, vectors [][]float32) {
+	if e == nil || len(ids) != len(vectors) {
+		return
+	}
+	buf := make([]byte, 0, len(vectors)*(24+12*embeddingDim))
+	for i, vector := range vectors {
+		buf = append(buf, `{"id":`...)
+		buf = strconv.AppendInt(buf, ids[i], 10)
+		buf = append(buf, `,"embedding":[`...)
+		for j, v := range vector {
+			if j > 0 {
+				buf = append(buf, ',')
+			}
+			buf = strconv.AppendFloat(buf, float64(v), 'g', -1, 32)
+		}
+		buf = append(buf, "]}\n"...)
+	}
+	select {
+	case e.batches <- buf:
+	default:
+		start := time.Now()
+		e.batches <- buf
+		e.stalled.Add(int64(time.Since(start)))
+	}
+	e.rows.Add(int64(len(ids)))
+}
+
+// close writes the queued batches and closes the file, returning the first
+// write error. Only the first call does anything.
+func (e *vectorExporter) close() error {
+	if e == nil {
+		return nil
+	}
+	e.once.Do(func() {
+		close(e.batches)
+		<-e.done
+	})
+	return e.err
+}
+
+// report closes the export and prints what it wrote
+func (e *vectorExporter) report() {
+	if e == nil {
+		return
+	}
+	if err := e.close(); err != nil {
+		fmt.Printf("⚠️  --export-vectors: writing %s failed, the file is incomplete: %v\n", e.path, err)
+		return
+	}
+	fmt.Printf("   -> Exported %d rows to %s (%.2f MB, workers waited %s for the disk)\n",
+		e.rows.Load(), e.path, float64(e.bytes)/(1024*1024), time.Duration(e.stalled.Load()).Round(time.Millisecond))
+}
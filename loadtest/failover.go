@@ -0,0 +1,150 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failoverDialTimeout bounds each connection attempt when several --milvus-addr
+// are given, so an unreachable address does not block the failover to the next
+const failoverDialTimeout = 10 * time.Second
+
+// connectionMessages are fragments of the errors a client gets once its Milvus
+// address has gone away
+var connectionMessages = []string{
+	"connection refused",
+	"transport is closing",
+	"server is closed",
+	"no such host",
+}
+
+// failoverEvent is one switch from a Milvus address to another
+type failoverEvent struct {
+	at       time.Duration // since the start of the run
+	from, to string
+	cause    string
+}
+
+// connRef identifies the connection set an operation ran on
+type connRef struct {
+	gen  int // bumped by every failover
+	addr int // index into Config.addrs
+}
+
+// splitAddrs parses the comma-separated --milvus-addr list
+func splitAddrs(spec string) ([]string, error) {
+	var addrs []string
+	for _, addr := range strings.Split(spec, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			return nil, errors.New("empty address")
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// isConnectionError reports whether err means the Milvus address is unreachable,
+// as opposed to the server rejecting the operation
+func isConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	return containsAny(err, connectionMessages)
+}
+
+// dial opens --connections clients to addr
+func (lt *loadTest) dial(ctx context.Context, addr string) ([]client.Client, error) {
+	clientConfig := client.Config{Address: addr}
+	if lt.wire != nil || lt.cfg.Compression != "none" {
+		clientConfig.DialOptions = append([]grpc.DialOption{}, client.DefaultGrpcOpts...)
+	}
+	if lt.wire != nil {
+		clientConfig.DialOptions = append(clientConfig.DialOptions, grpc.WithStatsHandler(lt.wire))
+	}
+	if lt.cfg.Compression != "none" {
+		clientConfig.DialOptions = append(clientConfig.DialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(lt.cfg.Compression)))
+	}
+	if len(lt.cfg.addrs) > 1 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, failoverDialTimeout)
+		defer cancel()
+	}
+	var conns []client.Client
+	for i := 0; i < lt.cfg.Connections; i++ {
+		milvusClient, err := client.NewClient(ctx, clientConfig)
+		if err != nil {
+			for _, conn := range conns {
+				conn.Close()
+			}
+			return nil, err
+		}
+		if lt.cfg.Transport == "rest" {
+			milvusClient = newRESTClient(milvusClient, addr, lt.wire)
+		}
+		conns = append(conns, milvusClient)
+	}
+	return conns, nil
+}
+
+// conn returns connection i of the current set and which set it belongs to.
+// Workers call it before every operation so they pick up a failover.
+func (lt *loadTest) conn(i int) (client.Client, connRef) {
+	lt.connMu.RLock()
+	defer lt.connMu.RUnlock()
+	return lt.conns[i%len(lt.conns)], connRef{gen: lt.connGen, addr: lt.addrIndex}
+}
+
+// failover moves every connection to the next reachable --milvus-addr after an
+// operation on ref failed with a connection error. Workers failing on the same
+// connection set at once trigger a single failover, the others reuse its result.
+func (lt *loadTest) failover(ctx context.Context, ref connRef, cause error) {
+	if len(lt.cfg.addrs) < 2 || !isConnectionError(cause) {
+		return
+	}
+	lt.connMu.Lock()
+	defer lt.connMu.Unlock()
+	if lt.connGen != ref.gen {
+		return // another worker already failed over
+	}
+	from := lt.cfg.addrs[lt.addrIndex]
+	for step := 1; step < len(lt.cfg.addrs); step++ {
+		next := (lt.addrIndex + step) % len(lt.cfg.addrs)
+		conns, err := lt.dial(ctx, lt.cfg.addrs[next])
+		if err != nil {
+			fmt.Printf("⚠️  Failover to %s failed: %v\n", lt.cfg.addrs[next], err)
+			continue
+		}
+		for _, conn := range lt.conns {
+			conn.Close()
+		}
+		lt.conns, lt.client = conns, conns[0]
+		lt.addrIndex = next
+		lt.connGen++
+		event := failoverEvent{at: time.Since(lt.start), from: from, to: lt.cfg.addrs[next], cause: cause.Error()}
+		lt.m.failovers = append(lt.m.failovers, event)
+		fmt.Printf("🔀 FAILOVER after %s: %s -> %s (%v)\n", event.at.Round(time.Millisecond), event.from, event.to, cause)
+		return
+	}
+}
+
+// busiestAddr returns the address that served the most operations
+func (lt *loadTest) busiestAddr() string {
+	busiest := 0
+	for i := range lt.cfg.addrs {
+		if lt.m.addrOps[i] > lt.m.addrOps[busiest] {
+			busiest = i
+		}
+	}
+	return lt.cfg.addrs[busiest]
+}
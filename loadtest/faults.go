@@ -0,0 +1,181 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// faultBatchRows is the size of the malformed batches --fault-injection-rate sends
+const faultBatchRows = 10
+
+// FaultResult is what Milvus made of the malformed batches of one kind the insert
+// phase sent for --fault-injection-rate. Every one should be rejected, and is
+// counted as a failed insert; Accepted counts those Milvus inserted anyway.
+type FaultResult struct {
+	Kind     string `json:"kind"`
+	Injected int64  `json:"injected"`
+	Rejected int64  `json:"rejected"`
+	Accepted int64  `json:"accepted"`
+}
+
+// faultInjector sends the malformed batches of the insert phase from a goroutine
+// of its own, so their round trips stay out of the workers' insert time. The
+// kinds are:
+//
+//   - wrong-dim: the embeddings have one dimension too many
+//   - nil-values: the embedding field carries no values at all
+//   - nan-vector: an embedding holds a NaN
+//   - long-varchar: a VarChar value is longer than max_length, only with a
+//     VarChar field among --scalar-fields
+type faultInjector struct {
+	lt        *loadTest
+	kinds     []string
+	varChar   string // the field long-varchar overflows
+	maxLength int
+	service   *client.GrpcClient
+	queue     chan int // worker whose batch a malformed one follows
+	done      chan struct{}
+}
+
+// newFaultInjector returns the injector of the insert phase, nil without
+// --fault-injection-rate. The batches go to the Milvus service directly, as
+// the SDK would refuse most of them before sending, so the client must be the
+// SDK's.
+func (lt *loadTest) newFaultInjector() (*faultInjector, error) {
+	if lt.cfg.FaultRate == 0 {
+		return nil, nil
+	}
+	service, err := sdkClient(lt.client)
+	if err != nil {
+		return nil, fmt.Errorf("--fault-injection-rate: %w", err)
+	}
+	f := &faultInjector{lt: lt, kinds: []string{"wrong-dim", "nil-values", "nan-vector"}, service: service}
+	if i := slices.IndexFunc(lt.cfg.scalars, func(s scalarField) bool { return s.dataType == entity.FieldTypeVarChar }); i >= 0 {
+		f.kinds = append(f.kinds, "long-varchar")
+		f.varChar = lt.cfg.scalars[i].name
+		f.maxLength, _ = strconv.Atoi(lt.cfg.scalars[i].typeParams[typeParamMaxLength]) // checked by parseScalarFields
+	}
+	return f, nil
+}
+
+// start sends a malformed batch for every request until stop, reporting each to
+// send. It resets the per kind counts of the metrics.
+func (f *faultInjector) start(ctx context.Context, autoID bool, send func(insertResult)) {
+	f.lt.m.faults = make([]FaultResult, len(f.kinds))
+	for i, kind := range f.kinds {
+		f.lt.m.faults[i].Kind = kind
+	}
+	f.queue, f.done = make(chan int, f.lt.cfg.numWorkers), make(chan struct{})
+	batch := f.lt.newBatchBuilder(autoID, faultBatchRows)
+	go func() {
+		defer close(f.done)
+		for worker := range f.queue {
+			f.inject(ctx, worker, batch, send)
+		}
+	}()
+}
+
+// request asks for a malformed batch after one of worker's. A request finding
+// the injector still busy with the ones before is dropped, never holding up
+// the worker.
+func (f *faultInjector) request(worker int) {
+	select {
+	case f.queue <- worker:
+	default:
+	}
+}
+
+// stop waits for the requested batches to be sent; the workers must be done
+func (f *faultInjector) stop() {
+	close(f.queue)
+	<-f.done
+}
+
+// inject sends one malformed batch of a kind drawn at random to the collection
+// of worker. Its primary keys are negative, which this tool never assigns
+// otherwise. A batch cut off by the end of the run or by a lost connection is
+// not reported, it was neither rejected nor accepted.
+func (f *faultInjector) inject(ctx context.Context, worker int, batch *batchBuilder, send func(insertResult)) {
+	kind := rand.Intn(len(f.kinds))
+	columns, _, ok := batch.fill(ctx, faultBatchRows)
+	if !ok {
+		return
+	}
+	if !batch.autoID {
+		keys := make([]int64, faultBatchRows)
+		for i := range keys {
+			keys[i] = -1 - int64(i)
+		}
+		columns = append(columns, entity.NewColumnInt64(primaryKeyField, keys))
+	}
+	fields := make([]*schemapb.FieldData, len(columns))
+	for i, column := range columns {
+		fields[i] = column.FieldData()
+	}
+	f.corrupt(f.kinds[kind], fields)
+	collection := f.lt.dataTarget(worker)
+	resp, err := f.service.Service.Insert(ctx, &milvuspb.InsertRequest{CollectionName: collection, FieldsData: fields, NumRows: faultBatchRows})
+	if ctx.Err() != nil || isConnectionError(err) {
+		return
+	}
+	if status := resp.GetStatus(); err == nil && status.GetErrorCode() != commonpb.ErrorCode_Success {
+		err = fmt.Errorf("%s: %s", status.GetErrorCode(), status.GetReason())
+	}
+	if err == nil {
+		log.Printf("⚠️  --fault-injection-rate: Milvus accepted a %s batch into '%s'", f.kinds[kind], collection)
+	}
+	send(insertResult{fault: kind + 1, rows: faultBatchRows, worker: worker, err: err})
+}
+
+// corrupt makes fields, a well-formed batch, malformed as kind says
+func (f *faultInjector) corrupt(kind string, fields []*schemapb.FieldData) {
+	for _, field := range fields {
+		switch {
+		case field.GetFieldName() == embeddingField && kind == "wrong-dim":
+			vectors := field.GetVectors()
+			data := vectors.GetFloatVector().GetData()
+			vectors.Dim++
+			vectors.GetFloatVector().Data = append(data, make([]float32, faultBatchRows)...)
+		case field.GetFieldName() == embeddingField && kind == "nil-values":
+			field.GetVectors().GetFloatVector().Data = nil
+		case field.GetFieldName() == embeddingField && kind == "nan-vector":
+			field.GetVectors().GetFloatVector().Data[0] = float32(math.NaN())
+		case field.GetFieldName() == f.varChar && kind == "long-varchar":
+			field.GetScalars().GetStringData().Data[0] = strings.Repeat("x", f.maxLength+1)
+		}
+	}
+}
+
+// addFault counts the outcome of a malformed batch: a rejected one is a failed
+// insert, as the run should see it
+func (m *metrics) addFault(r insertResult) {
+	f := &m.faults[r.fault-1]
+	f.Injected++
+	if r.err == nil {
+		f.Accepted++
+		return
+	}
+	f.Rejected++
+	m.failedInserts++
+}
+
+// faultsRejected returns how many malformed batches Milvus rejected
+func (m *metrics) faultsRejected() int64 {
+	var rejected int64
+	for _, f := range m.faults {
+		rejected += f.Rejected
+	}
+	return rejected
+}
@@ -0,0 +1,56 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+)
+
+// flushStrategies lists the --flush-strategy values
+var flushStrategies = []string{"per-collection", "batched"}
+
+// flushAlso adds more collections to a flush request, so a single request seals
+// the segments of all of them
+func flushAlso(names []string) client.FlushOption {
+	return func(req *milvuspb.FlushRequest) {
+		req.CollectionNames = append(req.CollectionNames, names...)
+	}
+}
+
+// flushBatched seals every test collection with one flush request and returns
+// the segments waiting to be persisted, by collection. The SDK only returns the
+// sealed segments of the first collection named; for the others every segment
+// not yet flushed once the request returns is waited for, which are the ones the
+// request sealed as no inserts run during the flush.
+func (lt *loadTest) flushBatched(ctx context.Context) (map[string][]int64, error) {
+	start := time.Now()
+	var first []int64
+	if _, err := lt.cfg.retry.run(ctx, func() error {
+		var err error
+		first, _, _, _, err = lt.client.FlushV2(ctx, lt.collections[0], true, flushAlso(lt.collections[1:]))
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to flush collections: %w", err)
+	}
+	lt.m.flushRequests++
+	lt.m.flushRequestTime += time.Since(start)
+
+	sealed := map[string][]int64{lt.collections[0]: first}
+	for _, name := range lt.collections[1:] {
+		segments, err := lt.client.GetPersistentSegmentInfo(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("collection '%s': failed to get segment info: %w", name, err)
+		}
+		var pending []int64
+		for _, segment := range segments {
+			if !segment.Flushed() {
+				pending = append(pending, segment.ID)
+			}
+		}
+		sealed[name] = pending
+	}
+	return sealed, nil
+}
@@ -0,0 +1,127 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// freshnessPollInterval is how often a probe row is searched for, freshnessTimeout
+// how long it may take to become visible before the probe counts as missed
+const (
+	freshnessPollInterval = 10 * time.Millisecond
+	freshnessTimeout      = 30 * time.Second
+)
+
+// freshness inserts --freshness probe rows one at a time and measures how long
+// each takes, after its insert was acknowledged, to be returned by a search (step 7f)
+func (lt *loadTest) freshness(ctx context.Context) error {
+	cfg := lt.cfg
+	if cfg.FreshnessProbes == 0 {
+		return nil
+	}
+	fmt.Printf("\n--- Step 7f: Insert-to-visible freshness lag (%d probes, %s consistency) ---\n", cfg.FreshnessProbes, cfg.Consistency)
+	target := lt.dataTarget(0)
+	probe := lt.newProbeRow()
+	searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+
+	var lags []time.Duration
+	for i := 0; i < cfg.FreshnessProbes && ctx.Err() == nil; i++ {
+		key, vector, err := probe.insert(ctx, target)
+		if err != nil {
+			return fmt.Errorf("freshness probe %d: %w", i, err)
+		}
+		acked := time.Now()
+
+		query := []entity.Vector{entity.FloatVector(vector)}
+		expr := fmt.Sprintf("%s == %d", primaryKeyField, key)
+		if lt.waitVisible(ctx, target, expr, query, searchParams) {
+			lags = append(lags, time.Since(acked))
+		} else if ctx.Err() == nil {
+			lt.m.freshnessMissed++
+			fmt.Printf("⚠️  Probe %d was not visible after %s\n", i, freshnessTimeout)
+		}
+	}
+
+	lt.m.freshnessLag = summarizeLatencies(lags)
+	lag := lt.m.freshnessLag
+	fmt.Printf("✅ Freshness lag over %d probes: p50 %s, p99 %s, max %s (not visible within %s: %d)\n",
+		lag.count, lag.p50, lag.p99, lag.max, freshnessTimeout, lt.m.freshnessMissed)
+	return nil
+}
+
+// probeRow builds and inserts single rows whose primary key is known, to search
+// for them once inserted
+type probeRow struct {
+	lt          *loadTest
+	autoID      bool
+	embeddings  *floatVectorColumn
+	primaryKeys *int64Column
+	scalars     []columnBuilder
+}
+
+func (lt *loadTest) newProbeRow() *probeRow {
+	p := &probeRow{
+		lt:          lt,
+		autoID:      primaryKeyAutoID(lt.schema),
+		embeddings:  newFloatVectorColumn(embeddingField, embeddingDim, 1),
+		primaryKeys: newInt64Column(primaryKeyField, 1),
+		scalars:     make([]columnBuilder, len(lt.cfg.scalars)),
+	}
+	for f, field := range lt.cfg.scalars {
+		p.scalars[f] = field.newBuilder(1)
+	}
+	return p
+}
+
+// insert inserts one random row into target and returns its primary key and vector
+func (p *probeRow) insert(ctx context.Context, target string) (int64, []float32, error) {
+	p.embeddings.reset()
+	fillRandomVectors(ctx, p.embeddings, 1, p.lt.cfg.normalize)
+	columns := []entity.Column{p.embeddings.column()}
+	for _, scalar := range p.scalars {
+		scalar.reset()
+		scalar.appendRandom()
+		columns = append(columns, scalar.column())
+	}
+	var key int64
+	if !p.autoID {
+		// Unique even in a collection kept from earlier runs
+		key = time.Now().UnixNano()
+		p.primaryKeys.reset()
+		p.primaryKeys.append(key)
+		columns = append(columns, p.primaryKeys.column())
+	}
+	inserted, err := p.lt.client.Insert(ctx, target, "", columns...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to insert: %w", err)
+	}
+	if p.autoID {
+		generated, ok := inserted.(*entity.ColumnInt64)
+		if !ok || generated.Len() == 0 {
+			return 0, nil, fmt.Errorf("insert returned no primary key")
+		}
+		key = generated.Data()[0]
+	}
+	return key, p.embeddings.rows[0], nil
+}
+
+// waitVisible searches for the row matching expr until a search returns it,
+// reporting false if that does not happen within freshnessTimeout
+func (lt *loadTest) waitVisible(ctx context.Context, target, expr string, query []entity.Vector, searchParams entity.SearchParam) bool {
+	deadline := time.Now().Add(freshnessTimeout)
+	for time.Now().Before(deadline) {
+		results, err := lt.client.Search(ctx, target, []string{}, expr, []string{}, query, embeddingField, lt.cfg.metricType, 1, searchParams)
+		if err == nil && len(results) > 0 && results[0].ResultCount > 0 {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(freshnessPollInterval):
+		}
+	}
+	return false
+}
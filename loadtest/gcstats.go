@@ -0,0 +1,57 @@
+package loadtest
+
+import (
+	"runtime"
+	"time"
+)
+
+// gcStats are the client's garbage collections over a run, to tell GC jitter in
+// the tool apart from server latency
+type gcStats struct {
+	collections int64
+	pauseTotal  time.Duration
+	pauseMax    time.Duration
+}
+
+// gcSnapshot is the runtime GC state at the start of a run
+type gcSnapshot struct {
+	numGC      uint32
+	pauseTotal uint64
+}
+
+func takeGCSnapshot() gcSnapshot {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return gcSnapshot{numGC: ms.NumGC, pauseTotal: ms.PauseTotalNs}
+}
+
+// since returns the collections run after the snapshot. The runtime only keeps
+// the last len(PauseNs) pauses, so on very long runs the maximum covers those.
+func (s gcSnapshot) since() gcStats {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	stats := gcStats{
+		collections: int64(ms.NumGC - s.numGC),
+		pauseTotal:  time.Duration(ms.PauseTotalNs - s.pauseTotal),
+	}
+	recent := min(ms.NumGC-s.numGC, uint32(len(ms.PauseNs)))
+	for i := uint32(0); i < recent; i++ {
+		// PauseNs is a circular buffer, the latest pause at (NumGC+255)%256
+		pause := time.Duration(ms.PauseNs[(ms.NumGC-i+uint32(len(ms.PauseNs))-1)%uint32(len(ms.PauseNs))])
+		stats.pauseMax = max(stats.pauseMax, pause)
+	}
+	return stats
+}
+
+func (s gcStats) pauseAvg() time.Duration {
+	if s.collections == 0 {
+		return 0
+	}
+	return s.pauseTotal / time.Duration(s.collections)
+}
+
+// explainsTail reports whether the longest GC pause is a sizeable part of latency
+// p99, in which case client GC may account for some of the tail
+func (s gcStats) explainsTail(p99 time.Duration) bool {
+	return p99 > 0 && s.pauseMax*10 >= p99
+}
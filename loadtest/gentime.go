@@ -0,0 +1,66 @@
+package loadtest
+
+import (
+	"fmt"
+	"time"
+)
+
+// generationBoundShare is the share of the insert workers' busy time spent
+// generating batches above which the run measures the client more than Milvus
+const generationBoundShare = 0.5
+
+// workerTime is how an insert worker spent its busy time
+type workerTime struct {
+	generation time.Duration // building the batches: vectors, scalars and keys
+	rpc        time.Duration // in Insert calls, retries included but not their backoff
+}
+
+// share returns the fraction of the busy time spent generating
+func (w workerTime) share() float64 {
+	if w.generation+w.rpc == 0 {
+		return 0
+	}
+	return float64(w.generation) / float64(w.generation+w.rpc)
+}
+
+// insertTime returns the time all insert workers spent generating and in RPCs
+func (m *metrics) insertTime() workerTime {
+	var total workerTime
+	for _, w := range m.workerTimes {
+		total.generation += w.generation
+		total.rpc += w.rpc
+	}
+	return total
+}
+
+// generationShareRange returns the lowest and highest generation share of the
+// insert workers that did any work
+func (m *metrics) generationShareRange() (lowest, highest float64) {
+	lowest = 1
+	for _, w := range m.workerTimes {
+		if w.generation+w.rpc == 0 {
+			continue
+		}
+		lowest, highest = min(lowest, w.share()), max(highest, w.share())
+	}
+	return min(lowest, highest), highest
+}
+
+// printGenerationSplit prints how the insert workers split their time and warns
+// when generating the data dominates
+func (lt *loadTest) printGenerationSplit() {
+	total := lt.m.insertTime()
+	if total.generation+total.rpc == 0 {
+		return
+	}
+	lowest, highest := lt.m.generationShareRange()
+	fmt.Printf("   -> Worker time: %.1f%% generating (%s), %.1f%% in Insert RPCs (%s); per worker %.1f%%-%.1f%% generating\n",
+		100*total.share(), total.generation.Round(time.Millisecond), 100*(1-total.share()), total.rpc.Round(time.Millisecond), 100*lowest, 100*highest)
+	if total.share() > generationBoundShare {
+		hint := "use fewer workers or a faster client machine"
+		if lt.vectorPool == nil {
+			hint = "set --distinct-vectors to draw rows from vectors generated up front, " + hint
+		}
+		fmt.Printf("⚠️  The workers spent more time generating data than inserting it: the throughput reflects the client, not Milvus (%s)\n", hint)
+	}
+}
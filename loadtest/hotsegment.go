@@ -0,0 +1,188 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+)
+
+// hotSegmentPrefix starts the names of the two --hot-segment collections
+const hotSegmentPrefix = collectionName + "_contention_"
+
+// The spread-out --hot-segment layout: rows hash over hotSpreadShards shards and
+// worker i inserts into partition i % hotSpreadPartitions, so the rows land in
+// hotSpreadShards x hotSpreadPartitions growing segments instead of one
+const (
+	hotSpreadShards     = 4
+	hotSpreadPartitions = 4
+)
+
+// A contentionLayout is how a --hot-segment collection lays out its rows
+type contentionLayout struct {
+	name       string
+	shards     int
+	partitions int // created partitions, 0 for the default one only
+}
+
+// contentionLayouts are the layouts --hot-segment compares, one segment first
+var contentionLayouts = []contentionLayout{
+	{name: "hot", shards: 1},
+	{name: "spread", shards: hotSpreadShards, partitions: hotSpreadPartitions},
+}
+
+// HotSegmentResult is the insert throughput --hot-segment measured into one layout
+type HotSegmentResult struct {
+	Layout          string        `json:"layout"` // "hot" (one segment) or "spread"
+	Shards          int           `json:"shards"`
+	Partitions      int           `json:"partitions"`
+	VectorsInserted int64         `json:"vectors_inserted"`
+	VectorsPerSec   float64       `json:"vectors_per_sec"`
+	FailedBatches   int64         `json:"failed_batches"`
+	LatencyP50      time.Duration `json:"latency_p50_ns"`
+	LatencyP99      time.Duration `json:"latency_p99_ns"`
+}
+
+// hotSegmentCompare finds the insert throughput ceiling of a single segment:
+// after the insert phase, all insert workers insert for --hot-segment into a
+// fresh collection with one shard and only the default partition, where every
+// row goes to the same growing segment, and then for as long into one whose rows
+// spread over hotSpreadShards shards and hotSpreadPartitions partitions. Nothing
+// is flushed meanwhile, so the hot segment only seals once it reaches the
+// server's dataCoord.segment.maxSize; lower that on the server for a tiny
+// segment, it cannot be set per collection (step 4c).
+func (lt *loadTest) hotSegmentCompare(ctx context.Context) error {
+	cfg := lt.cfg
+	if cfg.HotSegment <= 0 {
+		return nil
+	}
+	fmt.Printf("\n--- Step 4c: Insert contention: one segment vs %d shards x %d partitions (%s each, %d workers) ---\n",
+		hotSpreadShards, hotSpreadPartitions, cfg.HotSegment, cfg.numWorkers)
+	lt.phaseStart("hot-segment")
+	start := time.Now()
+	for _, layout := range contentionLayouts {
+		result, err := lt.contentionRun(ctx, layout)
+		if err != nil {
+			return fmt.Errorf("failed the %s --hot-segment layout: %w", layout.name, err)
+		}
+		lt.m.hotSegment = append(lt.m.hotSegment, result)
+		fmt.Printf("   -> %-6s %d shards, %d partitions: %.2f vectors/s, batch p50 %s, p99 %s, %d failed batches\n", result.Layout,
+			result.Shards, max(result.Partitions, 1), result.VectorsPerSec, result.LatencyP50, result.LatencyP99, result.FailedBatches)
+	}
+	lt.phaseEnd("hot-segment", time.Since(start))
+	fmt.Printf("✅ Insert contention complete: the spread layout inserts %.2fx as fast as one segment.\n", lt.m.hotSegmentSpeedup())
+	return nil
+}
+
+// contentionRun inserts into a fresh collection of layout for --hot-segment with
+// every insert worker and drops it again
+func (lt *loadTest) contentionRun(ctx context.Context, layout contentionLayout) (HotSegmentResult, error) {
+	cfg := lt.cfg
+	name := hotSegmentPrefix + layout.name
+	drop, err := lt.freshCollection(ctx, name, layout.shards)
+	if err != nil {
+		return HotSegmentResult{}, err
+	}
+	defer drop()
+	partitions := []string{""}
+	if layout.partitions > 0 {
+		partitions = partitions[:0]
+		for p := 0; p < layout.partitions; p++ {
+			partition := fmt.Sprintf("p%d", p)
+			if err := lt.client.CreatePartition(ctx, name, partition); err != nil {
+				return HotSegmentResult{}, fmt.Errorf("failed to create partition '%s': %w", partition, err)
+			}
+			partitions = append(partitions, partition)
+		}
+	}
+
+	inserted, elapsed, snapshot := lt.insertBurst(ctx, "hot-segment", "Contention", name, partitions, cfg.batchSize, cfg.HotSegment)
+	return HotSegmentResult{
+		Layout:          layout.name,
+		Shards:          layout.shards,
+		Partitions:      layout.partitions,
+		VectorsInserted: inserted,
+		VectorsPerSec:   float64(inserted) / elapsed.Seconds(),
+		FailedBatches:   snapshot.Failures,
+		LatencyP50:      snapshot.Latency.P50,
+		LatencyP99:      snapshot.Latency.P99,
+	}, nil
+}
+
+// freshCollection creates the named AutoID collection with the run's schema and
+// shards, dropping one left over by an earlier run first, and returns the func
+// that drops it again
+func (lt *loadTest) freshCollection(ctx context.Context, name string, shards int) (func(), error) {
+	cfg := lt.cfg
+	if has, err := lt.hasCollection(ctx, name); err != nil {
+		return nil, err
+	} else if has {
+		if err := lt.dropCollection(ctx, name); err != nil {
+			return nil, fmt.Errorf("failed to drop collection '%s' left over: %w", name, err)
+		}
+	}
+	schema := newSchema(true, cfg.vectorParams, cfg.scalars)
+	schema.CollectionName = name
+	if err := lt.createCollectionRace(ctx, schema, int32(shards), client.WithConsistencyLevel(cfg.consistencyLevel)); err != nil {
+		return nil, fmt.Errorf("failed to create collection '%s': %w", name, err)
+	}
+	return func() {
+		if err := lt.dropCollection(context.WithoutCancel(ctx), name); err != nil {
+			log.Printf("Failed to drop collection '%s': %v", name, err)
+		}
+	}, nil
+}
+
+// insertBurst inserts batches of batchSize rows into the named collection with
+// every insert worker for d, worker i writing to partitions[i % len(partitions)],
+// and returns the rows acknowledged, the time taken and the batch latencies.
+// Failed inserts are logged as events of phase, by "[<label> Worker i]".
+func (lt *loadTest) insertBurst(ctx context.Context, phase, label, name string, partitions []string, batchSize int, d time.Duration) (int64, time.Duration, Snapshot) {
+	cfg := lt.cfg
+	batches := NewCollector()
+	var inserted atomic.Int64
+	start := time.Now()
+	end := start.Add(d)
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.numWorkers; i++ {
+		wg.Add(1)
+		go func(goroutineID int) {
+			defer wg.Done()
+			partition := partitions[goroutineID%len(partitions)]
+			builder := lt.newBatchBuilder(true, batchSize)
+			for ctx.Err() == nil && time.Now().Before(end) {
+				columns, _, ok := builder.fill(ctx, batchSize)
+				if !ok {
+					return
+				}
+				opStart := time.Now()
+				ids, err := lt.client.Insert(ctx, name, partition, columns...)
+				batches.Record(time.Since(opStart), err)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Printf("[%s Worker %d] Failed to insert into '%s': %v", label, goroutineID, name, err)
+						lt.events.opError(phase, goroutineID, 0, err)
+					}
+					continue
+				}
+				inserted.Add(int64(acknowledgedRows(ids, batchSize)))
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	return inserted.Load(), elapsed, batches.Snapshot(elapsed)
+}
+
+// hotSegmentSpeedup returns how many times the --hot-segment spread layout's
+// insert throughput is the single segment's, 0 if either is missing
+func (m *metrics) hotSegmentSpeedup() float64 {
+	if len(m.hotSegment) < 2 || m.hotSegment[0].VectorsPerSec == 0 {
+		return 0
+	}
+	return m.hotSegment[1].VectorsPerSec / m.hotSegment[0].VectorsPerSec
+}
@@ -0,0 +1,95 @@
+package loadtest
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// idPool is a bounded, thread-safe ring buffer of recently inserted primary keys
+// and their vectors. Once full, new rows overwrite the oldest ones, so the pool
+// always holds the most recent size rows and memory stays bounded however long
+// the run is.
+type idPool struct {
+	mu      sync.Mutex
+	ids     []int64
+	vectors []float32 // embeddingDim values per slot
+	next    int
+	full    bool
+	added   int64
+}
+
+// newIDPool returns a pool holding at most size rows
+func newIDPool(size int) *idPool {
+	return &idPool{ids: make([]int64, size), vectors: make([]float32, size*embeddingDim)}
+}
+
+// add records the keys of inserted rows along with their vectors (which are
+// copied), evicting the oldest rows when the pool is full
+func (p *idPool) add(keys []int64, vectors [][]float32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, key := range keys {
+		p.ids[p.next] = key
+		copy(p.vectors[p.next*embeddingDim:(p.next+1)*embeddingDim], vectors[i])
+		p.next++
+		if p.next == len(p.ids) {
+			p.next = 0
+			p.full = true
+		}
+	}
+	p.added += int64(len(keys))
+}
+
+// len returns the number of keys currently held
+func (p *idPool) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.full {
+		return len(p.ids)
+	}
+	return p.next
+}
+
+// total returns the number of keys ever recorded, including evicted ones
+func (p *idPool) total() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.added
+}
+
+// sample returns up to n keys picked at random (with replacement) from the pool,
+// or nil if it is empty
+func (p *idPool) sample(n int) []int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	size := p.next
+	if p.full {
+		size = len(p.ids)
+	}
+	if size == 0 {
+		return nil
+	}
+	keys := make([]int64, n)
+	for i := range keys {
+		keys[i] = p.ids[rand.Intn(size)]
+	}
+	return keys
+}
+
+// rows returns a copy of every row held, and whether that is every row ever
+// recorded, i.e. nothing was evicted
+func (p *idPool) rows() (keys []int64, vectors [][]float32, complete bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	size := p.next
+	if p.full {
+		size = len(p.ids)
+	}
+	keys = append([]int64(nil), p.ids[:size]...)
+	flat := append([]float32(nil), p.vectors[:size*embeddingDim]...)
+	vectors = make([][]float32, size)
+	for i := range vectors {
+		vectors[i] = flat[i*embeddingDim : (i+1)*embeddingDim]
+	}
+	return keys, vectors, p.added == int64(size)
+}
@@ -0,0 +1,161 @@
+package loadtest
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// An indexShare is one type=weight entry of --index-mix
+type indexShare struct {
+	indexType entity.IndexType
+	weight    int
+}
+
+// IndexTypeResult is the search phase of the --index-mix collections sharing
+// one vector index type
+type IndexTypeResult struct {
+	IndexType      string        `json:"index_type"`
+	Collections    []string      `json:"collections"`
+	Searches       int64         `json:"searches"`
+	SearchesPerSec float64       `json:"searches_per_sec"`
+	LatencyAvg     time.Duration `json:"latency_avg_ns"`
+	LatencyP50     time.Duration `json:"latency_p50_ns"`
+	LatencyP95     time.Duration `json:"latency_p95_ns"`
+	LatencyP99     time.Duration `json:"latency_p99_ns"`
+}
+
+// parseIndexMix parses --index-mix, type=weight pairs such as
+// IVF_FLAT=1,IVF_SQ8=1, ordered by index type
+func parseIndexMix(spec string) ([]indexShare, error) {
+	params, err := parseParams(spec, ",")
+	if err != nil {
+		return nil, err
+	}
+	var shares []indexShare
+	for name, value := range params {
+		indexType, ok := indexTypes[strings.ToUpper(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown index type %q: must be one of IVF_FLAT, IVF_SQ8", name)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 1 {
+			return nil, fmt.Errorf("%s weight %q: must be an integer >= 1", name, value)
+		}
+		if slices.ContainsFunc(shares, func(s indexShare) bool { return s.indexType == indexType }) {
+			return nil, fmt.Errorf("index type %s set twice", indexType)
+		}
+		shares = append(shares, indexShare{indexType: indexType, weight: weight})
+	}
+	slices.SortFunc(shares, func(a, b indexShare) int { return strings.Compare(string(a.indexType), string(b.indexType)) })
+	return shares, nil
+}
+
+// assignIndexTypes picks the index type of each of n collections: every type
+// gets its weighted share of the collections, rounded by largest remainder, and
+// the collections are drawn at random for it. A type whose share rounds to no
+// collection is an error, its latency could not be reported.
+func assignIndexTypes(shares []indexShare, n int) ([]entity.IndexType, error) {
+	total := 0
+	for _, s := range shares {
+		total += s.weight
+	}
+	counts := make([]int, len(shares))
+	assigned := 0
+	for i, s := range shares {
+		counts[i] = n * s.weight / total
+		assigned += counts[i]
+	}
+	byRemainder := make([]int, len(shares))
+	for i := range byRemainder {
+		byRemainder[i] = i
+	}
+	slices.SortStableFunc(byRemainder, func(a, b int) int {
+		return n*shares[b].weight%total - n*shares[a].weight%total
+	})
+	for _, i := range byRemainder[:n-assigned] {
+		counts[i]++
+	}
+	types := make([]entity.IndexType, 0, n)
+	for i, s := range shares {
+		if counts[i] == 0 {
+			return nil, fmt.Errorf("%s gets none of the %d collections; raise its weight or --collections", s.indexType, n)
+		}
+		for range counts[i] {
+			types = append(types, s.indexType)
+		}
+	}
+	rand.Shuffle(len(types), func(i, j int) { types[i], types[j] = types[j], types[i] })
+	return types, nil
+}
+
+// indexTypeOf returns the vector index type built on the named collection: its
+// --index-mix pick, otherwise --index-type
+func (cfg *Config) indexTypeOf(name string) entity.IndexType {
+	if i := slices.Index(collectionNames(cfg.Collections), name); i >= 0 && i < len(cfg.indexPlan) {
+		return cfg.indexPlan[i]
+	}
+	return cfg.indexType
+}
+
+// formatIndexMix describes the index types of the collections, as
+// IVF_FLAT x2, IVF_SQ8 x2 when they are assigned and as the weights otherwise
+func (cfg *Config) formatIndexMix() string {
+	parts := make([]string, len(cfg.indexMix))
+	for i, s := range cfg.indexMix {
+		if cfg.indexPlan == nil {
+			parts[i] = fmt.Sprintf("%s=%d", s.indexType, s.weight)
+			continue
+		}
+		count := 0
+		for _, t := range cfg.indexPlan {
+			if t == s.indexType {
+				count++
+			}
+		}
+		parts[i] = fmt.Sprintf("%s x%d", s.indexType, count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// indexTypeSearch groups the search latencies of each collection, indexed like
+// lt.collections, by the index type the collection was described with, and
+// prints the types side by side
+func (lt *loadTest) indexTypeSearch(latencies [][]time.Duration) {
+	described := make(map[string]string, len(lt.m.collectionInfo))
+	for _, info := range lt.m.collectionInfo {
+		if len(info.Indexes) > 0 {
+			described[info.Name] = info.Indexes[0].Type
+		}
+	}
+	var results []IndexTypeResult
+	var merged [][]time.Duration
+	for i, name := range lt.collections {
+		indexType, ok := described[name]
+		if !ok {
+			indexType = string(lt.cfg.indexTypeOf(name))
+		}
+		j := slices.IndexFunc(results, func(r IndexTypeResult) bool { return r.IndexType == indexType })
+		if j < 0 {
+			j = len(results)
+			results = append(results, IndexTypeResult{IndexType: indexType})
+			merged = append(merged, nil)
+		}
+		results[j].Collections = append(results[j].Collections, name)
+		merged[j] = append(merged[j], latencies[i]...)
+	}
+	fmt.Printf("   %-12s %11s %10s %14s %12s %12s %12s %12s\n", "Index Type", "Collections", "Searches", "Searches/sec", "Avg", "p50", "p95", "p99")
+	for j := range results {
+		r := &results[j]
+		summary := summarizeLatencies(merged[j])
+		r.Searches, r.SearchesPerSec = int64(summary.count), float64(summary.count)/lt.m.searchTime.Seconds()
+		r.LatencyAvg, r.LatencyP50, r.LatencyP95, r.LatencyP99 = summary.avg, summary.p50, summary.p95, summary.p99
+		fmt.Printf("   %-12s %11d %10d %14.2f %12s %12s %12s %12s\n", r.IndexType, len(r.Collections), r.Searches, r.SearchesPerSec, r.LatencyAvg, r.LatencyP50, r.LatencyP95, r.LatencyP99)
+	}
+	lt.m.indexTypeSearch = results
+}
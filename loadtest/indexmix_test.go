@@ -0,0 +1,83 @@
+package loadtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+func TestAssignIndexTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mix     string
+		n       int
+		flat    int // IVF_FLAT collections wanted, the rest IVF_SQ8
+		wantErr string
+	}{
+		{"one type takes all", "IVF_FLAT=3", 4, 4, ""},
+		{"even split", "IVF_FLAT=1,IVF_SQ8=1", 4, 2, ""},
+		{"tied remainders go in index type order", "IVF_FLAT=1,IVF_SQ8=1", 3, 2, ""},
+		{"largest remainder rounds up", "IVF_FLAT=1,IVF_SQ8=2", 4, 1, ""},
+		{"weighted towards the first", "IVF_FLAT=2,IVF_SQ8=1", 4, 3, ""},
+		{"one collection each", "IVF_FLAT=1,IVF_SQ8=1", 2, 1, ""},
+		{"share rounds to none", "IVF_FLAT=1,IVF_SQ8=10", 5, 0, "IVF_FLAT gets none of the 5 collections"},
+		{"fewer collections than types", "IVF_FLAT=1,IVF_SQ8=1", 1, 0, "gets none of the 1 collections"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := parseIndexMix(tt.mix)
+			if err != nil {
+				t.Fatalf("parseIndexMix(%q) = %v", tt.mix, err)
+			}
+			types, err := assignIndexTypes(shares, tt.n)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("assignIndexTypes(%s, %d) = %v, want an error containing %q", tt.mix, tt.n, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("assignIndexTypes(%s, %d) = %v", tt.mix, tt.n, err)
+			}
+			if len(types) != tt.n {
+				t.Fatalf("assignIndexTypes(%s, %d) picked %d index types, want %d", tt.mix, tt.n, len(types), tt.n)
+			}
+			flat := 0
+			for _, indexType := range types {
+				if indexType == entity.IvfFlat {
+					flat++
+				}
+			}
+			if flat != tt.flat {
+				t.Errorf("assignIndexTypes(%s, %d) gave IVF_FLAT %d collections, want %d", tt.mix, tt.n, flat, tt.flat)
+			}
+		})
+	}
+}
+
+func TestParseIndexMix(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string // substring of the error, "" for none
+	}{
+		{"two types", "IVF_SQ8=1,IVF_FLAT=2", ""},
+		{"lower case type", "ivf_flat=1", ""},
+		{"unknown type", "HNSW=1", `unknown index type "HNSW"`},
+		{"zero weight", "IVF_FLAT=0", `IVF_FLAT weight "0"`},
+		{"weight not an integer", "IVF_FLAT=1.5", `IVF_FLAT weight "1.5"`},
+		{"type set twice", "IVF_FLAT=1,ivf_flat=2", "index type IVF_FLAT set twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIndexMix(tt.spec)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("parseIndexMix(%q) = %v, want no error", tt.spec, err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("parseIndexMix(%q) = %v, want an error containing %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// indexTypes maps the --index-type values to their SDK index type
+var indexTypes = map[string]entity.IndexType{
+	"IVF_FLAT": entity.IvfFlat,
+	"IVF_SQ8":  entity.IvfSQ8,
+}
+
+// indexVectorBytes estimates the vector data held by an index of indexType over
+// rows vectors: IVF_FLAT keeps the raw vectors, IVF_SQ8 quantizes each value to a
+// byte. The per-dimension ranges and the cluster centroids are left out.
+func indexVectorBytes(indexType entity.IndexType, rows int64) int64 {
+	if indexType == entity.IvfSQ8 {
+		return rows * embeddingDim
+	}
+	return embeddingBytes(rows)
+}
+
+// quantizationRecall measures recall over the same queries with an IVF_FLAT index
+// swapped in for the --index-type one, which is restored afterwards, so the recall
+// cost of quantization is measured on this run's data
+func (lt *loadTest) quantizationRecall(ctx context.Context, queries [][]entity.Vector, truths [][]int64) error {
+	fmt.Printf("Rebuilding '%s' with IVF_FLAT to measure the recall cost of %s...\n", lt.collections[0], lt.cfg.indexType)
+	if err := lt.replaceIndex(ctx, entity.IvfFlat); err != nil {
+		return err
+	}
+	recall, err := lt.measureRecall(ctx, queries, truths)
+	if err != nil {
+		return err
+	}
+	lt.m.flatRecall = recall
+	if err := lt.replaceIndex(ctx, lt.cfg.indexType); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Recall@%d with IVF_FLAT: %.4f, %s costs %.4f\n", searchTopK, lt.m.flatRecall, lt.cfg.indexType, lt.m.flatRecall-lt.m.exactRecall)
+	return nil
+}
+
+// replaceIndex releases the first collection, rebuilds its vector index as
+// indexType and loads it again
+func (lt *loadTest) replaceIndex(ctx context.Context, indexType entity.IndexType) error {
+	name := lt.collections[0]
+	if err := lt.client.ReleaseCollection(ctx, name); err != nil {
+		return fmt.Errorf("failed to release collection: %w", err)
+	}
+	if err := lt.client.DropIndex(ctx, name, embeddingField); err != nil {
+		return fmt.Errorf("failed to drop index: %w", err)
+	}
+	if err := lt.client.CreateIndex(ctx, name, embeddingField, lt.newIndexOf(indexType), false); err != nil {
+		return fmt.Errorf("failed to create %s index: %w", indexType, err)
+	}
+	if err := lt.client.LoadCollection(ctx, name, false, lt.loadOptions()...); err != nil {
+		return fmt.Errorf("failed to reload collection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// sdkClient returns the *client.GrpcClient under c, also behind --transport rest,
+// for what only it implements: the SDK search iterator and raw service calls
+func sdkClient(c client.Client) (*client.GrpcClient, error) {
+	if rest, ok := c.(*restClient); ok {
+		c = rest.Client
+	}
+	grpcClient, ok := c.(*client.GrpcClient)
+	if !ok {
+		return nil, fmt.Errorf("the client %T is not the SDK gRPC client", c)
+	}
+	return grpcClient, nil
+}
+
+// iteratorSearch pages through the nearest neighbours of --compare-queries query
+// vectors with the SDK search iterator, --iterator-page-size hits per page until
+// --iterator-total were fetched or the iterator ran out, timing every page and
+// every full retrieval (step 7k). Hits seen on an earlier page of the same
+// retrieval are counted, the iterator should not return any.
+func (lt *loadTest) iteratorSearch(ctx context.Context) error {
+	cfg := lt.cfg
+	if !cfg.Iterator {
+		return nil
+	}
+	grpcClient, err := sdkClient(lt.client)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n--- Step 7k: Search iterator, %d hits in pages of %d (%d queries) ---\n", cfg.IteratorTotal, cfg.IteratorPageSize, cfg.CompareQueries)
+	lt.phaseStart("iterator")
+	start := time.Now()
+	var opens, pages, retrievals []time.Duration
+	for q := 0; q < cfg.CompareQueries && ctx.Err() == nil; q++ {
+		// the iterator narrows the range filter of its search params page by page
+		searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+		opt := client.NewSearchIteratorOption(lt.collections[0], embeddingField, searchParams, randomQueryVector()[0], cfg.metricType).
+			WithBatchSize(cfg.IteratorPageSize)
+		retrievalStart := time.Now()
+		itr, err := grpcClient.SearchIterator(ctx, opt)
+		if err != nil {
+			return fmt.Errorf("failed to open search iterator %d: %w", q, err)
+		}
+		opens = append(opens, time.Since(retrievalStart))
+		seen := make(map[int64]struct{}, cfg.IteratorTotal)
+		for fetched := 0; fetched < cfg.IteratorTotal; {
+			pageStart := time.Now()
+			page, err := itr.Next(ctx)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("search iterator %d failed after %d hits: %w", q, fetched, err)
+			}
+			pages = append(pages, time.Since(pageStart))
+			ids, _ := page.IDs.(*entity.ColumnInt64)
+			if ids != nil {
+				for _, id := range ids.Data() {
+					if _, dup := seen[id]; dup {
+						lt.m.iteratorDuplicates++
+					}
+					seen[id] = struct{}{}
+				}
+			}
+			fetched += page.IDs.Len()
+			lt.m.iteratorHits += int64(page.IDs.Len())
+		}
+		retrievals = append(retrievals, time.Since(retrievalStart))
+	}
+	lt.m.iteratorTime = time.Since(start)
+	lt.phaseEnd("iterator", lt.m.iteratorTime)
+	lt.m.iteratorOpen = summarizeLatencies(opens)
+	lt.m.iteratorPages = summarizeLatencies(pages)
+	lt.m.iteratorRetrievals = summarizeLatencies(retrievals)
+
+	open, page, retrieval := lt.m.iteratorOpen, lt.m.iteratorPages, lt.m.iteratorRetrievals
+	fmt.Printf("   %-12s %10s %12s %12s %12s %12s\n", "Call", "Count", "Avg", "p50", "p95", "p99")
+	fmt.Printf("   %-12s %10d %12s %12s %12s %12s\n", "Open", open.count, open.avg, open.p50, open.p95, open.p99)
+	fmt.Printf("   %-12s %10d %12s %12s %12s %12s\n", "Page", page.count, page.avg, page.p50, page.p95, page.p99)
+	fmt.Printf("   %-12s %10d %12s %12s %12s %12s\n", "Retrieval", retrieval.count, retrieval.avg, retrieval.p50, retrieval.p95, retrieval.p99)
+	fmt.Printf("✅ Search iterator complete: %d hits in %s (%.2f hits/s).\n", lt.m.iteratorHits, lt.m.iteratorTime, lt.m.iteratorHitsPerSec())
+	if lt.m.iteratorDuplicates > 0 {
+		fmt.Printf("⚠️  %d hits were returned again on a later page of the same retrieval.\n", lt.m.iteratorDuplicates)
+	}
+	return nil
+}
+
+// iteratorHitsPerSec returns the hits the --iterator step fetched per second
+func (m *metrics) iteratorHitsPerSec() float64 {
+	if m.iteratorTime <= 0 {
+		return 0
+	}
+	return float64(m.iteratorHits) / m.iteratorTime.Seconds()
+}
@@ -0,0 +1,110 @@
+package loadtest
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// latencySummary holds the distribution of a set of latency samples
+type latencySummary struct {
+	count int
+	avg   time.Duration
+	min   time.Duration
+	p50   time.Duration
+	p95   time.Duration
+	p99   time.Duration
+	max   time.Duration
+}
+
+// summarizeLatencies computes the latency distribution of samples, which is sorted in place
+func summarizeLatencies(samples []time.Duration) latencySummary {
+	if len(samples) == 0 {
+		return latencySummary{}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	var total time.Duration
+	for _, s := range samples {
+		total += s
+	}
+	return latencySummary{
+		count: len(samples),
+		avg:   total / time.Duration(len(samples)),
+		min:   samples[0],
+		p50:   percentileOf(samples, 50),
+		p95:   percentileOf(samples, 95),
+		p99:   percentileOf(samples, 99),
+		max:   samples[len(samples)-1],
+	}
+}
+
+// percentileOf returns the nearest-rank percentile p (0-100) of sorted samples
+func percentileOf(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// LatencyPercentile is one of the --percentiles of a latency distribution.
+// Every sample is kept, so it is exact rather than a histogram estimate, but
+// it only ranks below the maximum with at least Needed samples.
+type LatencyPercentile struct {
+	Percentile float64       `json:"percentile"`
+	Latency    time.Duration `json:"latency_ns"`
+	Samples    int           `json:"samples"`
+	Needed     int           `json:"samples_needed"`
+}
+
+// parsePercentiles parses a comma separated list of ascending percentiles in (0, 100]
+func parsePercentiles(spec string) ([]float64, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	var percentiles []float64
+	for _, field := range strings.Split(spec, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || !(p > 0 && p <= 100) { // NaN fails every comparison
+			return nil, fmt.Errorf("percentile %q: must be a number in (0, 100]", field)
+		}
+		if len(percentiles) > 0 && p <= percentiles[len(percentiles)-1] {
+			return nil, fmt.Errorf("percentile %g: percentiles must be ascending", p)
+		}
+		percentiles = append(percentiles, p)
+	}
+	return percentiles, nil
+}
+
+// percentilesOf returns the percentiles ps of samples, which is sorted in place
+func percentilesOf(samples []time.Duration, ps []float64) []LatencyPercentile {
+	if len(samples) == 0 || len(ps) == 0 {
+		return nil
+	}
+	slices.Sort(samples)
+	percentiles := make([]LatencyPercentile, len(ps))
+	for i, p := range ps {
+		percentiles[i] = LatencyPercentile{Percentile: p, Latency: percentileOf(samples, p), Samples: len(samples), Needed: percentileSamples(p)}
+	}
+	return percentiles
+}
+
+// percentileSamples returns how many samples the nearest-rank percentile p needs
+// to rank below the maximum, 1 for p100
+func percentileSamples(p float64) int {
+	if p >= 100 {
+		return 1
+	}
+	return int(math.Ceil(100/(100-p) - 1e-9))
+}
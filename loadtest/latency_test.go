@@ -0,0 +1,49 @@
+package loadtest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePercentiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []float64
+		wantErr string // substring of the error, "" for none
+	}{
+		{"empty", "", nil, ""},
+		{"blank", "  ", nil, ""},
+		{"one", "99", []float64{99}, ""},
+		{"ascending with spaces", "50, 90 ,99.9,100", []float64{50, 90, 99.9, 100}, ""},
+		{"smallest above zero", "0.001", []float64{0.001}, ""},
+		{"zero", "0,50", nil, `percentile "0"`},
+		{"negative", "-1", nil, `percentile "-1"`},
+		{"above 100", "50,100.5", nil, `percentile "100.5"`},
+		{"not a number", "p99", nil, `percentile "p99"`},
+		{"NaN", "NaN", nil, `percentile "NaN"`},
+		{"infinite", "Inf", nil, `percentile "Inf"`},
+		{"empty entry", "50,,99", nil, `percentile ""`},
+		{"trailing comma", "50,", nil, `percentile ""`},
+		{"descending", "99,50", nil, "percentile 50: percentiles must be ascending"},
+		{"repeated", "90,90", nil, "percentile 90: percentiles must be ascending"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePercentiles(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("parsePercentiles(%q) = %v, want an error containing %q", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePercentiles(%q) = %v", tt.spec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePercentiles(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// ListedCollection is one collection the list command found on the server
+type ListedCollection struct {
+	Name      string   `json:"name"`
+	Rows      int64    `json:"rows"` // -1 when the statistics could not be read
+	LoadState string   `json:"load_state"`
+	Indexes   []string `json:"indexes"` // field:index type per indexed vector field
+}
+
+// runList lists the collections on the server whose name matches --list-filter,
+// with their row count, load state and vector index types, and changes nothing
+func runList(ctx context.Context, lt *loadTest) error {
+	filter := lt.cfg.ListFilter
+	if filter == "" {
+		filter = "*"
+	}
+	fmt.Printf("\n--- Collections matching '%s' ---\n", filter)
+	collections, err := lt.client.ListCollections(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list collections: %w", err)
+	}
+	var names []string
+	for _, coll := range collections {
+		if ok, _ := path.Match(filter, coll.Name); ok { // the pattern is checked by resolve
+			names = append(names, coll.Name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		listed, err := lt.listCollection(ctx, name)
+		if err != nil {
+			return fmt.Errorf("collection '%s': %w", name, err)
+		}
+		lt.m.listed = append(lt.m.listed, listed)
+	}
+	if len(names) == 0 {
+		fmt.Printf("No collection matches '%s'.\n", filter)
+		return nil
+	}
+	fmt.Printf("   %-48s %12s %-11s %s\n", "Collection", "Rows", "Load State", "Indexes")
+	for _, c := range lt.m.listed {
+		rows := strconv.FormatInt(c.Rows, 10)
+		if c.Rows < 0 {
+			rows = "?"
+		}
+		indexes := strings.Join(c.Indexes, ", ")
+		if indexes == "" {
+			indexes = "none"
+		}
+		fmt.Printf("   %-48s %12s %-11s %s\n", c.Name, rows, c.LoadState, indexes)
+	}
+	fmt.Printf("✅ %d of %d collections listed.\n", len(names), len(collections))
+	return nil
+}
+
+// listCollection reads the row count, load state and vector indexes of one collection
+func (lt *loadTest) listCollection(ctx context.Context, name string) (ListedCollection, error) {
+	listed := ListedCollection{Name: name, Rows: -1}
+	coll, err := lt.client.DescribeCollection(ctx, name)
+	if err != nil {
+		return listed, fmt.Errorf("failed to describe collection: %w", err)
+	}
+	state, err := lt.client.GetLoadState(ctx, name, nil)
+	if err != nil {
+		return listed, fmt.Errorf("failed to get load state: %w", err)
+	}
+	listed.LoadState = loadStateNames[state]
+	if stats, err := lt.client.GetCollectionStatistics(ctx, name); err == nil {
+		if rows, err := strconv.ParseInt(stats["row_count"], 10, 64); err == nil {
+			listed.Rows = rows
+		}
+	}
+	for _, field := range coll.Schema.Fields {
+		if field.DataType != entity.FieldTypeFloatVector && field.DataType != entity.FieldTypeBinaryVector &&
+			field.DataType != entity.FieldTypeFloat16Vector && field.DataType != entity.FieldTypeBFloat16Vector &&
+			field.DataType != entity.FieldTypeSparseVector {
+			continue
+		}
+		indexes, err := lt.client.DescribeIndex(ctx, name, field.Name)
+		if err != nil {
+			continue // not indexed
+		}
+		for _, index := range indexes {
+			listed.Indexes = append(listed.Indexes, field.Name+":"+string(index.IndexType()))
+		}
+	}
+	return listed, nil
+}
@@ -0,0 +1,117 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// The steps --load-order arranges, in their default order
+const (
+	stepFlush = "flush"
+	stepIndex = "index"
+	stepLoad  = "load"
+)
+
+// defaultLoadOrder is the historical flush -> index -> load sequence
+var defaultLoadOrder = []string{stepFlush, stepIndex, stepLoad}
+
+// stepHandoff is the wait --load-order adds when the index is created before the
+// flush: the sealed segments are then indexed and handed off to the query nodes
+// in the background, after the steps themselves returned
+const stepHandoff = "handoff"
+
+// handoffPoll is how often the handoff wait checks the index and load progress
+const handoffPoll = time.Second
+
+// StepTiming is how long one step of the --load-order sequence took
+type StepTiming struct {
+	Step     string        `json:"step"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// parseLoadOrder parses --load-order, a comma-separated permutation of flush,
+// index and load. Milvus only loads a collection whose vector field is indexed,
+// whatever the index type, so load has to come after index; an index created
+// before the flush is built per segment as the flush seals it.
+func parseLoadOrder(spec string, indexType entity.IndexType) ([]string, error) {
+	order := strings.Split(spec, ",")
+	for i := range order {
+		order[i] = strings.TrimSpace(order[i])
+	}
+	sorted := slices.Clone(order)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, []string{stepFlush, stepIndex, stepLoad}) {
+		return nil, fmt.Errorf("want each of flush, index and load once, e.g. %s", strings.Join(defaultLoadOrder, ","))
+	}
+	if slices.Index(order, stepLoad) < slices.Index(order, stepIndex) {
+		return nil, fmt.Errorf("load before index: Milvus cannot load a collection without a vector index, %s included", indexType)
+	}
+	return order, nil
+}
+
+// prepare flushes, indexes and loads the collections in --load-order (steps 4-6).
+// When the index comes before the flush, the indexing of the freshly sealed
+// segments and their handoff to the query nodes is waited for and timed too,
+// which is the transition a load-before-flush order benchmarks.
+func (lt *loadTest) prepare(ctx context.Context) error {
+	steps := map[string]func(context.Context) error{
+		stepFlush: lt.flush,
+		stepIndex: lt.createIndex,
+		stepLoad:  lt.load,
+	}
+	for _, step := range lt.cfg.loadOrder {
+		if err := steps[step](ctx); err != nil {
+			return err
+		}
+	}
+	timings := map[string]time.Duration{stepFlush: lt.m.flushTime, stepIndex: lt.m.indexTime, stepLoad: lt.m.loadTime}
+	lt.m.loadOrder = lt.m.loadOrder[:0]
+	for _, step := range lt.cfg.loadOrder {
+		lt.m.loadOrder = append(lt.m.loadOrder, StepTiming{Step: step, Duration: timings[step]})
+	}
+	if slices.Index(lt.cfg.loadOrder, stepIndex) < slices.Index(lt.cfg.loadOrder, stepFlush) {
+		if err := lt.waitHandoff(ctx); err != nil {
+			return err
+		}
+		lt.m.loadOrder = append(lt.m.loadOrder, StepTiming{Step: stepHandoff, Duration: lt.m.handoffTime})
+	}
+	parts := make([]string, len(lt.m.loadOrder))
+	for i, t := range lt.m.loadOrder {
+		parts[i] = fmt.Sprintf("%s %s", t.Step, t.Duration.Round(time.Millisecond))
+	}
+	fmt.Printf("   -> Load order: %s\n", strings.Join(parts, " -> "))
+	return nil
+}
+
+// waitHandoff waits, for at most --flush-timeout, until the segments the flush
+// sealed are indexed and loaded
+func (lt *loadTest) waitHandoff(ctx context.Context) error {
+	fmt.Println("\nWaiting for the sealed segments to be indexed and handed off...")
+	lt.phaseStart(stepHandoff)
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, lt.cfg.FlushTimeout)
+	defer cancel()
+	for {
+		settled, err := lt.settled(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the handoff progress: %w", err)
+		}
+		if settled {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("handoff did not complete within %s", lt.cfg.FlushTimeout)
+		case <-time.After(handoffPoll):
+		}
+	}
+	lt.m.handoffTime = time.Since(start)
+	lt.phaseEnd(stepHandoff, lt.m.handoffTime)
+	fmt.Printf("✅ Sealed segments indexed and handed off in %s.\n", lt.m.handoffTime)
+	return nil
+}
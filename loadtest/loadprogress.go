@@ -0,0 +1,62 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// loadPollInterval is how often the load progress is polled, loadProgressInterval
+// how often it is printed while the load runs
+const (
+	loadPollInterval     = 500 * time.Millisecond
+	loadProgressInterval = 5 * time.Second
+)
+
+// PartialLoad is a collection that was not fully loaded within --load-timeout
+type PartialLoad struct {
+	Collection    string `json:"collection"`
+	LoadedPercent int64  `json:"loaded_percent"`
+}
+
+// loadCollection starts loading the named collection and polls its progress,
+// printing it every loadProgressInterval. A collection still not fully loaded
+// after --load-timeout is recorded as a partial load and the run goes on to
+// search what is loaded, with a warning; only a collection of which nothing
+// loaded fails the step.
+func (lt *loadTest) loadCollection(ctx context.Context, name string) error {
+	if err := lt.client.LoadCollection(ctx, name, true, lt.loadOptions()...); err != nil {
+		return fmt.Errorf("failed to load collection: %w", err)
+	}
+	start := time.Now()
+	lastReport := start
+	for {
+		progress, err := lt.client.GetLoadingProgress(ctx, name, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get the load progress: %w", err)
+		}
+		if progress >= 100 {
+			return nil
+		}
+		now := time.Now()
+		if now.Sub(start) >= lt.cfg.LoadTimeout {
+			if progress == 0 {
+				return fmt.Errorf("nothing of the collection loaded within --load-timeout %s", lt.cfg.LoadTimeout)
+			}
+			lt.partialLoadMu.Lock()
+			lt.m.partialLoads = append(lt.m.partialLoads, PartialLoad{Collection: name, LoadedPercent: progress})
+			lt.partialLoadMu.Unlock()
+			fmt.Printf("⚠️  '%s' is only %d%% loaded after --load-timeout %s, searching what is loaded.\n", name, progress, lt.cfg.LoadTimeout)
+			return nil
+		}
+		if now.Sub(lastReport) >= loadProgressInterval {
+			fmt.Printf("   -> [%s] '%s': %d%% loaded\n", now.Sub(start).Round(time.Second), name, progress)
+			lastReport = now
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(loadPollInterval):
+		}
+	}
+}
@@ -0,0 +1,1384 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+const (
+	// Collection settings
+	collectionName  = "go_high_throughput_collection"
+	embeddingDim    = 8
+	primaryKeyField = "id"
+	embeddingField  = "embedding"
+)
+
+// collectionTTLProperty is the collection property holding the data TTL in seconds
+const collectionTTLProperty = "collection.ttl.seconds"
+
+// flushPollInterval is how often the flush state is polled, flushProgressInterval
+// how often the progress is printed meanwhile
+const (
+	flushPollInterval     = 500 * time.Millisecond
+	flushProgressInterval = 5 * time.Second
+)
+
+// metrics tracks everything reported in the summary table
+type metrics struct {
+	connectionTime         time.Duration
+	insertionTime          time.Duration
+	flushTime              time.Duration
+	indexTime              time.Duration
+	loadTime               time.Duration
+	handoffTime            time.Duration // indexing the segments sealed after the index was created
+	loadOrder              []StepTiming  // flush, index and load in --load-order, then the handoff
+	searchTime             time.Duration
+	cleanupTime            time.Duration
+	cleanupAction          string // what step 8 did with the collection: dropped, released or kept
+	warmupTime             time.Duration
+	preSearchWait          time.Duration  // spent in --pre-search-delay
+	preSearchSettled       bool           // whether --pre-search-settle saw indexing and loading complete
+	canarySeries           []CanarySample // every --canary-queries search, in search order
+	canaryResults          []CanaryResult // per canary vector
+	reloadLatency          latencySummary // load times of the --load-repeats reloads
+	gc                     gcStats        // client garbage collections over the run
+	failovers              []failoverEvent
+	addrOps                []int64 // successful inserts and searches served by each --milvus-addr
+	warmupFailures         int64
+	insertsPerSec          float64
+	searchesPerSec         float64
+	totalVectorsInserted   int64 // rows Milvus acknowledged
+	insertBatches          int64 // batches sent, including failed ones
+	insertBatchRows        int64 // rows submitted in them
+	finalBatchSize         int
+	minBatchSize           int
+	maxBatchSize           int
+	scalarBytesInserted    int64
+	totalSearchesPerformed int64
+	failedInserts          int64
+	partialInserts         int64 // batches Milvus acknowledged only some rows of
+	rowsRejected           int64 // rows of those not acknowledged
+	recoveredInserts       int64
+	insertRetries          int64
+	failedSearches         int64
+	recoveredSearches      int64
+	searchRetries          int64
+	projectionIDsOnly      latencySummary
+	projectionWithFields   latencySummary
+	searchLatency          latencySummary
+	collectionMemoryBytes  float64 // query node memory held by the loaded collection
+	collectionMemoryKnown  bool
+	loadRSSDeltaBytes      float64 // server resident memory growth during load
+	loadRSSDeltaKnown      bool
+	burstBaseline          windowStats
+	burstPeak              windowStats
+	preFlushLatency        latencySummary
+	postFlushLatency       latencySummary
+	throttledInserts       int64
+	throttledSearches      int64
+	throttlePauseTime      time.Duration
+	firstInsertThrottle    time.Duration // offset into the insert phase, 0 if never throttled
+	firstThrottleBatchSize int
+	firstSearchThrottle    time.Duration // offset into the search phase, 0 if never throttled
+	replicaCount           int
+	segmentsSealed         int
+	flushRequests          int           // flush RPCs issued under --flush-strategy
+	flushRequestTime       time.Duration // time spent in them, until segments are sealed
+	periodicFlushes        int           // --flush-every-n-batches flushes during the insert phase
+	periodicFlushTime      time.Duration
+	periodicFlushSegments  int // segments they sealed
+	periodicFlushFailures  int
+	periodicFlushCoalesced int   // triggers that arrived while a flush was running
+	flushedSegments        int   // segments persisted once the flush completed
+	flushedSegmentRows     int64 // rows in those segments
+	insertSeries           throughputSeries
+	searchSeries           throughputSeries
+	recallSum              float64
+	recallQueries          int64
+	exactRecall            float64 // recall@k of the --recall-check queries
+	exactRecallQueries     int
+	flatRecall             float64 // recall@k of the same queries on IVF_FLAT, for a quantized --index-type
+	validatedSearches      int64   // successful searches checked by --validate-results
+	emptyResults           int64   // of which returned no hits
+	shortResults           int64   // or fewer than expected
+	sweep                  []SweepPoint
+	topKSweep              []SweepPoint // one point per --topk-sweep level
+	topKKnee               int
+	maxQPSProbes           []SweepPoint   // every --find-max-qps probe
+	maxQPS                 SweepPoint     // the highest one that met the bound
+	directLatency          latencySummary // --alias comparison batch by collection name
+	aliasLatency           latencySummary // and through the alias
+	grpcLatency            latencySummary // --transport rest comparison batch over gRPC
+	restLatency            latencySummary // and over REST
+	iteratorOpen           latencySummary // --iterator: creating the iterator, which fetches a first page
+	iteratorPages          latencySummary // every Next call
+	iteratorRetrievals     latencySummary // all pages of one query vector
+	iteratorHits           int64
+	iteratorDuplicates     int64 // hits returned again within one retrieval
+	iteratorTime           time.Duration
+	searchPercentiles      []LatencyPercentile // of the search phase, per --percentiles
+	hotSegment             []HotSegmentResult  // one per --hot-segment layout
+	batchSweep             []BatchSweepResult  // one per --batch-sweep size
+	indexTypeSearch        []IndexTypeResult   // search phase per --index-mix index type
+	scalarIndexes          []ScalarIndexResult
+	rebuildBefore          windowStats
+	rebuildDuring          windowStats
+	rebuildAfter           windowStats
+	rebuildErr             error
+	freshnessLag           latencySummary
+	freshnessMissed        int
+	timeToSearchable       time.Duration // --time-to-searchable, from the sentinel's insert
+	searchableAfterLoad    time.Duration // and from the end of the load
+	sentinelMissed         bool
+	mixTime                time.Duration
+	timeWindowRows         int64 // rows in the --time-window filter
+	timeWindowTotal        int64
+	timeWindowQueryTime    time.Duration
+	collectionInfo         []CollectionInfo     // described once loaded
+	timeline               []PhaseSpan          // wall clock span of every phase, in start order
+	workerTimes            []workerTime         // per insert worker
+	exportedRows           int64                // written to --export-vectors
+	batchBuckets           map[int]*batchBucket // insert latency by power-of-two batch size
+	connStats              []ConnectionStats    // per --connections, nil with one
+	serverSamples          []ServerSample       // --server-metrics-interval scrapes
+	partialLoads           []PartialLoad        // collections not fully loaded within --load-timeout
+	fieldChecks            []FieldCheck         // --verify-fields values checked by the search phase
+	listed                 []ListedCollection   // what the list command found
+	coolDown               []windowStats        // --cool-down steps, in order
+	faults                 []FaultResult        // per --fault-injection-rate kind
+	orphans                []Orphan             // what the cleanup-orphans command dropped or would drop
+	ddlCreates             Snapshot             // --ddl-stress collection creates
+	ddlDrops               Snapshot             // and drops
+	ddlTime                time.Duration
+	ddlLeftovers           int // collections still there after the workers stopped
+	ddlRetries             int // collection existence checks, creates and drops sent again
+	ddlRaces               int // creates and drops another client had already done
+}
+
+// insertedBytes returns the raw size of the inserted vectors and scalar values
+func (m *metrics) insertedBytes() int64 {
+	return embeddingBytes(m.totalVectorsInserted) + m.scalarBytesInserted
+}
+
+// submittedPerSec returns the rows the insert phase submitted per second,
+// acknowledged or not, to compare with insertsPerSec
+func (m *metrics) submittedPerSec() float64 {
+	if m.insertionTime <= 0 {
+		return 0
+	}
+	return float64(m.insertBatchRows) / m.insertionTime.Seconds()
+}
+
+// avgBatchSize returns the mean number of rows per insert batch sent
+func (m *metrics) avgBatchSize() float64 {
+	if m.insertBatches == 0 {
+		return 0
+	}
+	return float64(m.insertBatchRows) / float64(m.insertBatches)
+}
+
+// recall returns the mean recall@k of the searches that had ground truth
+func (m *metrics) recall() float64 {
+	if m.recallQueries == 0 {
+		return 0
+	}
+	return m.recallSum / float64(m.recallQueries)
+}
+
+// windowStats aggregates the searches issued during one kind of burst window
+type windowStats struct {
+	searches  int64
+	failures  int64
+	elapsed   time.Duration
+	latencies []time.Duration
+	latency   latencySummary
+}
+
+// add records one search of the window
+func (w *windowStats) add(latency time.Duration, err error) {
+	w.searches++
+	if err != nil {
+		w.failures++
+		return
+	}
+	w.latencies = append(w.latencies, latency)
+}
+
+// qps returns the search rate achieved during the window
+func (w *windowStats) qps() float64 {
+	if w.elapsed <= 0 {
+		return 0
+	}
+	return float64(w.searches-w.failures) / w.elapsed.Seconds()
+}
+
+// errorRate returns the percentage of failed searches during the window
+func (w *windowStats) errorRate() float64 {
+	if w.searches == 0 {
+		return 0
+	}
+	return 100 * float64(w.failures) / float64(w.searches)
+}
+
+// loadTest holds the state shared by the steps of a run
+type loadTest struct {
+	cfg            *Config
+	client         client.Client
+	conns          []client.Client // search connections, conns[0] is client
+	connMu         sync.RWMutex    // guards client and conns against a failover during a phase
+	connGen        int
+	addrIndex      int       // the --milvus-addr the connections point at
+	start          time.Time // start of the run
+	collections    []string  // test collection names, one unless --collections is set
+	schema         *entity.Schema
+	wire           *wireStats
+	exporter       *vectorExporter // --export-vectors file, nil outside the insert phase
+	ids            *idPool         // recently inserted primary keys, nil when --id-pool-size is 0
+	queries        *querySet       // query vectors from --query-file, nil for random queries
+	faults         *faultInjector  // --fault-injection-rate, nil without
+	events         *eventStream
+	timelineMu     sync.Mutex // guards m.timeline
+	partialLoadMu  sync.Mutex // guards m.partialLoads
+	nextID         atomic.Int64
+	resumed        *checkpoint // the --checkpoint a --resume run continues
+	vectorPool     *vectorPool // the --distinct-vectors inserted rows are drawn from
+	aliasCreated   bool
+	insertEnd      time.Time // end of this run's insert phase, zero if it had none
+	sentinel       *sentinel // the --time-to-searchable row, nil without
+	budgetExceeded bool      // the run was stopped by --max-runtime
+	rebuild        rebuildTimeline
+	m              metrics
+
+	// clientOps is how many operations the running insert or search phase has
+	// done so far, read by the --server-metrics-interval scraper
+	clientOps atomic.Int64
+}
+
+// newSchema returns the schema of the test collection
+func newSchema(autoID bool, vectorParams map[string]string, scalars []scalarField) *entity.Schema {
+	schema := &entity.Schema{
+		CollectionName: collectionName,
+		Fields: []*entity.Field{
+			{Name: primaryKeyField, DataType: entity.FieldTypeInt64, PrimaryKey: true, AutoID: autoID},
+			vectorField(vectorParams),
+		},
+	}
+	for _, scalar := range scalars {
+		schema.Fields = append(schema.Fields, scalar.schemaField())
+	}
+	return schema
+}
+
+// vectorField returns the embedding field with the --vector-params type params
+func vectorField(params map[string]string) *entity.Field {
+	field := entity.NewField().WithName(embeddingField).WithDataType(embeddingType.fieldType).WithDim(embeddingDim)
+	for key, value := range params {
+		field.WithTypeParams(key, value)
+	}
+	return field
+}
+
+// connect opens the Milvus client (step 1), to the first reachable --milvus-addr
+func (lt *loadTest) connect(ctx context.Context) error {
+	fmt.Println("\n--- Step 1: Connect to Milvus ---")
+	lt.phaseStart("connect")
+	connectStart := time.Now()
+	if lt.cfg.WireStats {
+		lt.wire = &wireStats{}
+	}
+	lt.m.addrOps = make([]int64, len(lt.cfg.addrs))
+	var err error
+	for i, addr := range lt.cfg.addrs {
+		fmt.Printf("Attempting to connect to Milvus at %s...\n", addr)
+		if lt.conns, err = lt.dial(ctx, addr); err == nil {
+			lt.addrIndex = i
+			break
+		}
+		if i+1 < len(lt.cfg.addrs) {
+			next := lt.cfg.addrs[i+1]
+			lt.m.failovers = append(lt.m.failovers, failoverEvent{at: time.Since(lt.start), from: addr, to: next, cause: err.Error()})
+			fmt.Printf("🔀 FAILOVER: %s unreachable (%v), trying %s\n", addr, err, next)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to Milvus: %w", err)
+	}
+	lt.client = lt.conns[0]
+	lt.m.connStats = newConnectionStats(len(lt.conns), lt.cfg.numWorkers)
+	lt.m.connectionTime = time.Since(connectStart)
+	lt.phaseEnd("connect", lt.m.connectionTime)
+	fmt.Printf("✅ Connected to Milvus at %s successfully!\n", lt.cfg.addrs[lt.addrIndex])
+	if lt.cfg.Connections > 1 {
+		fmt.Printf("   -> Opened %d connections, search workers are spread across them\n", lt.cfg.Connections)
+	}
+	return nil
+}
+
+// close releases the Milvus connections
+func (lt *loadTest) close() {
+	for _, conn := range lt.conns {
+		conn.Close()
+	}
+}
+
+// dropExisting drops the collections left over by a previous run (step 2)
+func (lt *loadTest) dropExisting(ctx context.Context) error {
+	for _, name := range lt.collections {
+		fmt.Printf("\n--- Step 2: Check for and drop existing collection '%s' ---\n", name)
+		has, err := lt.hasCollection(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to check if collection exists: %w", err)
+		}
+		if has {
+			fmt.Printf("Collection '%s' already exists. Dropping it...\n", name)
+			if err := lt.dropCollection(ctx, name); err != nil {
+				return fmt.Errorf("failed to drop collection: %w", err)
+			}
+			fmt.Println("✅ Dropped existing collection.")
+		} else {
+			fmt.Println("Collection does not exist, proceeding.")
+		}
+	}
+	return nil
+}
+
+// createCollection creates the test collections (step 3)
+func (lt *loadTest) createCollection(ctx context.Context) error {
+	lt.schema = newSchema(lt.cfg.AutoID, lt.cfg.vectorParams, lt.cfg.scalars)
+	opts := []client.CreateCollectionOption{client.WithConsistencyLevel(lt.cfg.consistencyLevel)}
+	if lt.cfg.TTLSeconds > 0 {
+		opts = append(opts, client.WithCollectionProperty(collectionTTLProperty, strconv.Itoa(lt.cfg.TTLSeconds)))
+	}
+	for key, value := range lt.cfg.collectionProps {
+		opts = append(opts, client.WithCollectionProperty(key, value))
+	}
+	shards := int32(entity.DefaultShardNumber)
+	if lt.cfg.Shards > 0 {
+		shards = int32(lt.cfg.Shards)
+	}
+	for _, name := range lt.collections {
+		fmt.Printf("\n--- Step 3: Create collection '%s' ---\n", name)
+		schema := *lt.schema
+		schema.CollectionName = name
+		if err := lt.createCollectionRace(ctx, &schema, shards, opts...); err != nil {
+			return fmt.Errorf("failed to create collection: %w", err)
+		}
+		fmt.Println("✅ Collection created successfully.")
+	}
+	return lt.createAlias(ctx)
+}
+
+// useExisting checks that the collections populated by a previous run exist and
+// match the configured schema, and picks up their schema
+func (lt *loadTest) useExisting(ctx context.Context) error {
+	want := newSchema(lt.cfg.AutoID, lt.cfg.vectorParams, lt.cfg.scalars)
+	inserting := lt.cfg.Command == "insert" || len(lt.cfg.mix) > 0 || lt.cfg.FreshnessProbes > 0
+	for _, name := range lt.collections {
+		fmt.Printf("\n--- Step 2: Use existing collection '%s' ---\n", name)
+		has, err := lt.hasCollection(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to check if collection exists: %w", err)
+		}
+		if !has {
+			return fmt.Errorf("collection '%s' does not exist, populate it first with the insert command", name)
+		}
+		coll, err := lt.client.DescribeCollection(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to describe collection: %w", err)
+		}
+		if diff := schemaDiff(want, coll.Schema, inserting); len(diff) > 0 {
+			fmt.Printf("❌ Collection '%s' does not match the configured schema:\n", name)
+			for _, line := range diff {
+				fmt.Printf("   %s\n", line)
+			}
+			return withCategory(ErrConfig, fmt.Errorf("collection '%s' has an incompatible schema (%d differences): adjust the options to match it, or drop it with the cleanup command",
+				name, len(diff)))
+		}
+		if hasMixOp(lt.cfg.mix, "upsert") && primaryKeyAutoID(coll.Schema) {
+			return withCategory(ErrConfig, fmt.Errorf("collection '%s' generates its keys with AutoID, which Milvus cannot upsert into: drop upsert from --mix or populate a collection with --auto-id=false", name))
+		}
+		lt.schema = coll.Schema
+		fmt.Println("✅ Found existing collection.")
+	}
+	return lt.createAlias(ctx)
+}
+
+// insert inserts data continuously for the configured duration, with optional ramp-up (step 4)
+func (lt *loadTest) insert(ctx context.Context) {
+	cfg := lt.cfg
+	maxBytes := int64(cfg.MaxDataMB * 1024 * 1024)
+	if maxBytes > 0 && lt.resumed != nil {
+		maxBytes -= lt.resumed.BytesInserted // the resumed run inserted these already
+	}
+	if maxBytes > 0 {
+		fmt.Printf("\n--- Step 4: Starting continuous data insertion until %.2f MB are inserted ---\n", cfg.MaxDataMB)
+	} else {
+		fmt.Printf("\n--- Step 4: Starting continuous data insertion for %s ---\n", cfg.Duration)
+	}
+	if cfg.RampUp {
+		fmt.Printf("📈 RAMP-UP MODE: Gradually increasing load from %g%% to 100%%...\n", cfg.RampStartPct)
+	}
+	if cfg.DistinctVectors > 0 && lt.vectorPool == nil {
+		var ok bool
+		if lt.vectorPool, ok = newVectorPool(ctx, cfg.DistinctVectors, cfg.normalize); !ok {
+			return
+		}
+		fmt.Printf("🔁 DUPLICATION MODE: rows are drawn from %d distinct vectors\n", cfg.DistinctVectors)
+	}
+	if faults := lt.faults; faults != nil {
+		fmt.Printf("💣 FAULT INJECTION: %g malformed batches per insert batch, of kinds %v; Milvus must reject them\n", cfg.FaultRate, faults.kinds)
+	}
+
+	var wg sync.WaitGroup
+	insertionStartTime := time.Now()
+	testEndTime := insertionStartTime.Add(cfg.Duration)
+	lt.phaseStart("insert")
+
+	// The aggregator owns the insert metrics; workers only see the published totals
+	var insertedBytes atomic.Int64
+	lastThroughput := 0.0
+	var watermark *rowWatermark
+	if cfg.Checkpoint != "" {
+		watermark = lt.newRowWatermark()
+		fmt.Printf("💾 Recording progress in %s every %s\n", cfg.Checkpoint, checkpointInterval)
+	}
+	lt.m.workerTimes = make([]workerTime, cfg.numWorkers)
+	flusher := lt.startPeriodicFlusher(ctx)
+	var batchesInserted int64
+	agg := startAggregator(func(r insertResult) (int64, int64) {
+		m := &lt.m
+		if r.fault > 0 {
+			m.addFault(r)
+			if r.err != nil {
+				lt.events.opError("insert", r.worker, 0, r.err)
+			}
+			return m.totalVectorsInserted, m.failedInserts
+		}
+		if watermark != nil {
+			watermark.complete(r.first, batchOutcome{rows: int64(r.rows), inserted: r.err == nil, bytes: embeddingBytes(int64(r.rows)) + r.scalarBytes})
+			watermark.save(cfg.Checkpoint, false)
+		}
+		m.insertRetries += int64(r.attempts)
+		m.workerTimes[r.worker].generation += r.took.generation
+		m.workerTimes[r.worker].rpc += r.took.rpc
+		m.insertBatches++
+		m.insertBatchRows += int64(r.rows)
+		m.finalBatchSize = r.rows
+		if m.insertBatches == 1 || r.rows < m.minBatchSize {
+			m.minBatchSize = r.rows
+		}
+		m.maxBatchSize = max(m.maxBatchSize, r.rows)
+		m.throttlePauseTime += r.paused
+		if r.throttled > 0 {
+			if m.throttledInserts == 0 {
+				m.firstInsertThrottle = r.at
+				m.firstThrottleBatchSize = r.rows
+			}
+			m.throttledInserts += int64(r.throttled)
+		}
+		if r.err != nil {
+			m.failedInserts++
+		} else {
+			if r.acked < r.rows {
+				m.partialInserts++
+				m.rowsRejected += int64(r.rows - r.acked)
+			}
+			m.totalVectorsInserted += int64(r.acked)
+			if m.connStats != nil {
+				m.connStats[r.conn].VectorsInserted += int64(r.acked)
+			}
+			m.addBatchLatency(r.rows, r.took.rpc)
+			m.scalarBytesInserted += r.scalarBytes
+			m.addrOps[r.addr]++
+			if r.attempts > 0 {
+				m.recoveredInserts++
+			}
+			if batchesInserted++; flusher != nil {
+				flusher.batchInserted(batchesInserted)
+			}
+			// Real-time monitoring
+			if cfg.RealTime && m.insertBatches%10 == 0 {
+				elapsed := time.Since(insertionStartTime)
+				currentThroughput := float64(m.totalVectorsInserted) / elapsed.Seconds()
+				if currentThroughput != lastThroughput {
+					fmt.Printf("📊 [%s] Batch Size: %d, Throughput: %.1f ops/sec\n",
+						elapsed.Round(time.Second), r.rows, currentThroughput)
+					lastThroughput = currentThroughput
+				}
+			}
+		}
+		insertedBytes.Store(m.insertedBytes())
+		return m.totalVectorsInserted, m.failedInserts
+	}, lt.sampler("insert", insertionStartTime, &lt.m.insertSeries))
+
+	// Without AutoID the tool owns the keys: hand out disjoint ranges of row numbers,
+	// mapped to keys by --pk-strategy
+	autoID := primaryKeyAutoID(lt.schema)
+	if lt.faults != nil {
+		lt.faults.start(ctx, autoID, agg.send)
+	}
+	if !autoID {
+		fmt.Printf("🔑 Assigning %s primary keys (AutoID off)\n", cfg.PKStrategy)
+	} else if lt.ids != nil {
+		fmt.Println("🔑 Capturing AutoID-generated primary keys from insert results")
+	}
+
+	// Start all worker goroutines
+	for i := 0; i < cfg.numWorkers; i++ {
+		wg.Add(1)
+		go func(goroutineID int) {
+			defer wg.Done()
+			fmt.Printf("[Worker %d] Starting continuous insertion...\n", goroutineID)
+			rand.Seed(time.Now().UnixNano() + int64(goroutineID))
+
+			connIndex := goroutineID % cfg.Connections
+			collection := lt.dataTarget(goroutineID)
+			batchCount := 0
+			pause := throttlePause{base: cfg.ThrottleBackoff}
+			_, largestBatch := jitterRange(cfg.batchSize, cfg.BatchJitter)
+			batch := lt.newBatchBuilder(autoID, largestBatch)
+
+			for maxBytes > 0 || time.Now().Before(testEndTime) {
+				if ctx.Err() != nil {
+					break
+				}
+				// In volume mode the data size, not the duration, ends the phase
+				if maxBytes > 0 && insertedBytes.Load() >= maxBytes {
+					break
+				}
+
+				// Calculate dynamic load if ramp-up is enabled
+				currentBatchSize := cfg.batchSize
+				if cfg.RampUp {
+					elapsed := time.Since(insertionStartTime)
+					var currentWorkers int
+					currentWorkers, currentBatchSize = calculateDynamicLoad(elapsed, cfg.Duration, cfg.numWorkers, cfg.batchSize, cfg.RampStartPct)
+					// Workers beyond the current ramp level wait for their turn
+					if goroutineID >= currentWorkers {
+						time.Sleep(rampIdlePoll)
+						continue
+					}
+				}
+				if cfg.BatchJitter > 0 {
+					currentBatchSize = jitterBatch(currentBatchSize, cfg.BatchJitter)
+				}
+				if lt.faults != nil && rand.Float64() < cfg.FaultRate {
+					lt.faults.request(goroutineID)
+				}
+
+				var took workerTime
+				buildStart := time.Now()
+				columns, keys, scalarBytes, ok := batch.build(ctx, currentBatchSize)
+				if !ok {
+					break
+				}
+				took.generation = time.Since(buildStart)
+				conn, ref := lt.conn(connIndex)
+				throttled := 0
+				var inserted entity.Column
+				attempts, err := cfg.retry.run(ctx, func() error {
+					var err error
+					rpcStart := time.Now()
+					inserted, err = conn.Insert(ctx, collection, "", columns...)
+					took.rpc += time.Since(rpcStart)
+					if isThrottled(err) {
+						throttled++
+					}
+					return err
+				})
+				acked := 0
+				if err == nil {
+					batch.track(keys, inserted)
+					acked = acknowledgedRows(inserted, currentBatchSize)
+				} else {
+					lt.failover(ctx, ref, err)
+				}
+				at := time.Since(insertionStartTime)
+				paused := pause.after(ctx, throttled > 0)
+				agg.send(insertResult{
+					rows:        currentBatchSize,
+					acked:       acked,
+					first:       batch.first,
+					scalarBytes: scalarBytes,
+					attempts:    attempts,
+					throttled:   throttled,
+					paused:      paused,
+					at:          at,
+					addr:        ref.addr,
+					worker:      goroutineID,
+					conn:        connIndex,
+					took:        took,
+					err:         err,
+				})
+
+				if err != nil {
+					log.Printf("[Worker %d] Failed to insert batch %d after %d retries: %v", goroutineID, batchCount, attempts, err)
+					lt.events.opError("insert", goroutineID, attempts, err)
+					continue
+				}
+				if acked < currentBatchSize {
+					log.Printf("[Worker %d] Batch %d partially inserted: %d of %d rows acknowledged", goroutineID, batchCount, acked, currentBatchSize)
+				}
+				batchCount++
+			}
+			fmt.Printf("[Worker %d] Finished after %d batches.\n", goroutineID, batchCount)
+		}(i)
+	}
+
+	wg.Wait()
+	if lt.faults != nil {
+		lt.faults.stop()
+	}
+	agg.close()
+	if flusher != nil {
+		flusher.stop()
+	}
+	if watermark != nil {
+		watermark.save(cfg.Checkpoint, true)
+		fmt.Printf("💾 Checkpoint %s: %d rows inserted in all, resume from row %d\n", cfg.Checkpoint, watermark.cp.RowsInserted, watermark.cp.NextRow)
+	}
+	lt.insertEnd = time.Now()
+	lt.m.insertionTime = lt.insertEnd.Sub(insertionStartTime)
+	lt.phaseEnd("insert", lt.m.insertionTime)
+	lt.m.insertsPerSec = float64(lt.m.totalVectorsInserted) / lt.m.insertionTime.Seconds()
+
+	fmt.Printf("✅ All workers finished inserting data in %s.\n", lt.m.insertionTime)
+	fmt.Printf("   -> Total vectors inserted: %d acknowledged of %d submitted\n", lt.m.totalVectorsInserted, lt.m.insertBatchRows)
+	if maxBytes > 0 {
+		fmt.Printf("   -> Data volume reached: %.2f MB (target %.2f MB)\n", float64(lt.volumeInserted())/(1024*1024), cfg.MaxDataMB)
+	}
+	fmt.Printf("   -> Throughput: %.2f inserts/second\n", lt.m.insertsPerSec)
+	if lt.exporter != nil {
+		lt.exporter.report()
+		lt.m.exportedRows = lt.exporter.rows.Load()
+		lt.exporter = nil // later inserts (--mix, --freshness) are not part of the dataset
+	}
+	lt.printGenerationSplit()
+	lt.m.printConnectionInserts()
+	if flusher != nil {
+		fmt.Printf("   -> Periodic flushes: %d (avg %s, %d segments sealed, %d coalesced, %d failed)\n", lt.m.periodicFlushes,
+			lt.m.avgPeriodicFlushTime(), lt.m.periodicFlushSegments, lt.m.periodicFlushCoalesced, lt.m.periodicFlushFailures)
+	}
+	if pool := lt.vectorPool; pool != nil {
+		fmt.Printf("   -> Duplication: %.1f%% of rows repeat a vector, %d of %d distinct vectors used\n",
+			100*pool.duplicationRatio(), pool.distinctDrawn(), len(pool.vectors))
+	}
+	if cfg.RampUp || cfg.BatchJitter > 0 {
+		fmt.Printf("   -> Effective batch size: avg %.0f, final %d, range %d-%d (configured %d)\n",
+			lt.m.avgBatchSize(), lt.m.finalBatchSize, lt.m.minBatchSize, lt.m.maxBatchSize, cfg.batchSize)
+	}
+	fmt.Printf("   -> Failed batches: %d (recovered by retry: %d)\n", lt.m.failedInserts, lt.m.recoveredInserts)
+	if lt.faults != nil {
+		fmt.Printf("   -> Of the failed batches, %d are malformed ones Milvus rejected as it should\n", lt.m.faultsRejected())
+		for _, f := range lt.m.faults {
+			fmt.Printf("   -> Injected %s batches: %d, rejected by Milvus: %d\n", f.Kind, f.Injected, f.Rejected)
+			if f.Accepted > 0 {
+				fmt.Printf("⚠️  Milvus accepted %d malformed %s batches\n", f.Accepted, f.Kind)
+			}
+		}
+	}
+	if lt.m.partialInserts > 0 {
+		fmt.Printf("⚠️  Partially inserted batches: %d, %d rows rejected by Milvus and not counted as inserted\n", lt.m.partialInserts, lt.m.rowsRejected)
+	}
+	if lt.m.throttledInserts > 0 {
+		fmt.Printf("   -> Throttled inserts: %d, first after %s at batch size %d\n",
+			lt.m.throttledInserts, lt.m.firstInsertThrottle.Round(time.Millisecond), lt.m.firstThrottleBatchSize)
+	}
+	if lt.ids != nil && lt.ids.total() > 0 {
+		source := "assigned"
+		if autoID {
+			source = "captured from AutoID"
+		}
+		fmt.Printf("   -> Primary keys tracked: %d (of %d %s)\n", lt.ids.len(), lt.ids.total(), source)
+	}
+}
+
+// acknowledgedRows returns how many of the submitted rows an Insert reply
+// acknowledged, going by the primary keys it returned for them. A reply without
+// keys is taken as acknowledging them all.
+func acknowledgedRows(ids entity.Column, submitted int) int {
+	if ids == nil {
+		return submitted
+	}
+	return min(ids.Len(), submitted)
+}
+
+// primaryKeyAutoID reports whether Milvus generates the primary keys of schema
+func primaryKeyAutoID(schema *entity.Schema) bool {
+	for _, field := range schema.Fields {
+		if field.PrimaryKey {
+			return field.AutoID
+		}
+	}
+	return true
+}
+
+// flush seals the segments of the collections
+func (lt *loadTest) flush(ctx context.Context) error {
+	fmt.Println("\nFlushing collection to seal segments...")
+	lt.phaseStart("flush")
+	flushStart := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, lt.cfg.FlushTimeout)
+	defer cancel()
+
+	var batched map[string][]int64
+	if lt.cfg.FlushStrategy == "batched" {
+		var err error
+		if batched, err = lt.flushBatched(ctx); err != nil {
+			return err
+		}
+	}
+	var mu sync.Mutex
+	timings, err := lt.forEachCollection(ctx, func(ctx context.Context, name string) error {
+		sealed, ok := batched[name]
+		if !ok {
+			requestStart := time.Now()
+			if _, err := lt.cfg.retry.run(ctx, func() error {
+				var err error
+				sealed, _, _, _, err = lt.client.FlushV2(ctx, name, true)
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to flush collection: %w", err)
+			}
+			mu.Lock()
+			lt.m.flushRequests++
+			lt.m.flushRequestTime += time.Since(requestStart)
+			mu.Unlock()
+		}
+		mu.Lock()
+		lt.m.segmentsSealed += len(sealed)
+		mu.Unlock()
+		fmt.Printf("   -> Sealed %d segments of '%s', waiting for them to be persisted...\n", len(sealed), name)
+		if err := lt.waitFlushed(ctx, name, sealed, flushStart); err != nil {
+			return err
+		}
+		// The resulting segment layout, to correlate with --shards and --collection-properties
+		segments, err := lt.client.GetPersistentSegmentInfo(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get segment info: %w", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, segment := range segments {
+			if segment.Flushed() {
+				lt.m.flushedSegments++
+				lt.m.flushedSegmentRows += segment.NumRows
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lt.m.flushTime = time.Since(flushStart)
+	lt.phaseEnd("flush", lt.m.flushTime)
+	fmt.Printf("✅ Data flushed successfully in %s (%d segments sealed).\n", lt.m.flushTime, lt.m.segmentsSealed)
+	if len(lt.collections) > 1 {
+		fmt.Printf("   -> %s: %d flush requests, %s in total\n", lt.cfg.FlushStrategy, lt.m.flushRequests, lt.m.flushRequestTime)
+	}
+	if lt.m.flushedSegments > 0 {
+		fmt.Printf("   -> %d flushed segments, %d rows each on average\n", lt.m.flushedSegments, lt.m.flushedSegmentRows/int64(lt.m.flushedSegments))
+	}
+	lt.printCollectionTimings(timings)
+	return nil
+}
+
+// waitFlushed polls the persistent segment info of the collection until every
+// sealed segment is flushed, printing progress every flushProgressInterval
+func (lt *loadTest) waitFlushed(ctx context.Context, name string, sealed []int64, flushStart time.Time) error {
+	pending := make(map[int64]bool, len(sealed))
+	for _, id := range sealed {
+		pending[id] = true
+	}
+	timedOut := func() error {
+		return fmt.Errorf("flush did not complete within %s: %d of %d segments still pending", lt.cfg.FlushTimeout, len(pending), len(sealed))
+	}
+
+	lastReport := flushStart
+	for len(pending) > 0 {
+		var segments []*entity.Segment
+		if _, err := lt.cfg.retry.run(ctx, func() error {
+			var err error
+			segments, err = lt.client.GetPersistentSegmentInfo(ctx, name)
+			return err
+		}); err != nil {
+			if ctx.Err() != nil {
+				return timedOut()
+			}
+			return fmt.Errorf("failed to get flush progress: %w", err)
+		}
+		for _, segment := range segments {
+			if segment.Flushed() {
+				delete(pending, segment.ID)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		if now := time.Now(); now.Sub(lastReport) >= flushProgressInterval {
+			fmt.Printf("   -> [%s] '%s': %d/%d segments flushed\n", now.Sub(flushStart).Round(time.Second), name, len(sealed)-len(pending), len(sealed))
+			lastReport = now
+		}
+		select {
+		case <-ctx.Done():
+			return timedOut()
+		case <-time.After(flushPollInterval):
+		}
+	}
+	return nil
+}
+
+// createIndex builds the vector index and waits for it (step 5)
+func (lt *loadTest) createIndex(ctx context.Context) error {
+	fmt.Printf("\n--- Step 5: Create index on field '%s' ---\n", embeddingField)
+	fmt.Println("Waiting for index to be built (this may take a while)...")
+	lt.phaseStart("index")
+	indexStartTime := time.Now()
+	timings, err := lt.forEachCollection(ctx, func(ctx context.Context, name string) error {
+		if err := lt.client.CreateIndex(ctx, name, embeddingField, lt.newIndex(name), false); err != nil {
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	lt.m.indexTime = time.Since(indexStartTime)
+	lt.phaseEnd("index", lt.m.indexTime)
+	fmt.Printf("✅ Index created successfully in %s.\n", lt.m.indexTime)
+	lt.printCollectionTimings(timings)
+	return nil
+}
+
+// load loads the collection into memory (step 6)
+func (lt *loadTest) load(ctx context.Context) error {
+	fmt.Println("\n--- Step 6: Load collection into memory ---")
+	url := metricsURL(lt.cfg.addrs[lt.addrIndex], lt.cfg.MetricsURL)
+	before, scrapeErr := scrapeMetrics(ctx, url)
+	lt.phaseStart("load")
+	loadStartTime := time.Now()
+	lt.m.partialLoads = nil
+	timings, err := lt.forEachCollection(ctx, lt.loadCollection)
+	if err != nil {
+		return err
+	}
+	lt.m.loadTime = time.Since(loadStartTime)
+	lt.phaseEnd("load", lt.m.loadTime)
+	if len(lt.m.partialLoads) > 0 {
+		fmt.Printf("⚠️  Load gave up after %s with %d of %d collections partially loaded; Milvus may reject searches on them, which count as failed searches.\n",
+			lt.m.loadTime, len(lt.m.partialLoads), len(lt.collections))
+	} else {
+		fmt.Printf("✅ Collection loaded successfully in %s.\n", lt.m.loadTime)
+	}
+	lt.printCollectionTimings(timings)
+	lt.describeReplicas(ctx)
+
+	if scrapeErr != nil {
+		fmt.Printf("⚠️  Memory footprint unavailable, could not scrape %s: %v\n", url, scrapeErr)
+		return nil
+	}
+	lt.measureLoadedMemory(ctx, url, before)
+	return nil
+}
+
+// loadOptions returns the options every load of the collection uses
+func (lt *loadTest) loadOptions() []client.LoadCollectionOption {
+	return []client.LoadCollectionOption{client.WithReplicaNumber(int32(lt.cfg.Replicas))}
+}
+
+// describeReplicas prints which query nodes serve each replica of the (first)
+// loaded collection, so a read-scaling benchmark can check the replicas landed
+// on distinct nodes
+func (lt *loadTest) describeReplicas(ctx context.Context) {
+	replicas, err := lt.client.GetReplicas(ctx, lt.collections[0])
+	if err != nil {
+		fmt.Printf("⚠️  Replica layout unavailable: %v\n", err)
+		return
+	}
+	lt.m.replicaCount = len(replicas)
+	for _, replica := range replicas {
+		leaders := make([]int64, 0, len(replica.ShardReplicas))
+		for _, shard := range replica.ShardReplicas {
+			leaders = append(leaders, shard.LeaderID)
+		}
+		fmt.Printf("   -> Replica %d: query nodes %v, shard leaders %v\n", replica.ReplicaID, replica.NodeIDs, leaders)
+	}
+}
+
+// measureLoadedMemory reports the query node memory held by the loaded collection
+// (index plus raw data), falling back to the server RSS growth during the load when
+// the per-collection gauge isn't exported
+func (lt *loadTest) measureLoadedMemory(ctx context.Context, url string, before []promSample) {
+	after, err := scrapeMetrics(ctx, url)
+	if err != nil {
+		fmt.Printf("⚠️  Memory footprint unavailable, could not scrape %s: %v\n", url, err)
+		return
+	}
+
+	for _, name := range lt.collections {
+		coll, err := lt.client.DescribeCollection(ctx, name)
+		if err != nil {
+			continue
+		}
+		collectionID := fmt.Sprintf("%d", coll.ID)
+		if bytes, ok := sumMetric(after, queryNodeEntitySizeMetric, map[string]string{"collection_id": collectionID}); ok {
+			lt.m.collectionMemoryBytes += bytes
+			lt.m.collectionMemoryKnown = true
+		}
+	}
+	rssBefore, okBefore := sumMetric(before, residentMemoryMetric, nil)
+	rssAfter, okAfter := sumMetric(after, residentMemoryMetric, nil)
+	if okBefore && okAfter {
+		lt.m.loadRSSDeltaBytes, lt.m.loadRSSDeltaKnown = rssAfter-rssBefore, true
+	}
+
+	if lt.m.collectionMemoryKnown {
+		fmt.Printf("   -> Query node memory for collection: %.2f MB\n", lt.m.collectionMemoryBytes/(1024*1024))
+	}
+	if lt.m.loadRSSDeltaKnown {
+		fmt.Printf("   -> Server RSS growth during load: %.2f MB\n", lt.m.loadRSSDeltaBytes/(1024*1024))
+	}
+}
+
+// search performs continuous searches for searchDuration (step 7)
+func (lt *loadTest) search(ctx context.Context, searchDuration time.Duration) {
+	cfg := lt.cfg
+	fmt.Printf("\n--- Step 7: Perform continuous searches for %s ---\n", searchDuration)
+	if cfg.RampUp {
+		fmt.Printf("📈 RAMP-UP MODE: Gradually increasing search workers from %g%% to 100%%...\n", cfg.RampStartPct)
+	}
+	if cfg.CoolDown > 0 {
+		fmt.Printf("📉 COOL-DOWN MODE: then decreasing search workers from 100%% to none over %s\n", cfg.CoolDown)
+		lt.m.coolDown = make([]windowStats, coolDownSteps)
+	}
+
+	var searchWg sync.WaitGroup
+	searchStartTime := time.Now()
+	coolDownStart := searchStartTime.Add(searchDuration)
+	searchEndTime := coolDownStart.Add(cfg.CoolDown)
+	lt.phaseStart("search")
+
+	// Optionally pace the searches, either at a constant rate or following the burst schedule
+	var searchPacer *pacer
+	schedule := cfg.burstSchedule()
+	switch {
+	case cfg.Burst:
+		searchPacer = newPacer(schedule.rate)
+		fmt.Printf("💥 BURST MODE: %g qps baseline, %g qps bursts for %s every %s\n",
+			schedule.baselineQPS, schedule.burstQPS, schedule.duration, schedule.interval)
+	case cfg.TargetQPS > 0:
+		searchPacer = newPacer(constantRate(cfg.TargetQPS))
+		fmt.Printf("⏱️  PACED MODE: %g searches/second across all workers\n", cfg.TargetQPS)
+	}
+
+	outputFields := cfg.verifyFieldNames()
+	if len(outputFields) > 0 {
+		fmt.Printf("🔎 VERIFYING FIELDS: every search returns %v, checked against the generated values\n", outputFields)
+	}
+
+	expected := -1
+	if cfg.ValidateResults {
+		if expected = lt.expectedHits(ctx, searchTopK); expected >= 0 {
+			fmt.Printf("🔎 VALIDATING RESULTS: every search must return %d hits\n", expected)
+		} else {
+			fmt.Println("🔎 VALIDATING RESULTS: every search must return hits")
+		}
+	}
+
+	// The aggregator owns the search metrics and latency lists
+	var searchLatencies []time.Duration
+	connLatencies := make([][]time.Duration, len(lt.conns))
+	collectionLatencies := make([][]time.Duration, len(lt.collections))
+	sample := lt.sampler("search", searchStartTime, &lt.m.searchSeries)
+	agg := startAggregator(func(r searchResult) (int64, int64) {
+		m := &lt.m
+		// the cool-down is reported on its own, the phase's figures are those of full load
+		if elapsed := r.start.Sub(coolDownStart); cfg.CoolDown > 0 && elapsed >= 0 {
+			m.coolDown[cfg.coolDownStep(elapsed)].add(r.latency, r.err)
+			return m.totalSearchesPerformed, m.failedSearches
+		}
+		m.searchRetries += int64(r.attempts)
+		m.throttlePauseTime += r.paused
+		if r.throttled > 0 {
+			if m.throttledSearches == 0 {
+				m.firstSearchThrottle = r.start.Add(r.latency).Sub(searchStartTime)
+			}
+			m.throttledSearches += int64(r.throttled)
+		}
+		if r.err != nil {
+			m.failedSearches++
+		} else {
+			m.totalSearchesPerformed++
+			m.addrOps[r.addr]++
+			searchLatencies = append(searchLatencies, r.latency)
+			connLatencies[r.conn] = append(connLatencies[r.conn], r.latency)
+			if len(cfg.indexMix) > 0 {
+				collectionLatencies[r.collection] = append(collectionLatencies[r.collection], r.latency)
+			}
+			if r.ids != nil {
+				m.recallSum += lt.queries.recall(r.queryIndex, r.ids, searchTopK)
+				m.recallQueries++
+			}
+			if r.attempts > 0 {
+				m.recoveredSearches++
+			}
+			if cfg.ValidateResults {
+				m.validatedSearches++
+				empty, short := classifyHits(r.hits, expected)
+				if empty {
+					m.emptyResults++
+				} else if short {
+					m.shortResults++
+				}
+			}
+			if r.fieldChecks != nil {
+				m.addFieldChecks(r.fieldChecks)
+			}
+		}
+		if cfg.RebuildIndex {
+			lt.rebuild.window(m, r.start).add(r.latency, r.err)
+		}
+		if cfg.Burst {
+			window := &m.burstBaseline
+			if schedule.inBurst(r.slot) {
+				window = &m.burstPeak
+			}
+			window.add(r.latency, r.err)
+		}
+		return m.totalSearchesPerformed, m.failedSearches
+	}, func(now time.Time, operations, failures int64) {
+		if cfg.CoolDown == 0 || now.Before(coolDownStart) {
+			sample(now, operations, failures)
+		}
+	})
+
+	var rebuildDone chan struct{}
+	if cfg.RebuildIndex {
+		rebuildDone = make(chan struct{})
+		fmt.Printf("🔧 INDEX REBUILD MODE: the index is rebuilt %s into the search phase\n", searchDuration/3)
+		go func() {
+			defer close(rebuildDone)
+			lt.rebuildIndex(ctx, searchDuration/3)
+		}()
+	}
+
+	var canaryDone chan struct{}
+	if cfg.CanaryQueries > 0 {
+		canaryDone = make(chan struct{})
+		fmt.Printf("🐤 CANARY QUERIES: %d fixed query vectors, each searched every %s\n", cfg.CanaryQueries, cfg.CanaryInterval)
+		go func() {
+			defer close(canaryDone)
+			lt.canaries(ctx, searchStartTime, coolDownStart) // drift compares full load with full load
+		}()
+	}
+
+	for i := 0; i < cfg.numWorkers; i++ {
+		searchWg.Add(1)
+		go func(goroutineID int) {
+			defer searchWg.Done()
+			fmt.Printf("[Search Worker %d] Starting continuous searches...\n", goroutineID)
+			rand.Seed(time.Now().UnixNano() + int64(goroutineID))
+			connIndex := goroutineID % len(lt.conns)
+			collectionIndex := goroutineID % len(lt.collections)
+			collection := lt.dataTarget(goroutineID)
+
+			searchCount := 0
+			lastRampWorkers := 0
+			pause := throttlePause{base: cfg.ThrottleBackoff}
+			for time.Now().Before(searchEndTime) && ctx.Err() == nil {
+				// Follow the insert ramp curve over the search duration
+				if cfg.RampUp {
+					elapsed := time.Since(searchStartTime)
+					currentWorkers, _ := calculateDynamicLoad(elapsed, searchDuration, cfg.numWorkers, cfg.batchSize, cfg.RampStartPct)
+					if goroutineID == 0 && cfg.RealTime && currentWorkers != lastRampWorkers {
+						searches := agg.operations.Load()
+						fmt.Printf("📈 [%s] Search Workers: %d/%d, Throughput: %.1f searches/sec\n",
+							elapsed.Round(time.Second), currentWorkers, cfg.numWorkers, float64(searches)/elapsed.Seconds())
+						lastRampWorkers = currentWorkers
+					}
+					if goroutineID >= currentWorkers {
+						time.Sleep(rampIdlePoll)
+						continue
+					}
+				}
+				// and the same curve backwards over the cool-down
+				if elapsed := time.Since(coolDownStart); cfg.CoolDown > 0 && elapsed >= 0 &&
+					goroutineID >= coolDownWorkers(elapsed, cfg.CoolDown, cfg.numWorkers) {
+					time.Sleep(rampIdlePoll)
+					continue
+				}
+
+				var slot time.Duration
+				if searchPacer != nil {
+					var ok bool
+					if slot, ok = searchPacer.wait(ctx, searchEndTime); !ok {
+						break
+					}
+				}
+
+				var queryIndex int
+				var queryVector []entity.Vector
+				if lt.queries != nil {
+					queryIndex, queryVector = lt.queries.pick()
+				} else {
+					queryVector = randomQueryVector()
+				}
+				searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+
+				conn, ref := lt.conn(connIndex)
+				searchStart := time.Now()
+				throttled := 0
+				var results []client.SearchResult
+				attempts, err := cfg.retry.run(ctx, func() error {
+					var err error
+					results, err = conn.Search(ctx, collection, []string{}, "", outputFields, queryVector, embeddingField, cfg.metricType, searchTopK, searchParams)
+					if isThrottled(err) {
+						throttled++
+					}
+					return err
+				})
+				result := searchResult{
+					conn:       connIndex,
+					collection: collectionIndex,
+					start:      searchStart,
+					latency:    time.Since(searchStart),
+					slot:       slot,
+					attempts:   attempts,
+					throttled:  throttled,
+					addr:       ref.addr,
+					err:        err,
+					queryIndex: queryIndex,
+				}
+				if err != nil {
+					lt.failover(ctx, ref, err)
+				}
+				if err == nil && lt.queries != nil && lt.queries.groundTruth != nil && len(results) > 0 {
+					if ids, ok := results[0].IDs.(*entity.ColumnInt64); ok {
+						result.ids = ids.Data()
+					}
+				}
+				if err == nil && cfg.ValidateResults {
+					result.hits = resultHits(results)
+				}
+				if err == nil && len(outputFields) > 0 {
+					result.fieldChecks = cfg.verifyResults(results)
+				}
+				result.paused = pause.after(ctx, throttled > 0)
+				agg.send(result)
+
+				if err != nil {
+					log.Printf("[Search Worker %d] Failed to perform search %d after %d retries: %v", goroutineID, searchCount, attempts, err)
+					lt.events.opError("search", goroutineID, attempts, err)
+					continue
+				}
+
+				searchCount++
+			}
+			fmt.Printf("[Search Worker %d] Finished after %d searches.\n", goroutineID, searchCount)
+		}(i)
+	}
+	searchWg.Wait()
+	searchEnd := time.Now()
+	if rebuildDone != nil {
+		<-rebuildDone // a rebuild still running holds the collection unsearchable
+	}
+	if canaryDone != nil {
+		<-canaryDone
+	}
+	agg.close()
+	lt.phaseEnd("search", searchEnd.Sub(searchStartTime))
+	fullLoadEnd := searchEnd
+	if cfg.CoolDown > 0 && coolDownStart.Before(searchEnd) {
+		fullLoadEnd = coolDownStart
+	}
+	lt.m.searchTime = fullLoadEnd.Sub(searchStartTime)
+	lt.m.searchesPerSec = float64(lt.m.totalSearchesPerformed) / lt.m.searchTime.Seconds()
+	lt.m.searchLatency = summarizeLatencies(searchLatencies)
+	lt.m.searchPercentiles = percentilesOf(searchLatencies, lt.cfg.percentiles)
+
+	fmt.Printf("✅ All search workers finished in %s.\n", lt.m.searchTime)
+	fmt.Printf("   -> Total searches performed: %d\n", lt.m.totalSearchesPerformed)
+	fmt.Printf("   -> Throughput: %.2f searches/second\n", lt.m.searchesPerSec)
+	fmt.Printf("   -> Latency: avg %s, p50 %s, p99 %s\n", lt.m.searchLatency.avg, lt.m.searchLatency.p50, lt.m.searchLatency.p99)
+	fmt.Printf("   -> Failed searches: %d (recovered by retry: %d)\n", lt.m.failedSearches, lt.m.recoveredSearches)
+	if lt.m.throttledSearches > 0 {
+		fmt.Printf("   -> Throttled searches: %d, first after %s\n", lt.m.throttledSearches, lt.m.firstSearchThrottle.Round(time.Millisecond))
+	}
+	if lt.m.recallQueries > 0 {
+		fmt.Printf("   -> Recall@%d: %.4f over %d searches\n", searchTopK, lt.m.recall(), lt.m.recallQueries)
+	}
+
+	if len(lt.conns) > 1 {
+		fmt.Printf("   %-12s %10s %14s %12s %12s %12s\n", "Connection", "Searches", "Searches/sec", "Avg", "p50", "p99")
+		for i, latencies := range connLatencies {
+			summary := summarizeLatencies(latencies)
+			c := &lt.m.connStats[i]
+			c.Searches, c.SearchesPerSec = int64(summary.count), float64(summary.count)/lt.m.searchTime.Seconds()
+			c.SearchLatencyP50, c.SearchLatencyP99 = summary.p50, summary.p99
+			fmt.Printf("   %-12d %10d %14.2f %12s %12s %12s\n", i, summary.count, c.SearchesPerSec, summary.avg, summary.p50, summary.p99)
+		}
+	}
+	if len(cfg.indexMix) > 0 {
+		lt.indexTypeSearch(collectionLatencies)
+	}
+	if cfg.CanaryQueries > 0 {
+		lt.printCanaries()
+	}
+
+	if cfg.RebuildIndex {
+		lt.m.rebuildBefore.elapsed, lt.m.rebuildDuring.elapsed, lt.m.rebuildAfter.elapsed = lt.rebuild.split(searchStartTime, fullLoadEnd)
+		lt.printRebuild()
+	}
+	if cfg.CoolDown > 0 {
+		lt.finishCoolDown(coolDownStart, searchEnd)
+		lt.printCoolDown()
+	}
+
+	if cfg.Burst {
+		lt.m.burstBaseline.elapsed, lt.m.burstPeak.elapsed = schedule.split(lt.m.searchTime)
+		fmt.Printf("   %-10s %10s %14s %12s %12s %12s\n", "Window", "Searches", "Achieved QPS", "Error Rate", "p50", "p99")
+		for _, row := range []struct {
+			name   string
+			window *windowStats
+		}{{"Baseline", &lt.m.burstBaseline}, {"Burst", &lt.m.burstPeak}} {
+			row.window.latency = summarizeLatencies(row.window.latencies)
+			fmt.Printf("   %-10s %10d %14.2f %11.2f%% %12s %12s\n", row.name, row.window.searches,
+				row.window.qps(), row.window.errorRate(), row.window.latency.p50, row.window.latency.p99)
+		}
+	}
+}
+
+// preFlushSearch times a search batch against the growing segments, before the
+// flush seals them. Milvus only searches loaded collections and only loads indexed
+// ones, so when the collection is not loaded yet the index is created and the
+// collection loaded up front; the flush then seals and indexes the segments under it.
+func (lt *loadTest) preFlushSearch(ctx context.Context) error {
+	fmt.Printf("\n--- Step 4b: Search growing segments before flush (%d queries) ---\n", lt.cfg.CompareQueries)
+	state, err := lt.client.GetLoadState(ctx, lt.collections[0], nil)
+	if err != nil {
+		return fmt.Errorf("failed to get load state: %w", err)
+	}
+	if state != entity.LoadStateLoaded {
+		fmt.Println("Collection is not loaded yet, creating the index and loading it to search the growing segments...")
+		index := lt.newIndex(lt.collections[0])
+		if err := lt.client.CreateIndex(ctx, lt.collections[0], embeddingField, index, false); err != nil {
+			return fmt.Errorf("failed to create index before flush: %w", err)
+		}
+		if err := lt.client.LoadCollection(ctx, lt.collections[0], false, lt.loadOptions()...); err != nil {
+			return fmt.Errorf("failed to load collection before flush: %w", err)
+		}
+	}
+	lt.m.preFlushLatency, err = timeSearchBatch(ctx, lt.client, lt.collections[0], lt.cfg.metricType, lt.cfg.CompareQueries)
+	if err != nil {
+		return fmt.Errorf("failed to search before flush: %w", err)
+	}
+	fmt.Printf("✅ Pre-flush search latency: avg %s, p50 %s, p99 %s\n",
+		lt.m.preFlushLatency.avg, lt.m.preFlushLatency.p50, lt.m.preFlushLatency.p99)
+	return nil
+}
+
+// postFlushSearch times the same search batch once the segments are sealed, indexed
+// and loaded, and prints the comparison against preFlushSearch
+func (lt *loadTest) postFlushSearch(ctx context.Context) error {
+	fmt.Printf("\n--- Step 6b: Search sealed and indexed segments after flush (%d queries) ---\n", lt.cfg.CompareQueries)
+	var err error
+	lt.m.postFlushLatency, err = timeSearchBatch(ctx, lt.client, lt.collections[0], lt.cfg.metricType, lt.cfg.CompareQueries)
+	if err != nil {
+		return fmt.Errorf("failed to search after flush: %w", err)
+	}
+	pre, post := lt.m.preFlushLatency, lt.m.postFlushLatency
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Segments", "Avg", "p50", "p95", "p99")
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Growing", pre.avg, pre.p50, pre.p95, pre.p99)
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Sealed", post.avg, post.p50, post.p95, post.p99)
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Improvement", pre.avg-post.avg, pre.p50-post.p50, pre.p95-post.p95, pre.p99-post.p99)
+	fmt.Println("✅ Flush comparison complete.")
+	return nil
+}
+
+// projection optionally measures the cost of materializing output fields (step 7b)
+func (lt *loadTest) projection(ctx context.Context) error {
+	if !lt.cfg.ProjectionBench {
+		return nil
+	}
+	outputFields := outputFieldNames(lt.schema)
+	fmt.Printf("\n--- Step 7b: Output field projection benchmark (%d queries per variant) ---\n", lt.cfg.ProjectionQueries)
+	fmt.Printf("Comparing IDs only vs output fields %v...\n", outputFields)
+	idsOnly, withFields, err := runProjectionBenchmark(ctx, lt.client, lt.collections[0], lt.cfg.metricType, outputFields, lt.cfg.ProjectionQueries)
+	if err != nil {
+		return fmt.Errorf("failed to run projection benchmark: %w", err)
+	}
+	lt.m.projectionIDsOnly, lt.m.projectionWithFields = idsOnly, withFields
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Variant", "Avg", "p50", "p95", "p99")
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "IDs only", idsOnly.avg, idsOnly.p50, idsOnly.p95, idsOnly.p99)
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "All fields", withFields.avg, withFields.p50, withFields.p95, withFields.p99)
+	fmt.Printf("   %-12s %12s %12s %12s %12s\n", "Delta",
+		withFields.avg-idsOnly.avg,
+		withFields.p50-idsOnly.p50,
+		withFields.p95-idsOnly.p95,
+		withFields.p99-idsOnly.p99)
+	fmt.Println("✅ Projection benchmark complete.")
+	return nil
+}
+
+// cleanup drops the test collections (step 8)
+func (lt *loadTest) cleanup(ctx context.Context) error {
+	mode := lt.cfg.CleanupMode
+	if mode == "none" {
+		fmt.Println("\n--- Step 8: Clean up skipped (--cleanup-mode none) ---")
+		for _, name := range lt.collections {
+			fmt.Printf("Collection '%s' kept as it is.\n", name)
+		}
+		lt.m.cleanupAction = "kept"
+		return nil
+	}
+	lt.phaseStart("cleanup")
+	cleanupStart := time.Now()
+	if err := lt.dropAlias(ctx); err != nil {
+		return err
+	}
+	for _, name := range lt.collections {
+		if mode == "release" {
+			fmt.Printf("\n--- Step 8: Clean up by releasing collection '%s' ---\n", name)
+		} else {
+			fmt.Printf("\n--- Step 8: Clean up by dropping collection '%s' ---\n", name)
+		}
+		has, err := lt.hasCollection(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to check if collection exists: %w", err)
+		}
+		if !has {
+			fmt.Println("Collection does not exist, nothing to clean up.")
+			continue
+		}
+		if mode == "release" {
+			if err := lt.client.ReleaseCollection(ctx, name); err != nil {
+				return fmt.Errorf("failed to release collection: %w", err)
+			}
+			continue
+		}
+		if err := lt.dropCollection(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop collection: %w", err)
+		}
+	}
+	lt.m.cleanupTime = time.Since(cleanupStart)
+	lt.phaseEnd("cleanup", lt.m.cleanupTime)
+	if mode == "release" {
+		lt.m.cleanupAction = "released (data kept)"
+		fmt.Println("✅ Collection released from memory, its data is kept for later 'search' runs.")
+		return nil
+	}
+	lt.m.cleanupAction = "dropped"
+	fmt.Println("✅ Cleanup successful!")
+	return nil
+}
@@ -0,0 +1,104 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// --find-max-qps stops once the highest passing and lowest failing rates are
+// within maxQPSPrecision of each other, or after maxQPSProbes probes
+const (
+	maxQPSPrecision = 0.05
+	maxQPSProbes    = 16
+)
+
+// maxQPSStartRate is the first rate probed when the search phase measured none
+const maxQPSStartRate = 100
+
+// qpsSearch is the --find-max-qps control loop: it doubles the rate until a probe
+// fails, then bisects between the highest passing and the lowest failing rate
+type qpsSearch struct {
+	passed float64 // highest rate that met the bound, 0 if none yet
+	failed float64 // lowest rate that did not, 0 if none yet
+}
+
+// record adds the outcome of probing rate
+func (s *qpsSearch) record(rate float64, ok bool) {
+	if ok {
+		s.passed = max(s.passed, rate)
+	} else if s.failed == 0 || rate < s.failed {
+		s.failed = rate
+	}
+}
+
+// next returns the rate to probe next, false once the search has converged
+func (s *qpsSearch) next() (float64, bool) {
+	if s.failed == 0 {
+		return 2 * s.passed, true
+	}
+	if s.failed-s.passed <= maxQPSPrecision*s.failed || s.failed < 1 {
+		return 0, false
+	}
+	return (s.passed + s.failed) / 2, true
+}
+
+// probeVerdict returns why a probe missed the --find-max-qps bound, "" if it met it
+func probeVerdict(point SweepPoint, p99Bound time.Duration) string {
+	switch {
+	case point.Failures > 0:
+		return fmt.Sprintf("%d errors", point.Failures)
+	case point.Searches == 0:
+		return "no searches"
+	case point.LatencyP99 > p99Bound:
+		return "p99 over bound"
+	case point.AchievedQPS < sweepMinAchieved*point.TargetQPS:
+		return fmt.Sprintf("achieved %.0f%%", 100*point.AchievedQPS/point.TargetQPS)
+	}
+	return ""
+}
+
+// findMaxQPS searches for the highest paced search rate whose p99 stays within
+// --find-max-qps-p99 without errors, probing each rate for --sweep-step. It starts
+// at half the search phase throughput (step 7j).
+func (lt *loadTest) findMaxQPS(ctx context.Context) error {
+	cfg := lt.cfg
+	if !cfg.FindMaxQPS {
+		return nil
+	}
+	fmt.Printf("\n--- Step 7j: Find the maximum search rate with p99 <= %s and no errors (%s per probe) ---\n", cfg.MaxQPSP99, cfg.SweepStep)
+	lt.phaseStart("find-max-qps")
+	start := time.Now()
+	rate := lt.m.searchesPerSec / 2
+	if rate < 1 {
+		rate = maxQPSStartRate
+	}
+	var search qpsSearch
+	fmt.Printf("   %12s %14s %10s %12s %12s   %s\n", "Target QPS", "Achieved QPS", "Searches", "p50", "p99", "Result")
+	for probe := 0; probe < maxQPSProbes && ctx.Err() == nil; probe++ {
+		point := lt.searchAtRate(ctx, rate, searchTopK, cfg.SweepStep)
+		if ctx.Err() != nil {
+			break // a cut-short probe says nothing about the rate
+		}
+		lt.m.maxQPSProbes = append(lt.m.maxQPSProbes, point)
+		verdict := probeVerdict(point, cfg.MaxQPSP99)
+		search.record(rate, verdict == "")
+		if verdict == "" {
+			lt.m.maxQPS = point
+			verdict = "ok"
+		}
+		fmt.Printf("   %12.1f %14.2f %10d %12s %12s   %s\n", point.TargetQPS, point.AchievedQPS, point.Searches, point.LatencyP50, point.LatencyP99, verdict)
+		var more bool
+		if rate, more = search.next(); !more {
+			break
+		}
+	}
+	lt.phaseEnd("find-max-qps", time.Since(start))
+	if lt.m.maxQPS.TargetQPS == 0 {
+		fmt.Printf("⚠️  No probed rate kept p99 within %s without errors.\n", cfg.MaxQPSP99)
+		return nil
+	}
+	best := lt.m.maxQPS
+	fmt.Printf("✅ Maximum sustainable rate: %.1f qps (p50 %s, p99 %s) after %d probes.\n", best.TargetQPS, best.LatencyP50, best.LatencyP99, len(lt.m.maxQPSProbes))
+	return nil
+}
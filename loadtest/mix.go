@@ -0,0 +1,207 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// mixKeysPerOp is the number of primary keys each mixed query, delete or upsert targets
+const mixKeysPerOp = 10
+
+// mixOperations are the operation types a --mix spec can weight
+var mixOperations = []string{"insert", "search", "query", "delete", "upsert"}
+
+// mixOp is one weighted operation type of a mixed workload and what it achieved
+type mixOp struct {
+	name      string
+	weight    int
+	ops       int64
+	failures  int64
+	latencies []time.Duration
+	latency   latencySummary
+}
+
+// MixOpResult is the throughput and latency of one operation type of a mixed workload
+type MixOpResult struct {
+	Operation  string        `json:"operation"`
+	Weight     int           `json:"weight"`
+	Operations int64         `json:"operations"`
+	Failures   int64         `json:"failures"`
+	OpsPerSec  float64       `json:"ops_per_sec"`
+	LatencyP50 time.Duration `json:"latency_p50_ns"`
+	LatencyP99 time.Duration `json:"latency_p99_ns"`
+}
+
+// parseMix parses a comma separated list of operation=weight pairs
+func parseMix(spec string) ([]*mixOp, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	weights, err := parseParams(spec, ",")
+	if err != nil {
+		return nil, err
+	}
+	var ops []*mixOp
+	for _, name := range mixOperations {
+		value, ok := weights[name]
+		if !ok {
+			continue
+		}
+		delete(weights, name)
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("%s weight %q: must be a non-negative integer", name, value)
+		}
+		if weight > 0 {
+			ops = append(ops, &mixOp{name: name, weight: weight})
+		}
+	}
+	for name := range weights {
+		return nil, fmt.Errorf("unknown operation %q (want %s)", name, strings.Join(mixOperations, ", "))
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no operation has a positive weight")
+	}
+	return ops, nil
+}
+
+// hasMixOp reports whether the mix weights the operation name
+func hasMixOp(ops []*mixOp, name string) bool {
+	return slices.ContainsFunc(ops, func(op *mixOp) bool { return op.name == name })
+}
+
+// pickMixOp picks an operation at random according to the weights
+func pickMixOp(ops []*mixOp, totalWeight int) *mixOp {
+	r := rand.Intn(totalWeight)
+	for _, op := range ops {
+		if r < op.weight {
+			return op
+		}
+		r -= op.weight
+	}
+	return ops[len(ops)-1]
+}
+
+// mixed runs the --mix workload for duration in place of the search phase: every
+// worker picks an operation per iteration according to the weights (step 7)
+func (lt *loadTest) mixed(ctx context.Context, duration time.Duration) {
+	cfg := lt.cfg
+	fmt.Printf("\n--- Step 7: Run mixed workload %s for %s ---\n", cfg.Mix, duration)
+	ops, byName := cfg.mix, map[string]*mixOp{}
+	totalWeight := 0
+	for _, op := range ops {
+		totalWeight += op.weight
+		byName[op.name] = op
+	}
+	autoID := primaryKeyAutoID(lt.schema)
+	searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	end := start.Add(duration)
+	lt.phaseStart("mixed")
+	var operations, failures int64
+	agg := startAggregator(func(r mixResult) (int64, int64) {
+		r.op.ops++
+		operations++
+		if r.err != nil {
+			r.op.failures++
+			failures++
+		} else {
+			r.op.latencies = append(r.op.latencies, r.latency)
+		}
+		return operations, failures
+	}, nil)
+	for i := 0; i < cfg.numWorkers; i++ {
+		wg.Add(1)
+		go func(goroutineID int) {
+			defer wg.Done()
+			rand.Seed(time.Now().UnixNano() + int64(goroutineID))
+			conn := lt.conns[goroutineID%len(lt.conns)]
+			target := lt.dataTarget(goroutineID)
+			batch := lt.newBatchBuilder(autoID, cfg.batchSize)
+			for time.Now().Before(end) && ctx.Err() == nil {
+				op := pickMixOp(ops, totalWeight)
+				var keys []int64
+				if op.name == "query" || op.name == "delete" || op.name == "upsert" {
+					// Key operations need inserted keys; until there are any, insert instead
+					if keys = lt.ids.sample(mixKeysPerOp); keys == nil {
+						if op = byName["insert"]; op == nil {
+							op = &mixOp{name: "insert"} // not reported, only fills the pool
+						}
+					}
+				}
+
+				opStart := time.Now()
+				_, err := cfg.retry.run(ctx, func() error {
+					switch op.name {
+					case "insert":
+						columns, assigned, _, ok := batch.build(ctx, cfg.batchSize)
+						if !ok {
+							return ctx.Err()
+						}
+						inserted, err := conn.Insert(ctx, target, "", columns...)
+						if err == nil {
+							batch.track(assigned, inserted)
+						}
+						return err
+					case "search":
+						_, err := conn.Search(ctx, target, []string{}, "", []string{}, randomQueryVector(), embeddingField, cfg.metricType, searchTopK, searchParams)
+						return err
+					case "upsert":
+						columns, ok := batch.buildFor(ctx, keys)
+						if !ok {
+							return ctx.Err()
+						}
+						_, err := conn.Upsert(ctx, target, "", columns...)
+						return err
+					case "query":
+						_, err := conn.QueryByPks(ctx, target, []string{}, entity.NewColumnInt64(primaryKeyField, keys), []string{primaryKeyField})
+						return err
+					default:
+						return conn.DeleteByPks(ctx, target, "", entity.NewColumnInt64(primaryKeyField, keys))
+					}
+				})
+				agg.send(mixResult{op: op, latency: time.Since(opStart), err: err})
+				if err != nil && ctx.Err() == nil {
+					lt.events.opError(op.name, goroutineID, 0, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	agg.close()
+	lt.m.mixTime = time.Since(start)
+	lt.phaseEnd("mixed", lt.m.mixTime)
+
+	fmt.Printf("✅ Mixed workload finished in %s.\n", lt.m.mixTime)
+	fmt.Printf("   %-8s %8s %10s %10s %12s %12s %12s\n", "Op", "Weight", "Ops", "Failures", "Ops/sec", "p50", "p99")
+	for _, op := range ops {
+		op.latency = summarizeLatencies(op.latencies)
+		fmt.Printf("   %-8s %8d %10d %10d %12.2f %12s %12s\n", op.name, op.weight, op.ops, op.failures,
+			op.rate(lt.m.mixTime), op.latency.p50, op.latency.p99)
+	}
+}
+
+// mixResult is what a mix worker reports about one operation
+type mixResult struct {
+	op      *mixOp
+	latency time.Duration
+	err     error
+}
+
+// rate returns the successful operations per second over elapsed
+func (op *mixOp) rate(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(op.ops-op.failures) / elapsed.Seconds()
+}
@@ -0,0 +1,114 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+)
+
+// Orphan is a collection of an earlier run the cleanup-orphans command found
+type Orphan struct {
+	Name    string        `json:"name"`
+	Created time.Time     `json:"created"`
+	Age     time.Duration `json:"age_ns"`
+	Dropped bool          `json:"dropped"` // false in a dry run, or when the drop failed
+}
+
+// runCleanupOrphans drops the collections earlier runs left behind: those named
+// like the tool's own, matching --list-filter when given, and created more than
+// --orphan-age ago. Without --confirm it only prints what it would drop.
+func runCleanupOrphans(ctx context.Context, lt *loadTest) error {
+	cfg := lt.cfg
+	filter := cfg.ListFilter
+	if filter == "" {
+		filter = collectionName + "*"
+	}
+	mode := "dry run, pass --confirm to drop them"
+	if cfg.Confirm {
+		mode = "dropping them"
+	}
+	fmt.Printf("\n--- Orphaned collections matching '%s' created over %s ago (%s) ---\n", filter, cfg.OrphanAge, mode)
+	created, err := lt.collectionCreateTimes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list collections: %w", err)
+	}
+	now := time.Now()
+	var names []string
+	for name := range created {
+		// the tool's prefix is required whatever the filter, so a broad pattern
+		// cannot reach collections of other applications
+		if ok, _ := path.Match(filter, name); ok && strings.HasPrefix(name, collectionName) { // the pattern is checked by resolve
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	var failed []string
+	for _, name := range names {
+		orphan := Orphan{Name: name, Created: created[name], Age: now.Sub(created[name])}
+		if orphan.Created.IsZero() {
+			fmt.Printf("   -> %-48s skipped, Milvus reported no creation time\n", name)
+			continue
+		}
+		if orphan.Age < cfg.OrphanAge {
+			fmt.Printf("   -> %-48s skipped, created %s ago\n", name, orphan.Age.Round(time.Second))
+			continue
+		}
+		if cfg.Confirm {
+			if err := lt.dropCollection(ctx, name); err != nil {
+				fmt.Printf("⚠️  Failed to drop '%s': %v\n", name, err)
+				failed = append(failed, name)
+			} else {
+				orphan.Dropped = true
+			}
+		}
+		lt.m.orphans = append(lt.m.orphans, orphan)
+		action := "would be dropped"
+		if orphan.Dropped {
+			action = "dropped"
+		}
+		fmt.Printf("   -> %-48s created %s ago, %s\n", name, orphan.Age.Round(time.Second), action)
+	}
+	switch {
+	case len(failed) > 0:
+		return fmt.Errorf("failed to drop %d orphaned collections: %s", len(failed), strings.Join(failed, ", "))
+	case len(lt.m.orphans) == 0:
+		fmt.Println("✅ No orphaned collections.")
+	case cfg.Confirm:
+		fmt.Printf("✅ Dropped %d orphaned collections.\n", len(lt.m.orphans))
+	default:
+		fmt.Printf("✅ %d orphaned collections would be dropped; rerun with --confirm to drop them.\n", len(lt.m.orphans))
+	}
+	return nil
+}
+
+// collectionCreateTimes returns the creation time of every collection on the
+// server, the zero time for those Milvus reports none for. The SDK's
+// ListCollections drops the times, so the ShowCollections call is made directly.
+func (lt *loadTest) collectionCreateTimes(ctx context.Context) (map[string]time.Time, error) {
+	grpcClient, err := sdkClient(lt.client)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := grpcClient.Service.ShowCollections(ctx, &milvuspb.ShowCollectionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if status := resp.GetStatus(); status.GetErrorCode() != commonpb.ErrorCode_Success {
+		return nil, fmt.Errorf("%s: %s", status.GetErrorCode(), status.GetReason())
+	}
+	times := resp.GetCreatedUtcTimestamps() // unix milliseconds
+	created := make(map[string]time.Time, len(resp.GetCollectionNames()))
+	for i, name := range resp.GetCollectionNames() {
+		created[name] = time.Time{}
+		if i < len(times) && times[i] > 0 {
+			created[name] = time.UnixMilli(int64(times[i]))
+		}
+	}
+	return created, nil
+}
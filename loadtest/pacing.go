@@ -0,0 +1,90 @@
+package loadtest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pacer spaces operations issued by all workers so that their combined rate follows
+// rate(elapsed) operations per second. Slots that could not be used because every
+// worker was busy are dropped rather than caught up on, so a slow server lowers
+// the achieved rate instead of triggering a catch-up storm.
+type pacer struct {
+	mu    sync.Mutex
+	start time.Time
+	next  time.Time
+	rate  func(elapsed time.Duration) float64
+}
+
+// newPacer returns a pacer whose schedule starts now
+func newPacer(rate func(elapsed time.Duration) float64) *pacer {
+	now := time.Now()
+	return &pacer{start: now, next: now, rate: rate}
+}
+
+// constantRate returns a rate function for a fixed number of operations per second
+func constantRate(qps float64) func(time.Duration) float64 {
+	return func(time.Duration) float64 { return qps }
+}
+
+// wait reserves the next slot and blocks until it is due. It returns the slot's
+// offset from the start of the schedule, and false if ctx is done or the slot
+// falls after deadline.
+func (p *pacer) wait(ctx context.Context, deadline time.Time) (time.Duration, bool) {
+	p.mu.Lock()
+	now := time.Now()
+	if p.next.Before(now) {
+		p.next = now
+	}
+	slot := p.next
+	offset := slot.Sub(p.start)
+	p.next = slot.Add(time.Duration(float64(time.Second) / p.rate(offset)))
+	p.mu.Unlock()
+
+	if !slot.Before(deadline) {
+		return offset, false
+	}
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return offset, false
+	case <-timer.C:
+		return offset, true
+	}
+}
+
+// burstSchedule alternates between baseline load for interval and burst load for
+// duration, starting with baseline
+type burstSchedule struct {
+	baselineQPS float64
+	burstQPS    float64
+	duration    time.Duration
+	interval    time.Duration
+}
+
+// inBurst reports whether the schedule is in a burst window at elapsed
+func (b burstSchedule) inBurst(elapsed time.Duration) bool {
+	return elapsed%(b.interval+b.duration) >= b.interval
+}
+
+// rate is the pacer rate function of the schedule
+func (b burstSchedule) rate(elapsed time.Duration) float64 {
+	if b.inBurst(elapsed) {
+		return b.burstQPS
+	}
+	return b.baselineQPS
+}
+
+// split returns how much of the first total of the schedule is spent at baseline and in bursts
+func (b burstSchedule) split(total time.Duration) (baseline, burst time.Duration) {
+	cycle := b.interval + b.duration
+	cycles := total / cycle
+	baseline, burst = cycles*b.interval, cycles*b.duration
+	rest := total % cycle
+	if rest > b.interval {
+		return baseline + b.interval, burst + rest - b.interval
+	}
+	return baseline + rest, burst
+}
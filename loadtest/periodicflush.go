@@ -0,0 +1,106 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// periodicFlusher flushes the test collections after every --flush-every-n-batches
+// insert batches, counted across all workers. Flushes run on their own goroutine
+// so the workers keep inserting while segments are sealed; a trigger arriving
+// while a flush is still running is coalesced into the next one.
+type periodicFlusher struct {
+	lt        *loadTest
+	every     int64
+	trigger   chan struct{}
+	done      chan struct{}
+	coalesced int // triggers folded into a pending flush, owned by the aggregator
+}
+
+// startPeriodicFlusher starts the --flush-every-n-batches flusher, nil without it
+func (lt *loadTest) startPeriodicFlusher(ctx context.Context) *periodicFlusher {
+	if lt.cfg.FlushEvery == 0 {
+		return nil
+	}
+	f := &periodicFlusher{
+		lt:      lt,
+		every:   int64(lt.cfg.FlushEvery),
+		trigger: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go f.run(ctx)
+	fmt.Printf("🚿 Flushing every %d insert batches across all workers\n", lt.cfg.FlushEvery)
+	return f
+}
+
+// batchInserted is called by the insert aggregator with the number of batches
+// inserted so far and triggers a flush at every multiple of --flush-every-n-batches
+func (f *periodicFlusher) batchInserted(batches int64) {
+	if batches%f.every != 0 {
+		return
+	}
+	select {
+	case f.trigger <- struct{}{}:
+	default:
+		f.coalesced++
+	}
+}
+
+// run flushes every collection once per trigger until stop
+func (f *periodicFlusher) run(ctx context.Context) {
+	defer close(f.done)
+	m := &f.lt.m
+	for range f.trigger {
+		start := time.Now()
+		sealed, err := f.flush(ctx)
+		took := time.Since(start)
+		if err != nil {
+			m.periodicFlushFailures++
+			fmt.Printf("⚠️  Periodic flush failed after %s: %v\n", took.Round(time.Millisecond), err)
+			continue
+		}
+		m.periodicFlushes++
+		m.periodicFlushTime += took
+		m.periodicFlushSegments += sealed
+		if f.lt.cfg.RealTime {
+			fmt.Printf("🚿 Periodic flush %d sealed %d segments in %s\n", m.periodicFlushes, sealed, took.Round(time.Millisecond))
+		}
+	}
+}
+
+// flush flushes the test collections one after the other and waits for their
+// segments to be sealed, returning how many were
+func (f *periodicFlusher) flush(ctx context.Context) (int, error) {
+	sealed := 0
+	for _, name := range f.lt.collections {
+		conn, ref := f.lt.conn(0)
+		var segments []int64
+		if _, err := f.lt.cfg.retry.run(ctx, func() error {
+			var err error
+			segments, _, _, _, err = conn.FlushV2(ctx, name, false)
+			return err
+		}); err != nil {
+			f.lt.failover(ctx, ref, err)
+			return sealed, fmt.Errorf("collection '%s': %w", name, err)
+		}
+		sealed += len(segments)
+	}
+	return sealed, nil
+}
+
+// stop waits for the running flush, if any, and ends the flusher. The aggregator
+// must be closed first, so no batch triggers a flush anymore.
+func (f *periodicFlusher) stop() {
+	close(f.trigger)
+	<-f.done
+	f.lt.m.periodicFlushCoalesced = f.coalesced
+}
+
+// avgPeriodicFlushTime returns the mean duration of a --flush-every-n-batches flush
+func (m *metrics) avgPeriodicFlushTime() time.Duration {
+	if m.periodicFlushes == 0 {
+		return 0
+	}
+	return m.periodicFlushTime / time.Duration(m.periodicFlushes)
+}
@@ -0,0 +1,34 @@
+package loadtest
+
+import "math/rand"
+
+// pkStrategies are the --pk-strategy values, the default first
+var pkStrategies = []string{"sequential", "random", "hashed"}
+
+// pkMask keeps keys within the positive int64 range
+const pkMask = 1<<63 - 1
+
+// primaryKey returns the key of the n-th row the tool inserts. Sequential keys
+// fill segments in order; random keys spread uniformly but may repeat; hashed
+// keys spread the same way yet stay unique, being a permutation of n.
+func primaryKey(strategy string, n int64) int64 {
+	switch strategy {
+	case "random":
+		return rand.Int63()
+	case "hashed":
+		return permute63(n)
+	default:
+		return n
+	}
+}
+
+// permute63 scrambles the bits of n bijectively over [0, 2^63): each step, an
+// odd multiplication or a right xor-shift, is invertible modulo 2^63
+func permute63(n int64) int64 {
+	x := uint64(n) & pkMask
+	x = (x * 0x9e3779b97f4a7c15) & pkMask
+	x ^= x >> 29
+	x = (x * 0xbf58476d1ce4e5b9) & pkMask
+	x ^= x >> 32
+	return int64(x)
+}
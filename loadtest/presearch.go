@@ -0,0 +1,84 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// preSearchPoll is how often --pre-search-settle checks the index and load progress
+const preSearchPoll = time.Second
+
+// preSearchDelay waits --pre-search-delay before the search phase, so index
+// handoff still running in the background after the load does not skew the first
+// searches. With --pre-search-settle the wait ends early once every collection's
+// vector index covers all rows and its load progress is 100% (step 6g).
+func (lt *loadTest) preSearchDelay(ctx context.Context) {
+	cfg := lt.cfg
+	if cfg.PreSearchDelay <= 0 {
+		return
+	}
+	if cfg.PreSearchSettle {
+		fmt.Printf("\n--- Step 6g: Wait up to %s for indexing and loading to settle ---\n", cfg.PreSearchDelay)
+	} else {
+		fmt.Printf("\n--- Step 6g: Wait %s before searching ---\n", cfg.PreSearchDelay)
+	}
+	lt.phaseStart("pre-search-delay")
+	start := time.Now()
+	deadline := time.NewTimer(cfg.PreSearchDelay)
+	defer deadline.Stop()
+	var poll <-chan time.Time
+	if cfg.PreSearchSettle {
+		ticker := time.NewTicker(preSearchPoll)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+	for waiting := true; waiting; {
+		select {
+		case <-ctx.Done():
+			waiting = false
+		case <-deadline.C:
+			waiting = false
+		case <-poll:
+			settled, err := lt.settled(ctx)
+			if err != nil {
+				fmt.Printf("⚠️  Checking index and load progress failed, waiting out the delay: %v\n", err)
+				poll = nil
+			}
+			lt.m.preSearchSettled = settled
+			waiting = !settled
+		}
+	}
+	lt.m.preSearchWait = time.Since(start)
+	lt.phaseEnd("pre-search-delay", lt.m.preSearchWait)
+	switch {
+	case lt.m.preSearchSettled:
+		fmt.Printf("✅ Indexing and loading settled after %s.\n", lt.m.preSearchWait.Round(time.Millisecond))
+	case cfg.PreSearchSettle:
+		fmt.Printf("⚠️  Indexing or loading still in progress after %s, searching anyway.\n", lt.m.preSearchWait.Round(time.Millisecond))
+	default:
+		fmt.Printf("✅ Waited %s.\n", lt.m.preSearchWait.Round(time.Millisecond))
+	}
+}
+
+// settled reports whether the vector index of every test collection covers all
+// of its rows and every collection is fully loaded
+func (lt *loadTest) settled(ctx context.Context) (bool, error) {
+	for _, name := range lt.collections {
+		total, indexed, err := lt.client.GetIndexBuildProgress(ctx, name, embeddingField)
+		if err != nil {
+			return false, fmt.Errorf("collection '%s': %w", name, err)
+		}
+		if indexed < total {
+			return false, nil
+		}
+		progress, err := lt.client.GetLoadingProgress(ctx, name, nil)
+		if err != nil {
+			return false, fmt.Errorf("collection '%s': %w", name, err)
+		}
+		if progress < 100 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,61 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// outputFieldNames returns every non primary key field of the schema, i.e. the
+// full payload a search can be asked to materialize
+func outputFieldNames(schema *entity.Schema) []string {
+	var names []string
+	for _, field := range schema.Fields {
+		if !field.PrimaryKey {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// runProjectionBenchmark issues the same query vectors twice, once returning only
+// IDs and once returning outputFields, so the latency delta isolates the cost of
+// fetching the payload from the ANN traversal itself. Queries run sequentially
+// to keep concurrency from blurring the comparison, and the order of the two
+// variants alternates to cancel out any cache warm-up bias.
+func runProjectionBenchmark(ctx context.Context, milvusClient client.Client, collection string, metric entity.MetricType, outputFields []string, queries int) (latencySummary, latencySummary, error) {
+	searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+	idsOnly := make([]time.Duration, 0, queries)
+	withFields := make([]time.Duration, 0, queries)
+
+	search := func(queryVector []entity.Vector, fields []string) (time.Duration, error) {
+		start := time.Now()
+		_, err := milvusClient.Search(ctx, collection, []string{}, "", fields, queryVector, embeddingField, metric, searchTopK, searchParams)
+		return time.Since(start), err
+	}
+
+	for i := 0; i < queries; i++ {
+		queryVector := randomQueryVector()
+
+		variants := [][]string{{}, outputFields}
+		if i%2 == 1 {
+			variants[0], variants[1] = variants[1], variants[0]
+		}
+		for _, fields := range variants {
+			latency, err := search(queryVector, fields)
+			if err != nil {
+				return latencySummary{}, latencySummary{}, fmt.Errorf("projection search %d failed: %w", i, err)
+			}
+			if len(fields) == 0 {
+				idsOnly = append(idsOnly, latency)
+			} else {
+				withFields = append(withFields, latency)
+			}
+		}
+	}
+
+	return summarizeLatencies(idsOnly), summarizeLatencies(withFields), nil
+}
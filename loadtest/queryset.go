@@ -0,0 +1,110 @@
+package loadtest
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// searchTopK is the number of neighbours every search asks for
+const searchTopK = 3
+
+// querySet holds query vectors read from a file and, optionally, the ground-truth
+// nearest neighbour IDs of each query
+type querySet struct {
+	vectors     [][]float32
+	groundTruth [][]int64 // nil without a ground truth file
+}
+
+// loadQuerySet reads query vectors from an .fvecs file and, when truthPath is set,
+// their ground-truth neighbours from an .ivecs file (the ANN benchmark formats:
+// every row is a little-endian int32 dimension followed by that many values)
+func loadQuerySet(queryPath, truthPath string) (*querySet, error) {
+	qs := &querySet{}
+	err := readVecs(queryPath, func(row []byte, dim int) error {
+		if dim != embeddingDim {
+			return fmt.Errorf("query vector has dimension %d, collection uses %d", dim, embeddingDim)
+		}
+		vec := make([]float32, dim)
+		for i := range vec {
+			vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(row[4*i:]))
+		}
+		qs.vectors = append(qs.vectors, vec)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query file %s: %w", queryPath, err)
+	}
+	if len(qs.vectors) == 0 {
+		return nil, fmt.Errorf("query file %s contains no vectors", queryPath)
+	}
+	if truthPath == "" {
+		return qs, nil
+	}
+
+	err = readVecs(truthPath, func(row []byte, dim int) error {
+		ids := make([]int64, dim)
+		for i := range ids {
+			ids[i] = int64(int32(binary.LittleEndian.Uint32(row[4*i:])))
+		}
+		qs.groundTruth = append(qs.groundTruth, ids)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ground truth file %s: %w", truthPath, err)
+	}
+	if len(qs.groundTruth) != len(qs.vectors) {
+		return nil, fmt.Errorf("ground truth file %s has %d rows for %d queries", truthPath, len(qs.groundTruth), len(qs.vectors))
+	}
+	return qs, nil
+}
+
+// readVecs calls fn for every row of an .fvecs/.ivecs file with the row's raw values
+func readVecs(path string, fn func(row []byte, dim int) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header := make([]byte, 4)
+	for rowNum := 0; ; rowNum++ {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		dim := int(int32(binary.LittleEndian.Uint32(header)))
+		if dim <= 0 {
+			return fmt.Errorf("row %d: invalid dimension %d", rowNum, dim)
+		}
+		row := make([]byte, 4*dim)
+		if _, err := io.ReadFull(r, row); err != nil {
+			return fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		if err := fn(row, dim); err != nil {
+			return fmt.Errorf("row %d: %w", rowNum, err)
+		}
+	}
+}
+
+// pick returns the index of a random query and its vector
+func (qs *querySet) pick() (int, []entity.Vector) {
+	i := rand.Intn(len(qs.vectors))
+	return i, []entity.Vector{entity.FloatVector(qs.vectors[i])}
+}
+
+// recall returns the fraction of the first k ground-truth neighbours of query i
+// found in ids
+func (qs *querySet) recall(i int, ids []int64, k int) float64 {
+	return overlapRecall(qs.groundTruth[i], ids, k)
+}
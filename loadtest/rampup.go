@@ -0,0 +1,55 @@
+package loadtest
+
+import (
+	"math/rand"
+	"time"
+)
+
+// rampIdlePoll is how often a worker that is not yet part of the ramp checks again
+const rampIdlePoll = 100 * time.Millisecond
+
+// calculateDynamicLoad calculates the current load based on elapsed time (like a real dyno).
+// The load grows linearly from startPct percent of the maximum (at least 1 worker and
+// 1 vector per batch, but never more than the maximum itself) to exactly the maximum
+// once elapsed reaches totalDuration.
+func calculateDynamicLoad(elapsed time.Duration, totalDuration time.Duration, maxWorkers int, maxBatchSize int, startPct float64) (int, int) {
+	if elapsed >= totalDuration {
+		return maxWorkers, maxBatchSize
+	}
+
+	// Linear ramp-up: start at startPct of max, reach 100% at the end
+	progress := max(0, float64(elapsed)/float64(totalDuration))
+
+	// Start at startPct of max capacity
+	startWorkers := min(maxWorkers, max(1, int(float64(maxWorkers)*startPct/100)))
+	startBatchSize := min(maxBatchSize, max(1, int(float64(maxBatchSize)*startPct/100)))
+
+	currentWorkers := startWorkers + int(float64(maxWorkers-startWorkers)*progress)
+	currentBatchSize := startBatchSize + int(float64(maxBatchSize-startBatchSize)*progress)
+
+	return currentWorkers, currentBatchSize
+}
+
+// coolDownWorkers returns the search workers active elapsed into a --cool-down of
+// totalDuration: the ramp-up curve from 0% run backwards, from maxWorkers down
+// to a single worker, and none once the cool-down is over.
+func coolDownWorkers(elapsed time.Duration, totalDuration time.Duration, maxWorkers int) int {
+	if elapsed >= totalDuration {
+		return 0
+	}
+	workers, _ := calculateDynamicLoad(totalDuration-elapsed, totalDuration, maxWorkers, 1, 0)
+	return workers
+}
+
+// jitterRange returns the smallest and largest batch sizes --batch-jitter pct
+// draws around size, never below 1 row
+func jitterRange(size int, pct float64) (int, int) {
+	spread := int(float64(size) * pct / 100)
+	return max(1, size-spread), size + spread
+}
+
+// jitterBatch returns a batch size drawn uniformly from jitterRange
+func jitterBatch(size int, pct float64) int {
+	lowest, highest := jitterRange(size, pct)
+	return lowest + rand.Intn(highest-lowest+1)
+}
@@ -0,0 +1,63 @@
+package loadtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateDynamicLoad(t *testing.T) {
+	const total = 100 * time.Second
+	tests := []struct {
+		name                   string
+		elapsed                time.Duration
+		maxWorkers, maxBatch   int
+		startPct               float64
+		wantWorkers, wantBatch int
+	}{
+		{"zero elapsed starts at 10%", 0, 20, 2000, 10, 2, 200},
+		{"halfway", total / 2, 20, 2000, 10, 11, 1100},
+		{"just before the end", total - time.Nanosecond, 20, 2000, 10, 19, 1999},
+		{"boundary returns max exactly", total, 20, 2000, 10, 20, 2000},
+		{"past the end stays at max", 2 * total, 20, 2000, 10, 20, 2000},
+		{"negative elapsed starts at 10%", -time.Second, 20, 2000, 10, 2, 200},
+		{"start floors at 1 worker, the batch stays proportional", 0, 5, 500, 10, 1, 50},
+		{"tiny max starts at 1 worker", 0, 1, 50, 10, 1, 5},
+		{"tiny max ramps without overshooting", total / 2, 3, 50, 10, 2, 27},
+		{"zero workers", total / 2, 0, 2000, 10, 0, 1100},
+		{"1% start", 0, 20, 2000, 1, 1, 20},
+		{"1% halfway", total / 2, 100, 10000, 1, 50, 5050},
+		{"batch floors at 1 vector", 0, 4, 5, 1, 1, 1},
+		{"100% starts at max", 0, 20, 2000, 100, 20, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workers, batch := calculateDynamicLoad(tt.elapsed, total, tt.maxWorkers, tt.maxBatch, tt.startPct)
+			if workers != tt.wantWorkers || batch != tt.wantBatch {
+				t.Errorf("calculateDynamicLoad(%s, %s, %d, %d, %g) = (%d, %d), want (%d, %d)",
+					tt.elapsed, total, tt.maxWorkers, tt.maxBatch, tt.startPct, workers, batch, tt.wantWorkers, tt.wantBatch)
+			}
+		})
+	}
+}
+
+func TestCalculateDynamicLoadIsMonotonic(t *testing.T) {
+	const total = 10 * time.Second
+	for _, pct := range []float64{1, 10, 50, 100} {
+		for _, limits := range [][2]int{{1, 1}, {3, 50}, {20, 2000}, {100, 10000}} {
+			prevWorkers, prevBatch := 0, 0
+			for elapsed := time.Duration(0); elapsed <= total; elapsed += 100 * time.Millisecond {
+				workers, batch := calculateDynamicLoad(elapsed, total, limits[0], limits[1], pct)
+				if workers < prevWorkers || batch < prevBatch {
+					t.Fatalf("start %g%%, max %v: load decreased at %s: (%d, %d) after (%d, %d)", pct, limits, elapsed, workers, batch, prevWorkers, prevBatch)
+				}
+				if workers > limits[0] || batch > limits[1] {
+					t.Fatalf("start %g%%, max %v: load (%d, %d) exceeds the maximum at %s", pct, limits, workers, batch, elapsed)
+				}
+				prevWorkers, prevBatch = workers, batch
+			}
+			if prevWorkers != limits[0] || prevBatch != limits[1] {
+				t.Fatalf("start %g%%, max %v: ended at (%d, %d)", pct, limits, prevWorkers, prevBatch)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// rebuildTimeline marks the index rebuild window of the search phase. Milvus only
+// drops the index of a released collection, so the window spans release, drop,
+// create and reload; searches issued meanwhile fail until the reload completes.
+type rebuildTimeline struct {
+	start atomic.Int64 // unix nanoseconds, 0 until the rebuild starts
+	end   atomic.Int64 // unix nanoseconds, 0 until the collection is loaded again
+}
+
+// window returns the stats a search issued at t is accounted to
+func (r *rebuildTimeline) window(m *metrics, t time.Time) *windowStats {
+	at := t.UnixNano()
+	if start := r.start.Load(); start == 0 || at < start {
+		return &m.rebuildBefore
+	}
+	if end := r.end.Load(); end == 0 || at < end {
+		return &m.rebuildDuring
+	}
+	return &m.rebuildAfter
+}
+
+// split returns how long the search phase that started at phaseStart and ended at
+// phaseEnd spent before, during and after the rebuild
+func (r *rebuildTimeline) split(phaseStart, phaseEnd time.Time) (before, during, after time.Duration) {
+	start, end := phaseEnd, phaseEnd
+	if ns := r.start.Load(); ns != 0 {
+		start = time.Unix(0, ns)
+	}
+	if ns := r.end.Load(); ns != 0 {
+		end = time.Unix(0, ns)
+	}
+	return start.Sub(phaseStart), end.Sub(start), phaseEnd.Sub(end)
+}
+
+// rebuildIndex waits for delay, then drops and recreates the index of every
+// collection while the search workers keep running
+func (lt *loadTest) rebuildIndex(ctx context.Context, delay time.Duration) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(delay):
+	}
+	fmt.Println("🔧 INDEX REBUILD: releasing, dropping and recreating the index under search load...")
+	conn, _ := lt.conn(0)
+	lt.rebuild.start.Store(time.Now().UnixNano())
+	_, err := lt.forEachCollection(ctx, func(ctx context.Context, name string) error {
+		if err := conn.ReleaseCollection(ctx, name); err != nil {
+			return fmt.Errorf("failed to release collection: %w", err)
+		}
+		if err := conn.DropIndex(ctx, name, embeddingField); err != nil {
+			return fmt.Errorf("failed to drop index: %w", err)
+		}
+		if err := conn.CreateIndex(ctx, name, embeddingField, lt.newIndex(name), false); err != nil {
+			return fmt.Errorf("failed to recreate index: %w", err)
+		}
+		if err := conn.LoadCollection(ctx, name, false, lt.loadOptions()...); err != nil {
+			return fmt.Errorf("failed to reload collection: %w", err)
+		}
+		return nil
+	})
+	lt.rebuild.end.Store(time.Now().UnixNano())
+	if err != nil {
+		lt.m.rebuildErr = err
+		fmt.Printf("⚠️  Index rebuild failed: %v\n", err)
+		return
+	}
+	fmt.Printf("🔧 INDEX REBUILD: collection searchable again after %s\n",
+		time.Duration(lt.rebuild.end.Load()-lt.rebuild.start.Load()).Round(time.Millisecond))
+}
+
+// printRebuild prints the search stats before, during and after the rebuild
+func (lt *loadTest) printRebuild() {
+	fmt.Printf("   %-10s %10s %14s %12s %12s %12s\n", "Window", "Searches", "Achieved QPS", "Error Rate", "p50", "p99")
+	for _, row := range []struct {
+		name   string
+		window *windowStats
+	}{{"Before", &lt.m.rebuildBefore}, {"Rebuild", &lt.m.rebuildDuring}, {"After", &lt.m.rebuildAfter}} {
+		row.window.latency = summarizeLatencies(row.window.latencies)
+		fmt.Printf("   %-10s %10d %14.2f %11.2f%% %12s %12s\n", row.name, row.window.searches,
+			row.window.qps(), row.window.errorRate(), row.window.latency.p50, row.window.latency.p99)
+	}
+}
@@ -0,0 +1,153 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// metricTypes maps the --metric values to their SDK metric type
+var metricTypes = map[string]entity.MetricType{
+	"L2":     entity.L2,
+	"IP":     entity.IP,
+	"COSINE": entity.COSINE,
+}
+
+// distance returns how far b is from a under metric, smaller being nearer. IP and
+// COSINE are similarities, so they are negated; COSINE normalizes both vectors
+// first, as Milvus does, so a long vector pointing away from the query does not
+// beat a short one pointing at it the way it would with a raw dot product.
+func distance(metric entity.MetricType, a, b []float32) float64 {
+	switch metric {
+	case entity.IP:
+		return -dot(a, b)
+	case entity.COSINE:
+		norms := math.Sqrt(dot(a, a) * dot(b, b))
+		if norms == 0 {
+			return 0
+		}
+		return -dot(a, b) / norms
+	default:
+		var sum float64
+		for i := range a {
+			d := float64(a[i]) - float64(b[i])
+			sum += d * d
+		}
+		return sum
+	}
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+// exactNeighbors returns the keys of the k vectors nearest to query under metric,
+// nearest first, by brute force over every vector
+func exactNeighbors(metric entity.MetricType, query []float32, keys []int64, vectors [][]float32, k int) []int64 {
+	order := make([]int, len(keys))
+	distances := make([]float64, len(keys))
+	for i := range order {
+		order[i] = i
+		distances[i] = distance(metric, query, vectors[i])
+	}
+	sort.Slice(order, func(a, b int) bool { return distances[order[a]] < distances[order[b]] })
+	if len(order) > k {
+		order = order[:k]
+	}
+	neighbors := make([]int64, len(order))
+	for i, row := range order {
+		neighbors[i] = keys[row]
+	}
+	return neighbors
+}
+
+// overlapRecall returns the fraction of the first k truth IDs found in ids
+func overlapRecall(truth, ids []int64, k int) float64 {
+	if len(truth) > k {
+		truth = truth[:k]
+	}
+	if len(truth) == 0 {
+		return 0
+	}
+	want := make(map[int64]bool, len(truth))
+	for _, id := range truth {
+		want[id] = true
+	}
+	found := 0
+	for _, id := range ids {
+		if want[id] {
+			found++
+			delete(want, id)
+		}
+	}
+	return float64(found) / float64(len(truth))
+}
+
+// recallCheck measures recall@k against exact ground truth computed on the client
+// from the vectors held by the primary key pool (step 7c). The ground truth is only
+// exact if the pool holds every inserted row, so the check is skipped otherwise.
+func (lt *loadTest) recallCheck(ctx context.Context) error {
+	if lt.cfg.RecallCheck == 0 {
+		return nil
+	}
+	fmt.Printf("\n--- Step 7c: Verify %s recall against exact search (%d queries) ---\n", lt.cfg.metricType, lt.cfg.RecallCheck)
+	if lt.ids == nil {
+		fmt.Println("⚠️  Skipping recall check: the primary key pool is disabled (--id-pool-size 0)")
+		return nil
+	}
+	keys, vectors, complete := lt.ids.rows()
+	if len(keys) == 0 {
+		fmt.Println("⚠️  Skipping recall check: no inserted vectors were tracked in this run")
+		return nil
+	}
+	if !complete {
+		fmt.Printf("⚠️  Skipping recall check: %d rows were inserted but the pool holds %d, raise --id-pool-size\n", lt.ids.total(), len(keys))
+		return nil
+	}
+
+	queries := make([][]entity.Vector, lt.cfg.RecallCheck)
+	truths := make([][]int64, lt.cfg.RecallCheck)
+	for i := range queries {
+		queries[i] = randomQueryVector()
+		truths[i] = exactNeighbors(lt.cfg.metricType, queries[i][0].(entity.FloatVector), keys, vectors, searchTopK)
+	}
+	recall, err := lt.measureRecall(ctx, queries, truths)
+	if err != nil {
+		return err
+	}
+	lt.m.exactRecall = recall
+	lt.m.exactRecallQueries = lt.cfg.RecallCheck
+	fmt.Printf("✅ Recall@%d of %s against exact %s search over %d vectors: %.4f\n", searchTopK, lt.cfg.indexType, lt.cfg.metricType, len(keys), lt.m.exactRecall)
+	if lt.cfg.indexType != entity.IvfFlat {
+		return lt.quantizationRecall(ctx, queries, truths)
+	}
+	return nil
+}
+
+// measureRecall searches the first collection with every query and returns the
+// mean recall@k against the matching ground truth
+func (lt *loadTest) measureRecall(ctx context.Context, queries [][]entity.Vector, truths [][]int64) (float64, error) {
+	searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+	var sum float64
+	for i, queryVector := range queries {
+		results, err := lt.client.Search(ctx, lt.collections[0], []string{}, "", []string{}, queryVector, embeddingField, lt.cfg.metricType, searchTopK, searchParams)
+		if err != nil {
+			return 0, fmt.Errorf("recall check search %d failed: %w", i, err)
+		}
+		var ids []int64
+		if len(results) > 0 {
+			if column, ok := results[0].IDs.(*entity.ColumnInt64); ok {
+				ids = column.Data()
+			}
+		}
+		sum += overlapRecall(truths[i], ids, searchTopK)
+	}
+	return sum / float64(len(queries)), nil
+}
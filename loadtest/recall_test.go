@@ -0,0 +1,58 @@
+package loadtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// The query points along the x axis. Row 1 is short but off at 45 degrees, so it
+// is nearest by L2; row 2 is far away but almost parallel to the query, so it is
+// nearest by COSINE. A raw dot product also favours row 2, but for its length,
+// which row 3 (longer still, and further off axis) shows.
+var (
+	recallQuery = []float32{1, 0}
+	recallKeys  = []int64{1, 2, 3}
+	recallRows  = [][]float32{{0.5, 0.5}, {10, 1}, {20, 30}}
+)
+
+func TestExactNeighbors(t *testing.T) {
+	tests := []struct {
+		metric entity.MetricType
+		want   []int64
+	}{
+		{entity.L2, []int64{1, 2, 3}},
+		{entity.COSINE, []int64{2, 1, 3}},
+		{entity.IP, []int64{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			got := exactNeighbors(tt.metric, recallQuery, recallKeys, recallRows, len(recallKeys))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exactNeighbors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCosineDistanceIgnoresLength(t *testing.T) {
+	short := distance(entity.COSINE, recallQuery, []float32{1, 1})
+	long := distance(entity.COSINE, recallQuery, []float32{100, 100})
+	if diff := short - long; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("COSINE distance depends on vector length: %g vs %g", short, long)
+	}
+	if got := distance(entity.COSINE, recallQuery, []float32{0, 0}); got != 0 {
+		t.Errorf("COSINE distance to a zero vector = %g, want 0", got)
+	}
+}
+
+func TestOverlapRecall(t *testing.T) {
+	truth := exactNeighbors(entity.COSINE, recallQuery, recallKeys, recallRows, 1)
+	if got := overlapRecall(truth, []int64{2}, 1); got != 1 {
+		t.Errorf("recall of the cosine neighbour = %g, want 1", got)
+	}
+	if got := overlapRecall(truth, []int64{1}, 1); got != 0 {
+		t.Errorf("recall of the L2 neighbour under COSINE = %g, want 0", got)
+	}
+}
@@ -0,0 +1,44 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// reloadCycles releases and reloads the collections --load-repeats times after the
+// first load, timing every reload. The first load reads the segments from object
+// storage, the repeats mostly find them in the query nodes' caches (step 6e).
+func (lt *loadTest) reloadCycles(ctx context.Context) error {
+	cfg := lt.cfg
+	if cfg.LoadRepeats == 0 {
+		return nil
+	}
+	fmt.Printf("\n--- Step 6e: Release and reload the collection %d times ---\n", cfg.LoadRepeats)
+	var loads []time.Duration
+	for i := 1; i <= cfg.LoadRepeats && ctx.Err() == nil; i++ {
+		if _, err := lt.forEachCollection(ctx, func(ctx context.Context, name string) error {
+			if err := lt.client.ReleaseCollection(ctx, name); err != nil {
+				return fmt.Errorf("failed to release collection: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		start := time.Now()
+		if _, err := lt.forEachCollection(ctx, func(ctx context.Context, name string) error {
+			if err := lt.client.LoadCollection(ctx, name, false, lt.loadOptions()...); err != nil {
+				return fmt.Errorf("failed to reload collection: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		loads = append(loads, time.Since(start))
+		fmt.Printf("   -> Reload %d/%d: %s\n", i, cfg.LoadRepeats, loads[len(loads)-1])
+	}
+	lt.m.reloadLatency = summarizeLatencies(loads)
+	fmt.Printf("✅ Load time: first %s, reloads min %s, median %s, max %s\n",
+		lt.m.loadTime, lt.m.reloadLatency.min, lt.m.reloadLatency.p50, lt.m.reloadLatency.max)
+	return nil
+}
@@ -0,0 +1,254 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// transports lists the --transport values
+var transports = []string{"grpc", "rest"}
+
+// restIdleConns is how many keep-alive HTTP connections a REST client holds on to,
+// enough for every insert and search worker sharing it
+const restIdleConns = 256
+
+// restClient sends inserts and searches through the Milvus RESTful API (v2) and
+// everything else (DDL, flush, index, load) through the embedded gRPC client
+type restClient struct {
+	client.Client
+	baseURL string
+	http    *http.Client
+	wire    *wireStats // nil without --wire-stats
+}
+
+// newRESTClient wraps grpcClient so its data path goes over HTTP to addr, the
+// same host:port the gRPC client dialled
+func newRESTClient(grpcClient client.Client, addr string, wire *wireStats) *restClient {
+	baseURL := addr
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "http://" + baseURL
+	}
+	return &restClient{
+		Client:  grpcClient,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: restIdleConns}},
+		wire:    wire,
+	}
+}
+
+// restResponse is the envelope of every RESTful API reply
+type restResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// restID is a primary key in a REST reply, which Milvus sends as a number or,
+// for int64 keys that do not fit a JSON number, as a string
+type restID int64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (id *restID) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid primary key %s: %w", data, err)
+	}
+	*id = restID(v)
+	return nil
+}
+
+// post sends request to the RESTful API endpoint path and decodes the data of
+// the reply into data
+func (c *restClient) post(ctx context.Context, counter func(*wireStats) *wireCounter, path string, request, data any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	reply, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if c.wire != nil {
+		w := counter(c.wire)
+		w.rpcs.Add(1)
+		w.bytesSent.Add(int64(len(body)))
+		w.payloadSent.Add(int64(len(body)))
+		w.bytesReceived.Add(int64(len(reply)))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: HTTP %s", path, resp.Status)
+	}
+	var envelope restResponse
+	if err := json.Unmarshal(reply, &envelope); err != nil {
+		return fmt.Errorf("%s: invalid reply: %w", path, err)
+	}
+	if envelope.Code != 0 {
+		return fmt.Errorf("%s: code %d: %s", path, envelope.Code, envelope.Message)
+	}
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(envelope.Data, data)
+}
+
+// restRows turns columns into the row objects the insert endpoint expects
+func restRows(columns []entity.Column) ([]map[string]any, error) {
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	rows := make([]map[string]any, columns[0].Len())
+	for i := range rows {
+		rows[i] = make(map[string]any, len(columns))
+	}
+	for _, column := range columns {
+		if column.Len() != len(rows) {
+			return nil, fmt.Errorf("column %s has %d rows, want %d", column.Name(), column.Len(), len(rows))
+		}
+		for i, row := range rows {
+			value, err := column.Get(i)
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", column.Name(), err)
+			}
+			row[column.Name()] = value
+		}
+	}
+	return rows, nil
+}
+
+// Insert implements client.Client over POST /v2/vectordb/entities/insert
+func (c *restClient) Insert(ctx context.Context, collName string, partitionName string, columns ...entity.Column) (entity.Column, error) {
+	rows, err := restRows(columns)
+	if err != nil {
+		return nil, err
+	}
+	request := map[string]any{"collectionName": collName, "data": rows}
+	if partitionName != "" {
+		request["partitionName"] = partitionName
+	}
+	var reply struct {
+		InsertIDs []restID `json:"insertIds"`
+	}
+	if err := c.post(ctx, func(w *wireStats) *wireCounter { return &w.insert }, "/v2/vectordb/entities/insert", request, &reply); err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(reply.InsertIDs))
+	for i, id := range reply.InsertIDs {
+		ids[i] = int64(id)
+	}
+	return entity.NewColumnInt64(primaryKeyField, ids), nil
+}
+
+// Search implements client.Client over POST /v2/vectordb/entities/search. The
+// endpoint returns the hits of all vectors as one list, so they are split back
+// per query vector in order, topK apiece. Output fields are requested but not
+// decoded into the results.
+func (c *restClient) Search(ctx context.Context, collName string, partitions []string, expr string, outputFields []string,
+	vectors []entity.Vector, vectorField string, metricType entity.MetricType, topK int, sp entity.SearchParam, _ ...client.SearchQueryOptionFunc) ([]client.SearchResult, error) {
+	data := make([]any, len(vectors))
+	for i, vector := range vectors {
+		data[i] = vector
+	}
+	request := map[string]any{
+		"collectionName": collName,
+		"data":           data,
+		"annsField":      vectorField,
+		"limit":          topK,
+		"searchParams":   map[string]any{"metricType": string(metricType), "params": sp.Params()},
+	}
+	if len(partitions) > 0 {
+		request["partitionNames"] = partitions
+	}
+	if expr != "" {
+		request["filter"] = expr
+	}
+	if len(outputFields) > 0 {
+		request["outputFields"] = outputFields
+	}
+	var hits []struct {
+		ID       restID  `json:"id"`
+		Distance float32 `json:"distance"`
+	}
+	if err := c.post(ctx, func(w *wireStats) *wireCounter { return &w.search }, "/v2/vectordb/entities/search", request, &hits); err != nil {
+		return nil, err
+	}
+	results := make([]client.SearchResult, len(vectors))
+	for i := range results {
+		chunk := hits[min(len(hits), i*topK):min(len(hits), (i+1)*topK)]
+		ids := make([]int64, len(chunk))
+		scores := make([]float32, len(chunk))
+		for j, hit := range chunk {
+			ids[j], scores[j] = int64(hit.ID), hit.Distance
+		}
+		results[i] = client.SearchResult{ResultCount: len(chunk), IDs: entity.NewColumnInt64(primaryKeyField, ids), Scores: scores}
+	}
+	return results, nil
+}
+
+// Close closes the idle HTTP connections and the gRPC client
+func (c *restClient) Close() error {
+	c.http.CloseIdleConnections()
+	return c.Client.Close()
+}
+
+// transportCompare times the same search batch over gRPC and over REST, to show
+// what --transport rest costs (step 7h)
+func (lt *loadTest) transportCompare(ctx context.Context) error {
+	rest, ok := lt.client.(*restClient)
+	if !ok {
+		return nil
+	}
+	fmt.Printf("\n--- Step 7h: Search over gRPC vs REST (%d queries each) ---\n", lt.cfg.CompareQueries)
+	var err error
+	if lt.m.grpcLatency, err = timeSearchBatch(ctx, rest.Client, lt.collections[0], lt.cfg.metricType, lt.cfg.CompareQueries); err != nil {
+		return fmt.Errorf("failed to search over gRPC: %w", err)
+	}
+	if lt.m.restLatency, err = timeSearchBatch(ctx, rest, lt.collections[0], lt.cfg.metricType, lt.cfg.CompareQueries); err != nil {
+		return fmt.Errorf("failed to search over REST: %w", err)
+	}
+	grpcLatency, restLatency := lt.m.grpcLatency, lt.m.restLatency
+	fmt.Printf("   %-12s %12s %12s %12s %12s %12s\n", "Transport", "QPS", "Avg", "p50", "p95", "p99")
+	fmt.Printf("   %-12s %12.1f %12s %12s %12s %12s\n", "gRPC", sequentialQPS(grpcLatency), grpcLatency.avg, grpcLatency.p50, grpcLatency.p95, grpcLatency.p99)
+	fmt.Printf("   %-12s %12.1f %12s %12s %12s %12s\n", "REST", sequentialQPS(restLatency), restLatency.avg, restLatency.p50, restLatency.p95, restLatency.p99)
+	fmt.Printf("   %-12s %11.1f%% %12s %12s %12s %12s\n", "Difference", lt.m.restThroughputChange(),
+		restLatency.avg-grpcLatency.avg, restLatency.p50-grpcLatency.p50, restLatency.p95-grpcLatency.p95, restLatency.p99-grpcLatency.p99)
+	fmt.Println("✅ Transport comparison complete.")
+	return nil
+}
+
+// sequentialQPS returns the throughput of a batch of one-at-a-time searches
+func sequentialQPS(l latencySummary) float64 {
+	if l.avg <= 0 {
+		return 0
+	}
+	return float64(time.Second) / float64(l.avg)
+}
+
+// restThroughputChange returns how much higher (positive) or lower the REST
+// search throughput of the transport comparison is than gRPC's, in percent
+func (m *metrics) restThroughputChange() float64 {
+	grpcQPS := sequentialQPS(m.grpcLatency)
+	if grpcQPS == 0 {
+		return 0
+	}
+	return 100 * (sequentialQPS(m.restLatency) - grpcQPS) / grpcQPS
+}
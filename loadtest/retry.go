@@ -0,0 +1,158 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRetryBackoff caps the exponential backoff between two attempts
+const maxRetryBackoff = 30 * time.Second
+
+// retryableMessages are fragments of Milvus service error reasons that describe
+// transient conditions (overload, leader switch, nodes still coming up)
+var retryableMessages = []string{
+	"rate limit",
+	"request limit exceeded",
+	"service not ready",
+	"service unavailable",
+	"not fully loaded",
+	"channel not available",
+	"shard leader",
+	"no available shard delegator",
+	"node not found",
+	"server is closed",
+}
+
+// throttleMessages are fragments of the errors Milvus returns when a read or write
+// quota / rate limit pushes back on the client
+var throttleMessages = []string{
+	"rate limit",
+	"request limit exceeded",
+	"quota exceeded",
+	"deny to write",
+	"deny to read",
+	"memory limit exceeded",
+	"disk limit exceeded",
+}
+
+// retryPolicy describes how a single insert or search operation is retried
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+}
+
+// run executes op and retries it while it fails with a retryable error, waiting
+// backoff, 2*backoff, 4*backoff, ... between attempts. It returns the number of
+// retries that were performed and the error of the last attempt.
+func (p retryPolicy) run(ctx context.Context, op func() error) (int, error) {
+	retries := 0
+	for {
+		err := op()
+		if err == nil || retries >= p.maxRetries || !isRetryable(err) {
+			return retries, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return retries, err
+		case <-time.After(p.wait(retries)):
+		}
+		retries++
+	}
+}
+
+// wait returns the backoff before the retry following the given number of
+// retries: backoff doubled on every retry up to maxRetryBackoff, none with a
+// zero backoff. The doubling stops at the cap, so it cannot overflow.
+func (p retryPolicy) wait(retries int) time.Duration {
+	wait := p.backoff
+	for range retries {
+		if wait >= maxRetryBackoff {
+			break
+		}
+		wait *= 2
+	}
+	return min(wait, maxRetryBackoff)
+}
+
+// isRetryable reports whether err is a transient failure worth retrying, as opposed
+// to a permanent one (bad request, missing collection, schema mismatch, ...)
+func isRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// gRPC transport level errors carry a proper status code
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+			return true
+		case codes.Unknown:
+			// fall through to the message check below
+		default:
+			return false
+		}
+	}
+
+	// Milvus service errors only carry the reason string
+	return containsAny(err, retryableMessages)
+}
+
+// isThrottled reports whether err is the server enforcing a rate limit or quota
+func isThrottled(err error) bool {
+	if err == nil {
+		return false
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.ResourceExhausted {
+		return true
+	}
+	return containsAny(err, throttleMessages)
+}
+
+// containsAny reports whether the error message contains any of fragments, ignoring case
+func containsAny(err error, fragments []string) bool {
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range fragments {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// throttlePause is the adaptive pause a worker takes after being throttled: it
+// starts at base, doubles while the server keeps throttling and resets on the
+// first operation that goes through. A zero base disables it.
+type throttlePause struct {
+	base    time.Duration
+	current time.Duration
+}
+
+// after is called once per operation and sleeps when the operation was throttled
+func (t *throttlePause) after(ctx context.Context, throttled bool) time.Duration {
+	if t.base <= 0 {
+		return 0
+	}
+	if !throttled {
+		t.current = 0
+		return 0
+	}
+	if t.current == 0 {
+		t.current = t.base
+	} else if t.current < maxRetryBackoff {
+		t.current *= 2
+	}
+	if t.current > maxRetryBackoff {
+		t.current = maxRetryBackoff
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(t.current):
+	}
+	return t.current
+}
@@ -0,0 +1,107 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicyWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff time.Duration
+		retries int
+		want    time.Duration
+	}{
+		{"zero backoff", 0, 0, 0},
+		{"zero backoff stays zero", 0, 5, 0},
+		{"first retry", 100 * time.Millisecond, 0, 100 * time.Millisecond},
+		{"doubled", 100 * time.Millisecond, 1, 200 * time.Millisecond},
+		{"doubled twice", 100 * time.Millisecond, 2, 400 * time.Millisecond},
+		{"capped", 10 * time.Second, 2, maxRetryBackoff},
+		{"capped far past an overflowing shift", time.Second, 100, maxRetryBackoff},
+		{"backoff above the cap", time.Minute, 0, maxRetryBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := retryPolicy{maxRetries: 3, backoff: tt.backoff}
+			if got := p.wait(tt.retries); got != tt.want {
+				t.Errorf("wait(%d) with backoff %s = %s, want %s", tt.retries, tt.backoff, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRun(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "node down")
+	tests := []struct {
+		name        string
+		backoff     time.Duration
+		errs        []error // returned by the attempts in turn, nil once past the end
+		wantRetries int
+		wantErr     string // substring of the error, "" for none
+		maxElapsed  time.Duration
+	}{
+		{"no backoff retries at once", 0, []error{unavailable, unavailable, unavailable, unavailable}, 3, "node down", 50 * time.Millisecond},
+		{"succeeds on a retry", 0, []error{unavailable}, 1, "", 50 * time.Millisecond},
+		{"doubling backoff", 20 * time.Millisecond, []error{unavailable, unavailable}, 2, "", 500 * time.Millisecond},
+		{"permanent error not retried", 0, []error{errors.New("schema mismatch")}, 0, "schema mismatch", 50 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := retryPolicy{maxRetries: 3, backoff: tt.backoff}
+			attempt := 0
+			start := time.Now()
+			retries, err := p.run(context.Background(), func() error {
+				defer func() { attempt++ }()
+				if attempt < len(tt.errs) {
+					return tt.errs[attempt]
+				}
+				return nil
+			})
+			elapsed := time.Since(start)
+			if retries != tt.wantRetries {
+				t.Errorf("run() retried %d times, want %d", retries, tt.wantRetries)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("run() = %v, want no error", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("run() = %v, want an error containing %q", err, tt.wantErr)
+			}
+			if least := p.wait(0) * time.Duration(1<<tt.wantRetries-1); elapsed < least || elapsed > tt.maxElapsed {
+				t.Errorf("run() took %s, want %s to %s", elapsed, least, tt.maxElapsed)
+			}
+		})
+	}
+}
+
+func TestResolveRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff time.Duration
+		wantErr string // substring of the error, "" for none
+	}{
+		{"default", 100 * time.Millisecond, ""},
+		{"zero", 0, ""},
+		{"negative", -time.Second, "invalid --retry-backoff -1s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.RetryBackoff = tt.backoff
+			err := cfg.resolve()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("resolve() = %v, want no error", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("resolve() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,655 @@
+// Package loadtest runs the Milvus load test pipelines. The milvus-stress-test
+// command is a thin wrapper around it; other programs can drive a run directly:
+//
+//	cfg := loadtest.DefaultConfig()
+//	cfg.MilvusAddr = "milvus:19530"
+//	cfg.Pressure = "high"
+//	runner, err := loadtest.NewRunner(cfg)
+//	...
+//	result, err := runner.Run(ctx)
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// budgetCleanupTimeout bounds the cleanup run once --max-runtime has expired
+const budgetCleanupTimeout = time.Minute
+
+// Command is a subcommand: the pipeline of steps a run executes
+type Command struct {
+	Name        string
+	Description string
+	run         func(ctx context.Context, lt *loadTest) error
+}
+
+// commands lists the subcommands, the default one first
+var commands = []Command{
+	{"full", "Run the whole pipeline: create, insert, index, load, search, drop (default)", runFull},
+	{"insert", "Create and populate the collection, flush and index it, and keep it", runInsert},
+	{"search", "Load the collection populated by 'insert' and search it for --duration", runSearch},
+	{"cleanup", "Drop the test collection (or release it, see --cleanup-mode)", runCleanup},
+	{"list", "List the collections on the server with rows, load state and indexes (see --list-filter)", runList},
+	{"cleanup-orphans", "Drop the tool's collections older than --orphan-age left by crashed runs (needs --confirm)", runCleanupOrphans},
+}
+
+// Commands returns the available commands, the default one first
+func Commands() []Command {
+	return append([]Command(nil), commands...)
+}
+
+// findCommand returns the named command, or nil if there is none
+func findCommand(name string) *Command {
+	for i := range commands {
+		if commands[i].Name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// runFull runs connect -> create -> insert -> index -> load -> search -> cleanup
+func runFull(ctx context.Context, lt *loadTest) error {
+	if lt.cfg.DDLStress {
+		return lt.ddlStress(ctx)
+	}
+	if err := lt.dropExisting(ctx); err != nil {
+		return err
+	}
+	if err := lt.createCollection(ctx); err != nil {
+		return err
+	}
+	lt.insert(ctx)
+	if err := lt.hotSegmentCompare(ctx); err != nil {
+		return err
+	}
+	if err := lt.batchSweep(ctx); err != nil {
+		return err
+	}
+	if lt.cfg.FlushCompare {
+		if err := lt.preFlushSearch(ctx); err != nil {
+			return err
+		}
+	}
+	if err := lt.insertSentinel(ctx); err != nil {
+		return err
+	}
+	if err := lt.prepare(ctx); err != nil {
+		return err
+	}
+	if err := lt.timeToSearchable(ctx); err != nil {
+		return err
+	}
+	if err := lt.describeCollections(ctx); err != nil {
+		return err
+	}
+	if err := lt.reloadCycles(ctx); err != nil {
+		return err
+	}
+	if err := lt.timeWindow(ctx); err != nil {
+		return err
+	}
+	if lt.cfg.FlushCompare {
+		if err := lt.postFlushSearch(ctx); err != nil {
+			return err
+		}
+	}
+	lt.searchPhase(ctx, lt.cfg.Duration/4) // Search for 1/4 of the total test duration
+	if err := lt.sweep(ctx); err != nil {
+		return err
+	}
+	if err := lt.topKSweep(ctx); err != nil {
+		return err
+	}
+	if err := lt.findMaxQPS(ctx); err != nil {
+		return err
+	}
+	if err := lt.iteratorSearch(ctx); err != nil {
+		return err
+	}
+	if err := lt.aliasCompare(ctx); err != nil {
+		return err
+	}
+	if err := lt.transportCompare(ctx); err != nil {
+		return err
+	}
+	if err := lt.scalarIndexCompare(ctx); err != nil {
+		return err
+	}
+	if err := lt.freshness(ctx); err != nil {
+		return err
+	}
+	if err := lt.recallCheck(ctx); err != nil {
+		return err
+	}
+	if err := lt.projection(ctx); err != nil {
+		return err
+	}
+	return lt.cleanup(ctx)
+}
+
+// runInsert populates a fresh collection and leaves it in place for later 'search' runs
+func runInsert(ctx context.Context, lt *loadTest) error {
+	if lt.cfg.Resume {
+		if err := lt.resumeInsert(ctx); err != nil {
+			return err
+		}
+	} else {
+		if err := lt.dropExisting(ctx); err != nil {
+			return err
+		}
+		if err := lt.populate(ctx); err != nil {
+			return err
+		}
+	}
+	for _, name := range lt.collections {
+		fmt.Printf("\nCollection '%s' kept for subsequent 'search' runs.\n", name)
+	}
+	return nil
+}
+
+// runSearch searches the collection populated by a previous 'insert' run
+func runSearch(ctx context.Context, lt *loadTest) error {
+	if err := lt.useExisting(ctx); err != nil {
+		return err
+	}
+	if err := lt.load(ctx); err != nil {
+		return err
+	}
+	if err := lt.describeCollections(ctx); err != nil {
+		return err
+	}
+	if err := lt.reloadCycles(ctx); err != nil {
+		return err
+	}
+	if err := lt.timeWindow(ctx); err != nil {
+		return err
+	}
+	lt.searchPhase(ctx, lt.cfg.Duration)
+	if err := lt.sweep(ctx); err != nil {
+		return err
+	}
+	if err := lt.topKSweep(ctx); err != nil {
+		return err
+	}
+	if err := lt.findMaxQPS(ctx); err != nil {
+		return err
+	}
+	if err := lt.iteratorSearch(ctx); err != nil {
+		return err
+	}
+	if err := lt.aliasCompare(ctx); err != nil {
+		return err
+	}
+	if err := lt.transportCompare(ctx); err != nil {
+		return err
+	}
+	if err := lt.freshness(ctx); err != nil {
+		return err
+	}
+	return lt.projection(ctx)
+}
+
+// searchPhase runs the search phase, or the --mix workload in its place, after the
+// optional warm-up searches
+func (lt *loadTest) searchPhase(ctx context.Context, duration time.Duration) {
+	lt.preSearchDelay(ctx)
+	lt.warmSearch(ctx)
+	if len(lt.cfg.mix) > 0 {
+		lt.mixed(ctx, duration)
+		return
+	}
+	lt.search(ctx, duration)
+}
+
+// runCleanup drops or releases the test collection, per --cleanup-mode
+func runCleanup(ctx context.Context, lt *loadTest) error {
+	return lt.cleanup(ctx)
+}
+
+// populate creates the collection, inserts data, flushes and indexes it (steps 3-5)
+func (lt *loadTest) populate(ctx context.Context) error {
+	if err := lt.createCollection(ctx); err != nil {
+		return err
+	}
+	lt.insert(ctx)
+	if err := lt.hotSegmentCompare(ctx); err != nil {
+		return err
+	}
+	if err := lt.batchSweep(ctx); err != nil {
+		return err
+	}
+	if err := lt.flush(ctx); err != nil {
+		return err
+	}
+	return lt.createIndex(ctx)
+}
+
+// Runner runs one load test. Create it with NewRunner, call Run once, then
+// optionally PrintSummary to print the human-readable summary table.
+type Runner struct {
+	lt            *loadTest
+	totalDuration time.Duration
+}
+
+// Result holds the headline numbers of a run. Phases the command did not run are
+// zero. It is also the document written by --summary-json, durations in nanoseconds.
+type Result struct {
+	Command        string        `json:"command"`
+	TotalDuration  time.Duration `json:"total_duration_ns"`
+	BudgetExceeded bool          `json:"budget_exceeded"` // stopped by --max-runtime, the numbers are partial
+
+	ConnectionTime   time.Duration `json:"connection_time_ns"`
+	InsertionTime    time.Duration `json:"insertion_time_ns"`
+	FlushTime        time.Duration `json:"flush_time_ns"`
+	FlushRequests    int           `json:"flush_requests"` // under --flush-strategy
+	FlushReqTime     time.Duration `json:"flush_request_time_ns"`
+	PeriodicFlushes  int           `json:"periodic_flushes"` // under --flush-every-n-batches
+	PeriodicFlushAvg time.Duration `json:"periodic_flush_avg_ns"`
+	PeriodicSegments int           `json:"periodic_flush_segments"`
+	IndexTime        time.Duration `json:"index_time_ns"`
+	LoadTime         time.Duration `json:"load_time_ns"`
+	ReloadMin        time.Duration `json:"reload_min_ns"` // over the --load-repeats reloads
+	ReloadMedian     time.Duration `json:"reload_median_ns"`
+	ReloadMax        time.Duration `json:"reload_max_ns"`
+	SearchTime       time.Duration `json:"search_time_ns"`
+	CleanupTime      time.Duration `json:"cleanup_time_ns"`
+	CleanupAction    string        `json:"cleanup_action"` // dropped, released (data kept) or kept
+
+	VectorsInserted     int64   `json:"vectors_inserted"` // acknowledged by Milvus
+	InsertsPerSec       float64 `json:"inserts_per_sec"`
+	VectorsSubmitted    int64   `json:"vectors_submitted"` // sent, including failed and rejected rows
+	SubmittedPerSec     float64 `json:"submitted_per_sec"`
+	FailedInserts       int64   `json:"failed_inserts"`
+	PartialInserts      int64   `json:"partial_inserts"`  // batches Milvus acknowledged only some rows of
+	RowsRejected        int64   `json:"rows_rejected"`    // rows of those, not in vectors_inserted
+	FlushedSegments     int     `json:"flushed_segments"` // segments persisted by the flush
+	ConfiguredBatchSize int     `json:"configured_batch_size"`
+	AvgBatchSize        float64 `json:"avg_batch_size"`   // rows per insert actually sent, lower than configured during ramp-up
+	FinalBatchSize      int     `json:"final_batch_size"` // rows in the last insert sent
+	MinBatchSize        int     `json:"min_batch_size"`   // smallest and largest inserts sent, see --batch-jitter
+	MaxBatchSize        int     `json:"max_batch_size"`
+	ResumedFromRows     int64   `json:"resumed_from_rows"` // rows a --resume run found inserted already
+	DuplicationRatio    float64 `json:"duplication_ratio"` // rows repeating a --distinct-vectors vector, 0 without
+	ExportedRows        int64   `json:"exported_rows"`     // written to --export-vectors
+	Compression         string  `json:"compression"`
+	Transport           string  `json:"transport"`
+	InsertPayloadBytes  int64   `json:"insert_payload_bytes"` // insert messages before --compression, 0 without --wire-stats
+	InsertWireBytes     int64   `json:"insert_wire_bytes"`    // and as sent on the wire
+
+	GenerationTime  time.Duration `json:"generation_time_ns"` // insert workers building batches, summed over workers
+	InsertRPCTime   time.Duration `json:"insert_rpc_time_ns"` // and in Insert calls
+	GenerationShare float64       `json:"generation_share"`   // of the two, above 0.5 the run is client-bound
+
+	SearchesPerformed int64         `json:"searches_performed"`
+	SearchesPerSec    float64       `json:"searches_per_sec"`
+	FailedSearches    int64         `json:"failed_searches"`
+	SearchLatencyAvg  time.Duration `json:"search_latency_avg_ns"`
+	SearchLatencyP50  time.Duration `json:"search_latency_p50_ns"`
+	SearchLatencyP95  time.Duration `json:"search_latency_p95_ns"`
+	SearchLatencyP99  time.Duration `json:"search_latency_p99_ns"`
+	Recall            float64       `json:"recall"`        // mean recall@k, 0 without a ground truth file
+	ExactRecall       float64       `json:"exact_recall"`  // recall@k against exact search, 0 without --recall-check
+	FlatRecall        float64       `json:"flat_recall"`   // the same on IVF_FLAT, 0 unless --index-type quantizes
+	EmptyResults      int64         `json:"empty_results"` // searches with no hits, 0 without --validate-results
+	ShortResults      int64         `json:"short_results"` // searches with fewer hits than expected
+	EmptyResultRate   float64       `json:"empty_result_rate"`
+	IndexType         string        `json:"index_type"`
+
+	AliasLatencyOverhead time.Duration `json:"alias_latency_overhead_ns"` // mean latency through --alias minus by name
+	GRPCSearchQPS        float64       `json:"grpc_search_qps"`           // sequential --transport rest comparison batch, 0 without
+	RESTSearchQPS        float64       `json:"rest_search_qps"`
+	FreshnessLagP50      time.Duration `json:"freshness_lag_p50_ns"` // insert acknowledged to searchable, 0 without --freshness
+	FreshnessLagP99      time.Duration `json:"freshness_lag_p99_ns"`
+	TimeWindowRows       int64         `json:"time_window_rows"` // rows matching the --time-window filter
+
+	Failovers   int    `json:"failovers"`    // switches to another --milvus-addr
+	BusiestAddr string `json:"busiest_addr"` // the address that served the most inserts and searches
+
+	ClientGCCount      int64         `json:"client_gc_count"` // garbage collections in the tool itself
+	ClientGCPauseTotal time.Duration `json:"client_gc_pause_total_ns"`
+	ClientGCPauseMax   time.Duration `json:"client_gc_pause_max_ns"`
+
+	// Estimates from --cost-per-hour and the insert and search throughput, 0 without
+	EstCostPerMillionInserts  float64 `json:"est_cost_per_million_inserts"`
+	EstCostPerMillionSearches float64 `json:"est_cost_per_million_searches"`
+
+	// AssertionFailures describes every SLA assertion the run missed. Run still
+	// succeeds; callers decide whether a non-empty list fails the run.
+	AssertionFailures []string `json:"assertion_failures"`
+
+	Collections []CollectionInfo `json:"collections"` // as described once loaded, nil if no load ran
+	Timeline    []PhaseSpan      `json:"timeline"`    // wall clock start and end of every phase
+
+	Sweep     []SweepPoint  `json:"sweep"`       // latency vs throughput curve, nil without --sweep
+	TopKSweep []SweepPoint  `json:"top_k_sweep"` // latency vs topK curve, nil without --topk-sweep
+	TopKKnee  int           `json:"top_k_knee"`  // first topK whose p50 doubled, 0 if none did
+	Mix       []MixOpResult `json:"mix"`         // per operation results of the --mix workload
+
+	MaxSustainableQPS float64       `json:"max_sustainable_qps"` // highest --find-max-qps rate that met the bound, 0 if none
+	MaxQPSLatencyP50  time.Duration `json:"max_qps_latency_p50_ns"`
+	MaxQPSLatencyP99  time.Duration `json:"max_qps_latency_p99_ns"`
+	MaxQPSProbes      []SweepPoint  `json:"max_qps_probes"` // every rate --find-max-qps probed, in order
+
+	// Search iterator latencies and throughput, 0 without --iterator
+	IteratorOpenP50      time.Duration `json:"iterator_open_p50_ns"`
+	IteratorPageP50      time.Duration `json:"iterator_page_p50_ns"`
+	IteratorPageP99      time.Duration `json:"iterator_page_p99_ns"`
+	IteratorRetrievalP50 time.Duration `json:"iterator_retrieval_p50_ns"` // all pages of one query vector
+	IteratorRetrievalP99 time.Duration `json:"iterator_retrieval_p99_ns"`
+	IteratorHits         int64         `json:"iterator_hits"`
+	IteratorHitsPerSec   float64       `json:"iterator_hits_per_sec"`
+	IteratorDuplicates   int64         `json:"iterator_duplicates"` // hits returned again within one retrieval
+
+	DDLStress    []MixOpResult `json:"ddl_stress"`    // create and drop results of --ddl-stress (weight 0)
+	DDLLeftovers int           `json:"ddl_leftovers"` // --ddl-stress collections dropped at the end
+
+	// InsertLatencyByBatch is the insert latency per power-of-two batch size, to
+	// see how it scales when --ramp-up or --batch-jitter vary the batch size
+	InsertLatencyByBatch []BatchSizeBucket `json:"insert_latency_by_batch_size"`
+
+	// Insert batch and search phase latency at each of --percentiles
+	InsertLatencyPercentiles []LatencyPercentile `json:"insert_latency_percentiles"`
+	SearchLatencyPercentiles []LatencyPercentile `json:"search_latency_percentiles"`
+
+	// ServerMetrics is the --server-metrics-interval time series of the Milvus
+	// metrics endpoint, with the client throughput at each scrape
+	ServerMetrics []ServerSample `json:"server_metrics"`
+
+	Connections []ConnectionStats `json:"connections"` // per --connections, nil with a single connection
+
+	ScalarIndexes []ScalarIndexResult `json:"scalar_indexes"` // filtered search per --scalar-index, nil without
+
+	HotSegment []HotSegmentResult `json:"hot_segment"` // insert throughput per --hot-segment layout, nil without
+
+	BatchSweep []BatchSweepResult `json:"batch_sweep"` // insert throughput per --batch-sweep size, nil without
+
+	IndexTypes []IndexTypeResult `json:"index_types"` // search phase per --index-mix index type, nil without
+
+	PreSearchWait    time.Duration `json:"pre_search_wait_ns"` // spent in --pre-search-delay, 0 without
+	PreSearchSettled bool          `json:"pre_search_settled"` // indexing and loading completed during it
+
+	// LoadOrder times the flush, index and load steps in --load-order, followed
+	// by the handoff when the index was created before the flush
+	LoadOrder   []StepTiming  `json:"load_order"`
+	HandoffTime time.Duration `json:"handoff_time_ns"`
+
+	// Coefficient of variation of the per-second throughput of the insert and
+	// search phases, 0 with fewer than two seconds sampled
+	InsertThroughputCV float64 `json:"insert_throughput_cv"`
+	SearchThroughputCV float64 `json:"search_throughput_cv"`
+
+	// Canaries summarizes each --canary-queries vector, CanarySeries is every
+	// canary search in order; nil without
+	Canaries     []CanaryResult `json:"canaries"`
+	CanarySeries []CanarySample `json:"canary_series"`
+
+	DDLRetries int `json:"ddl_retries"` // collection existence checks, creates and drops sent again
+	DDLRaces   int `json:"ddl_races"`   // creates and drops another client had already done
+
+	Listed  []ListedCollection `json:"listed_collections"` // found by the list command, nil for the others
+	Orphans []Orphan           `json:"orphans"`            // dropped, or in a dry run due, by cleanup-orphans
+
+	FieldChecks []FieldCheck `json:"field_checks"` // search output values per --verify-fields field, nil without
+
+	PartialLoads []PartialLoad `json:"partial_loads"` // collections only partly loaded within --load-timeout, nil when all loaded
+
+	CoolDown []CoolDownStep `json:"cool_down"` // search traffic per --cool-down step, nil without
+
+	Faults []FaultResult `json:"injected_faults"` // malformed batches per --fault-injection-rate kind, nil without
+
+	// --time-to-searchable: from the sentinel row's insert, and from the end of
+	// the load, until search returned it; zero without or when it was missed
+	TimeToSearchable    time.Duration `json:"time_to_searchable_ns"`
+	SearchableAfterLoad time.Duration `json:"searchable_after_load_ns"`
+	SentinelMissed      bool          `json:"sentinel_missed"`
+
+	ScalarCardinality []FieldCardinality `json:"scalar_cardinality"` // distinct values per --scalar-gen field, nil without
+}
+
+// NewRunner validates cfg and prepares a run. It does not connect to Milvus yet.
+// Its errors match ErrConfig.
+func NewRunner(cfg Config) (*Runner, error) {
+	if err := cfg.resolve(); err != nil {
+		return nil, withCategory(ErrConfig, err)
+	}
+	lt := &loadTest{cfg: &cfg, collections: collectionNames(cfg.Collections)}
+	if cfg.IDPoolSize > 0 {
+		lt.ids = newIDPool(cfg.IDPoolSize)
+	}
+	if cfg.QueryFile != "" {
+		queries, err := loadQuerySet(cfg.QueryFile, cfg.GroundTruthFile)
+		if err != nil {
+			return nil, withCategory(ErrConfig, err)
+		}
+		lt.queries = queries
+	}
+	return &Runner{lt: lt}, nil
+}
+
+// Run connects to Milvus and runs the configured command. A failure to connect
+// matches ErrConnect. When the run outlasts --max-runtime it returns the partial
+// Result along with ErrBudgetExceeded.
+func (r *Runner) Run(ctx context.Context) (*Result, error) {
+	lt, cfg := r.lt, r.lt.cfg
+	if cfg.MaxRuntime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.MaxRuntime)
+		defer cancel()
+	}
+	cfg.printConfig()
+	if lt.queries != nil {
+		fmt.Printf("Loaded %d query vectors from %s\n", len(lt.queries.vectors), cfg.QueryFile)
+	}
+
+	totalStartTime := time.Now()
+	lt.start = totalStartTime
+	gcStart := takeGCSnapshot()
+	if cfg.EventStream != "" {
+		events, err := newEventStream(cfg.EventStream)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open event stream: %w", err)
+		}
+		lt.events = events
+		defer events.close()
+	}
+	if cfg.ExportVectors != "" {
+		exporter, err := newVectorExporter(cfg.ExportVectors)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create export file: %w", err)
+		}
+		lt.exporter = exporter
+		defer exporter.close()
+	}
+	if err := lt.connect(ctx); err != nil {
+		return nil, withCategory(ErrConnect, err)
+	}
+	defer lt.close()
+	faults, err := lt.newFaultInjector()
+	if err != nil {
+		return nil, withCategory(ErrConfig, err)
+	}
+	lt.faults = faults
+	defer func() {
+		// The alias is dropped however the run ends, even when ctx is cancelled
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), aliasCleanupTimeout)
+		defer cancel()
+		if err := lt.dropAlias(cleanupCtx); err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+		}
+	}()
+
+	scraper := lt.startServerScraper(ctx)
+	err = findCommand(cfg.Command).run(ctx, lt)
+	scraper.stop()
+	if cfg.MaxRuntime > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		lt.budgetExceeded = true
+		fmt.Printf("\n⏱️  TIME BUDGET EXCEEDED: --max-runtime %s reached, the current phase was stopped.\n", cfg.MaxRuntime)
+		if cfg.Command == "full" && lt.m.cleanupAction == "" {
+			cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), budgetCleanupTimeout)
+			defer cancel()
+			if err := lt.cleanup(cleanupCtx); err != nil {
+				fmt.Printf("⚠️  Cleanup after the time budget failed: %v\n", err)
+			}
+		}
+		lt.m.gc = gcStart.since()
+		r.totalDuration = time.Since(totalStartTime)
+		return r.result(), ErrBudgetExceeded
+	}
+	if err != nil {
+		return nil, err
+	}
+	lt.m.gc = gcStart.since()
+	r.totalDuration = time.Since(totalStartTime)
+	return r.result(), nil
+}
+
+// PrintSummary prints the summary table of the completed run
+func (r *Runner) PrintSummary() {
+	r.lt.printSummary(r.totalDuration)
+}
+
+// result collects the Result of the completed run
+func (r *Runner) result() *Result {
+	m := &r.lt.m
+	var mix []MixOpResult
+	for _, op := range r.lt.cfg.mix {
+		mix = append(mix, MixOpResult{
+			Operation:  op.name,
+			Weight:     op.weight,
+			Operations: op.ops,
+			Failures:   op.failures,
+			OpsPerSec:  op.rate(m.mixTime),
+			LatencyP50: op.latency.p50,
+			LatencyP99: op.latency.p99,
+		})
+	}
+	result := &Result{
+		Command:              r.lt.cfg.Command,
+		TotalDuration:        r.totalDuration,
+		BudgetExceeded:       r.lt.budgetExceeded,
+		ConnectionTime:       m.connectionTime,
+		InsertionTime:        m.insertionTime,
+		FlushTime:            m.flushTime,
+		FlushRequests:        m.flushRequests,
+		FlushReqTime:         m.flushRequestTime,
+		PeriodicFlushes:      m.periodicFlushes,
+		PeriodicFlushAvg:     m.avgPeriodicFlushTime(),
+		PeriodicSegments:     m.periodicFlushSegments,
+		IndexTime:            m.indexTime,
+		LoadTime:             m.loadTime,
+		ReloadMin:            m.reloadLatency.min,
+		ReloadMedian:         m.reloadLatency.p50,
+		ReloadMax:            m.reloadLatency.max,
+		SearchTime:           m.searchTime,
+		CleanupTime:          m.cleanupTime,
+		CleanupAction:        m.cleanupAction,
+		VectorsInserted:      m.totalVectorsInserted,
+		InsertsPerSec:        m.insertsPerSec,
+		VectorsSubmitted:     m.insertBatchRows,
+		SubmittedPerSec:      m.submittedPerSec(),
+		FailedInserts:        m.failedInserts,
+		PartialInserts:       m.partialInserts,
+		RowsRejected:         m.rowsRejected,
+		FlushedSegments:      m.flushedSegments,
+		ConfiguredBatchSize:  r.lt.cfg.batchSize,
+		AvgBatchSize:         m.avgBatchSize(),
+		FinalBatchSize:       m.finalBatchSize,
+		ExportedRows:         m.exportedRows,
+		MinBatchSize:         m.minBatchSize,
+		MaxBatchSize:         m.maxBatchSize,
+		GenerationTime:       m.insertTime().generation,
+		InsertRPCTime:        m.insertTime().rpc,
+		GenerationShare:      m.insertTime().share(),
+		Compression:          r.lt.cfg.Compression,
+		Transport:            r.lt.cfg.Transport,
+		SearchesPerformed:    m.totalSearchesPerformed,
+		SearchesPerSec:       m.searchesPerSec,
+		FailedSearches:       m.failedSearches,
+		SearchLatencyAvg:     m.searchLatency.avg,
+		SearchLatencyP50:     m.searchLatency.p50,
+		SearchLatencyP95:     m.searchLatency.p95,
+		SearchLatencyP99:     m.searchLatency.p99,
+		Recall:               m.recall(),
+		ExactRecall:          m.exactRecall,
+		FlatRecall:           m.flatRecall,
+		EmptyResults:         m.emptyResults,
+		ShortResults:         m.shortResults,
+		EmptyResultRate:      m.emptyResultRate(),
+		IndexType:            string(r.lt.cfg.indexType),
+		AliasLatencyOverhead: m.aliasLatency.avg - m.directLatency.avg,
+		GRPCSearchQPS:        sequentialQPS(m.grpcLatency),
+		RESTSearchQPS:        sequentialQPS(m.restLatency),
+		FreshnessLagP50:      m.freshnessLag.p50,
+		FreshnessLagP99:      m.freshnessLag.p99,
+		TimeWindowRows:       m.timeWindowRows,
+		Failovers:            len(m.failovers),
+		BusiestAddr:          r.lt.busiestAddr(),
+		ClientGCCount:        m.gc.collections,
+		ClientGCPauseTotal:   m.gc.pauseTotal,
+		ClientGCPauseMax:     m.gc.pauseMax,
+		Collections:          append([]CollectionInfo(nil), m.collectionInfo...),
+		Timeline:             append([]PhaseSpan(nil), m.timeline...),
+		Sweep:                append([]SweepPoint(nil), m.sweep...),
+		TopKSweep:            append([]SweepPoint(nil), m.topKSweep...),
+		TopKKnee:             m.topKKnee,
+		MaxSustainableQPS:    m.maxQPS.TargetQPS,
+		MaxQPSLatencyP50:     m.maxQPS.LatencyP50,
+		MaxQPSLatencyP99:     m.maxQPS.LatencyP99,
+		MaxQPSProbes:         append([]SweepPoint(nil), m.maxQPSProbes...),
+		IteratorOpenP50:      m.iteratorOpen.p50,
+		IteratorPageP50:      m.iteratorPages.p50,
+		IteratorPageP99:      m.iteratorPages.p99,
+		IteratorRetrievalP50: m.iteratorRetrievals.p50,
+		IteratorRetrievalP99: m.iteratorRetrievals.p99,
+		IteratorHits:         m.iteratorHits,
+		IteratorHitsPerSec:   m.iteratorHitsPerSec(),
+		IteratorDuplicates:   m.iteratorDuplicates,
+		Mix:                  mix,
+		InsertLatencyByBatch: m.batchSizeBuckets(),
+		ScalarIndexes:        append([]ScalarIndexResult(nil), m.scalarIndexes...),
+		ServerMetrics:        append([]ServerSample(nil), m.serverSamples...),
+		Connections:          append([]ConnectionStats(nil), m.connStats...),
+		DDLLeftovers:         m.ddlLeftovers,
+	}
+	result.PreSearchWait, result.PreSearchSettled = m.preSearchWait, m.preSearchSettled
+	result.HotSegment = append([]HotSegmentResult(nil), m.hotSegment...)
+	result.BatchSweep = append([]BatchSweepResult(nil), m.batchSweep...)
+	result.IndexTypes = append([]IndexTypeResult(nil), m.indexTypeSearch...)
+	result.LoadOrder, result.HandoffTime = append([]StepTiming(nil), m.loadOrder...), m.handoffTime
+	result.InsertThroughputCV, _ = m.insertSeries.cv()
+	result.SearchThroughputCV, _ = m.searchSeries.cv()
+	result.Canaries = append([]CanaryResult(nil), m.canaryResults...)
+	result.DDLRetries, result.DDLRaces = m.ddlRetries, m.ddlRaces
+	result.CanarySeries = append([]CanarySample(nil), m.canarySeries...)
+	result.ScalarCardinality = r.lt.cfg.scalarCardinality()
+	result.PartialLoads = append([]PartialLoad(nil), m.partialLoads...)
+	result.CoolDown = m.coolDownTrajectory(r.lt.cfg)
+	result.Faults = append([]FaultResult(nil), m.faults...)
+	result.TimeToSearchable, result.SearchableAfterLoad, result.SentinelMissed = m.timeToSearchable, m.searchableAfterLoad, m.sentinelMissed
+	result.FieldChecks = append([]FieldCheck(nil), m.fieldChecks...)
+	result.Listed = append([]ListedCollection(nil), m.listed...)
+	result.Orphans = append([]Orphan(nil), m.orphans...)
+	result.InsertLatencyPercentiles = m.insertPercentiles(r.lt.cfg.percentiles)
+	result.SearchLatencyPercentiles = m.searchPercentiles
+	result.EstCostPerMillionInserts = costPerMillion(r.lt.cfg.CostPerHour, m.insertsPerSec)
+	result.EstCostPerMillionSearches = costPerMillion(r.lt.cfg.CostPerHour, m.searchesPerSec)
+	if m.ddlTime > 0 {
+		result.DDLStress = []MixOpResult{m.ddlCreates.opResult("create"), m.ddlDrops.opResult("drop")}
+	}
+	if pool := r.lt.vectorPool; pool != nil {
+		result.DuplicationRatio = pool.duplicationRatio()
+	}
+	if r.lt.resumed != nil {
+		result.ResumedFromRows = r.lt.resumed.RowsInserted
+	}
+	if wire := r.lt.wire; wire != nil {
+		result.InsertPayloadBytes = wire.insert.payloadSent.Load()
+		result.InsertWireBytes = wire.insert.bytesSent.Load()
+	}
+	result.AssertionFailures = result.assertionFailures(r.lt.cfg)
+	return result
+}
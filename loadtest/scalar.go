@@ -0,0 +1,151 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// scalarIntRange bounds the values generated for Int64 scalar fields
+const scalarIntRange = 1_000_000
+
+// scalarField is an extra scalar field of the test collection, declared with
+// --scalar-fields as name:type[:key=value...]
+type scalarField struct {
+	name       string
+	dataType   entity.FieldType
+	typeParams map[string]string
+	timestamp  bool          // Int64 insert time in unix milliseconds, from --timestamp-field
+	spread     time.Duration // timestamps are spread at random over this much of the past, 0 = insert time
+	gen        *valueGen     // value distribution from --scalar-gen, nil for the default random values
+}
+
+// scalarTypes maps the --scalar-fields type names to their field type
+var scalarTypes = map[string]entity.FieldType{
+	"int64":   entity.FieldTypeInt64,
+	"float":   entity.FieldTypeFloat,
+	"varchar": entity.FieldTypeVarChar,
+}
+
+// parseScalarFields parses a comma separated list of name:type declarations, each
+// optionally followed by :key=value type params
+func parseScalarFields(spec string) ([]scalarField, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var fields []scalarField
+	seen := map[string]bool{primaryKeyField: true, embeddingField: true}
+	for _, decl := range strings.Split(spec, ",") {
+		parts := strings.Split(strings.TrimSpace(decl), ":")
+		if len(parts) < 2 || parts[0] == "" {
+			return nil, fmt.Errorf("field %q: want name:type[:key=value...]", decl)
+		}
+		name, typeName := parts[0], parts[1]
+		if seen[name] {
+			return nil, fmt.Errorf("field %q: field name already in use", name)
+		}
+		seen[name] = true
+		dataType, ok := scalarTypes[strings.ToLower(typeName)]
+		if !ok {
+			return nil, fmt.Errorf("field %q: unknown type %q (want int64, float or varchar)", name, typeName)
+		}
+		typeParams, err := parseParams(strings.Join(parts[2:], ":"), ":")
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		field := scalarField{name: name, dataType: dataType, typeParams: typeParams}
+		if err := checkTypeParams(field.schemaField()); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// schemaField returns the collection schema field
+func (f scalarField) schemaField() *entity.Field {
+	field := entity.NewField().WithName(f.name).WithDataType(f.dataType)
+	for key, value := range f.typeParams {
+		field.WithTypeParams(key, value)
+	}
+	return field
+}
+
+// columnBuilder is a reusable column builder that can fill itself with random values
+type columnBuilder interface {
+	reset()
+	appendRandom()
+	column() entity.Column
+	sizeBytes() int64
+}
+
+// fillRandom appends n random values to b, returning false if ctx is done first
+func fillRandom(ctx context.Context, b columnBuilder, n int) bool {
+	for k := 0; k < n; k++ {
+		if k%generationCheckInterval == 0 && ctx.Err() != nil {
+			return false
+		}
+		b.appendRandom()
+	}
+	return true
+}
+
+// newBuilder returns a column builder generating values for the field
+func (f scalarField) newBuilder(capacity int) columnBuilder {
+	if f.timestamp {
+		return &timestampColumn{int64Column: newInt64Column(f.name, capacity), spread: f.spread}
+	}
+	if f.gen != nil {
+		return f.newGenColumn(capacity)
+	}
+	switch f.dataType {
+	case entity.FieldTypeInt64:
+		return newInt64Column(f.name, capacity)
+	case entity.FieldTypeFloat:
+		return newFloatColumn(f.name, capacity)
+	default:
+		maxLength, _ := strconv.Atoi(f.typeParams[typeParamMaxLength]) // checked by parseScalarFields
+		return newVarCharColumn(f.name, maxLength, capacity)
+	}
+}
+
+func (c *int64Column) appendRandom()    { c.append(rand.Int63n(scalarIntRange)) }
+func (c *int64Column) sizeBytes() int64 { return int64(8 * len(c.data)) }
+
+// timestampColumn generates insert timestamps in unix milliseconds
+type timestampColumn struct {
+	*int64Column
+	spread time.Duration
+}
+
+func (c *timestampColumn) appendRandom() {
+	ts := time.Now()
+	if c.spread > 0 {
+		ts = ts.Add(-time.Duration(rand.Int63n(int64(c.spread))))
+	}
+	c.append(ts.UnixMilli())
+}
+
+func (c *floatColumn) appendRandom()    { c.append(rand.Float32() * 100) }
+func (c *floatColumn) sizeBytes() int64 { return int64(4 * len(c.data)) }
+
+func (c *varCharColumn) appendRandom() {
+	value := "item-" + strconv.Itoa(rand.Intn(scalarIntRange))
+	if len(value) > c.maxLength {
+		value = value[len(value)-c.maxLength:] // keep the random digits
+	}
+	c.append(value)
+}
+
+func (c *varCharColumn) sizeBytes() int64 {
+	size := 0
+	for _, s := range c.data {
+		size += len(s)
+	}
+	return int64(size)
+}
@@ -0,0 +1,349 @@
+package loadtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// The Zipf distribution of a bare "zipf" generator: exponent and number of values
+const (
+	defaultZipfS = 1.2
+	defaultZipfN = 1000
+)
+
+// maxTrackedDistinct bounds the distinct values counted per generated field, so
+// a continuous distribution cannot grow the count without limit
+const maxTrackedDistinct = 100_000
+
+// generatorSeq tells apart the random sources of builders created at once
+var generatorSeq atomic.Int64
+
+// A valueGen is the --scalar-gen value distribution of one scalar field
+type valueGen struct {
+	spec     string
+	kind     string    // enum, uniform, normal or zipf
+	enum     []string  // enum values, as given
+	a, b     float64   // uniform low and high, normal mean and stddev, zipf exponent and value count
+	distinct *distinct // values generated so far, shared by every builder of the field
+}
+
+// FieldCardinality is how many distinct values a --scalar-gen field received
+type FieldCardinality struct {
+	Field     string `json:"field"`
+	Generator string `json:"generator"`
+	Distinct  int    `json:"distinct"`
+	Capped    bool   `json:"capped"` // counting stopped at maxTrackedDistinct
+}
+
+// distinct counts the distinct values of a field, up to maxTrackedDistinct
+type distinct struct {
+	mu     sync.Mutex
+	values map[string]struct{}
+	capped atomic.Bool
+}
+
+// add records a batch of values
+func (d *distinct) add(values []string) {
+	if d.capped.Load() {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, v := range values {
+		if len(d.values) >= maxTrackedDistinct {
+			d.capped.Store(true)
+			return
+		}
+		d.values[v] = struct{}{}
+	}
+}
+
+// count returns the distinct values seen and whether counting stopped early
+func (d *distinct) count() (int, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.values), d.capped.Load()
+}
+
+// parseScalarGens attaches the generators of --scalar-gen, field=kind[:args]
+// entries separated by semicolons, to the declared fields:
+//
+//	enum:a,b,c        one of the listed values, equally likely
+//	uniform:low,high  uniform over [low, high], integers for int64 fields
+//	normal:mean,dev   normal, rounded for int64 fields
+//	zipf[:s,n]        Zipf over 0..n-1 with exponent s > 1 (default 1.2, 1000)
+//
+// Varchar fields take the numeric distributions as "item-<n>" strings.
+func parseScalarGens(spec string, fields []scalarField) error {
+	if spec == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(spec, ";") {
+		name, genSpec, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" || genSpec == "" {
+			return fmt.Errorf("entry %q: want field=kind[:args]", entry)
+		}
+		i := -1
+		for j := range fields {
+			if fields[j].name == name {
+				i = j
+			}
+		}
+		if i < 0 {
+			return fmt.Errorf("field %q is not declared by --scalar-fields", name)
+		}
+		if fields[i].gen != nil {
+			return fmt.Errorf("field %q has two generators", name)
+		}
+		gen, err := parseValueGen(genSpec, fields[i])
+		if err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+		fields[i].gen = gen
+	}
+	return nil
+}
+
+// parseValueGen parses the generator spec of one field
+func parseValueGen(spec string, field scalarField) (*valueGen, error) {
+	kind, args, _ := strings.Cut(spec, ":")
+	gen := &valueGen{spec: spec, kind: kind, distinct: &distinct{values: map[string]struct{}{}}}
+	numbers := func(want int) error {
+		parts := strings.Split(args, ",")
+		if len(parts) != want {
+			return fmt.Errorf("%s wants %d comma separated numbers, got %q", kind, want, args)
+		}
+		var err error
+		if gen.a, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64); err != nil {
+			return fmt.Errorf("%s: %w", kind, err)
+		}
+		if gen.b, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64); err != nil {
+			return fmt.Errorf("%s: %w", kind, err)
+		}
+		return nil
+	}
+	switch kind {
+	case "enum":
+		if args == "" {
+			return nil, fmt.Errorf("enum wants at least one value")
+		}
+		gen.enum = strings.Split(args, ",")
+		maxLength, _ := strconv.Atoi(field.typeParams[typeParamMaxLength])
+		for _, v := range gen.enum {
+			var err error
+			switch field.dataType {
+			case entity.FieldTypeInt64:
+				_, err = strconv.ParseInt(v, 10, 64)
+			case entity.FieldTypeFloat:
+				_, err = strconv.ParseFloat(v, 32)
+			default:
+				if len(v) > maxLength {
+					err = fmt.Errorf("longer than max_length %d", maxLength)
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("enum value %q: %w", v, err)
+			}
+		}
+	case "uniform":
+		if err := numbers(2); err != nil {
+			return nil, err
+		}
+		if gen.a > gen.b {
+			return nil, fmt.Errorf("uniform low %g is above high %g", gen.a, gen.b)
+		}
+	case "normal":
+		if err := numbers(2); err != nil {
+			return nil, err
+		}
+		if gen.b < 0 {
+			return nil, fmt.Errorf("normal stddev %g must be >= 0", gen.b)
+		}
+	case "zipf":
+		gen.a, gen.b = defaultZipfS, defaultZipfN
+		if args != "" {
+			if err := numbers(2); err != nil {
+				return nil, err
+			}
+		}
+		if gen.a <= 1 || gen.b < 1 || gen.b != math.Trunc(gen.b) {
+			return nil, fmt.Errorf("zipf wants an exponent > 1 and a whole number of values >= 1, got %g,%g", gen.a, gen.b)
+		}
+	default:
+		return nil, fmt.Errorf("unknown generator %q (want enum, uniform, normal or zipf)", kind)
+	}
+	return gen, nil
+}
+
+// generator draws the values of one column builder from its own random source,
+// since rand.Zipf needs one and the builders of a field run concurrently
+type generator struct {
+	gen  *valueGen
+	rng  *rand.Rand
+	zipf *rand.Zipf
+}
+
+func newGenerator(gen *valueGen) *generator {
+	g := &generator{gen: gen, rng: rand.New(rand.NewSource(time.Now().UnixNano() + generatorSeq.Add(1)))}
+	if gen.kind == "zipf" {
+		g.zipf = rand.NewZipf(g.rng, gen.a, 1, uint64(gen.b)-1)
+	}
+	return g
+}
+
+// number draws a numeric value, or the index of an enum value
+func (g *generator) number() float64 {
+	switch g.gen.kind {
+	case "enum":
+		return float64(g.rng.Intn(len(g.gen.enum)))
+	case "uniform":
+		return g.gen.a + g.rng.Float64()*(g.gen.b-g.gen.a)
+	case "normal":
+		return g.gen.a + g.rng.NormFloat64()*g.gen.b
+	default:
+		return float64(g.zipf.Uint64())
+	}
+}
+
+// integer draws a value for an int64 column; uniform covers both bounds
+func (g *generator) integer() int64 {
+	if g.gen.kind == "enum" {
+		v, _ := strconv.ParseInt(g.gen.enum[g.rng.Intn(len(g.gen.enum))], 10, 64) // checked by parseValueGen
+		return v
+	}
+	if g.gen.kind == "uniform" {
+		low, high := int64(math.Ceil(g.gen.a)), int64(math.Floor(g.gen.b))
+		return low + g.rng.Int63n(max(high-low+1, 1))
+	}
+	return int64(math.Round(g.number()))
+}
+
+// newGenColumn returns the column builder of a field with a --scalar-gen generator
+func (f scalarField) newGenColumn(capacity int) columnBuilder {
+	g := newGenerator(f.gen)
+	switch f.dataType {
+	case entity.FieldTypeInt64:
+		return &genInt64Column{int64Column: newInt64Column(f.name, capacity), g: g}
+	case entity.FieldTypeFloat:
+		return &genFloatColumn{floatColumn: newFloatColumn(f.name, capacity), g: g}
+	default:
+		maxLength, _ := strconv.Atoi(f.typeParams[typeParamMaxLength]) // checked by parseScalarFields
+		return &genVarCharColumn{varCharColumn: newVarCharColumn(f.name, maxLength, capacity), g: g}
+	}
+}
+
+// genInt64Column fills an Int64 column from a generator
+type genInt64Column struct {
+	*int64Column
+	g *generator
+}
+
+func (c *genInt64Column) appendRandom() { c.append(c.g.integer()) }
+
+// column also counts the distinct values of the batch
+func (c *genInt64Column) column() entity.Column {
+	values := make([]string, len(c.data))
+	for i, v := range c.data {
+		values[i] = strconv.FormatInt(v, 10)
+	}
+	c.g.gen.distinct.add(values)
+	return c.int64Column.column()
+}
+
+// genFloatColumn fills a Float column from a generator
+type genFloatColumn struct {
+	*floatColumn
+	g *generator
+}
+
+func (c *genFloatColumn) appendRandom() {
+	if c.g.gen.kind == "enum" {
+		v, _ := strconv.ParseFloat(c.g.gen.enum[int(c.g.number())], 32) // checked by parseValueGen
+		c.append(float32(v))
+		return
+	}
+	c.append(float32(c.g.number()))
+}
+
+// column also counts the distinct values of the batch
+func (c *genFloatColumn) column() entity.Column {
+	if !c.g.gen.distinct.capped.Load() {
+		values := make([]string, len(c.data))
+		for i, v := range c.data {
+			values[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+		}
+		c.g.gen.distinct.add(values)
+	}
+	return c.floatColumn.column()
+}
+
+// genVarCharColumn fills a VarChar column from a generator
+type genVarCharColumn struct {
+	*varCharColumn
+	g *generator
+}
+
+func (c *genVarCharColumn) appendRandom() {
+	if c.g.gen.kind == "enum" {
+		c.append(c.g.gen.enum[int(c.g.number())])
+		return
+	}
+	value := "item-" + strconv.FormatInt(c.g.integer(), 10)
+	if len(value) > c.maxLength {
+		value = value[len(value)-c.maxLength:] // keep the digits
+	}
+	c.append(value)
+}
+
+// column also counts the distinct values of the batch
+func (c *genVarCharColumn) column() entity.Column {
+	c.g.gen.distinct.add(c.data)
+	return c.varCharColumn.column()
+}
+
+// filter returns a search filter on a generated field: the first enum value, the
+// lowest tenth of a uniform range or a normal distribution, the most frequent
+// Zipf value
+func (g *valueGen) filter(field scalarField) string {
+	quote := func(v string) string {
+		if field.dataType == entity.FieldTypeVarChar {
+			return strconv.Quote(v)
+		}
+		return v
+	}
+	switch {
+	case g.kind == "enum":
+		return fmt.Sprintf("%s == %s", field.name, quote(g.enum[0]))
+	case g.kind == "zipf" && field.dataType == entity.FieldTypeVarChar:
+		return fmt.Sprintf(`%s == "item-0"`, field.name)
+	case g.kind == "zipf":
+		return fmt.Sprintf("%s == 0", field.name)
+	case field.dataType == entity.FieldTypeVarChar:
+		return fmt.Sprintf(`%s like "item-1%%"`, field.name)
+	case g.kind == "uniform":
+		return fmt.Sprintf("%s < %g", field.name, g.a+(g.b-g.a)/10)
+	default:
+		return fmt.Sprintf("%s < %g", field.name, g.a-1.2816*g.b) // the 10th percentile
+	}
+}
+
+// scalarCardinality returns the distinct values of every generated field
+func (cfg *Config) scalarCardinality() []FieldCardinality {
+	var result []FieldCardinality
+	for _, f := range cfg.scalars {
+		if f.gen == nil {
+			continue
+		}
+		n, capped := f.gen.distinct.count()
+		result = append(result, FieldCardinality{Field: f.name, Generator: f.gen.spec, Distinct: n, Capped: capped})
+	}
+	return result
+}
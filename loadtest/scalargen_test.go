@@ -0,0 +1,123 @@
+package loadtest
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+func TestParseScalarGens(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string // substring of the error, "" for none
+	}{
+		{"no generators", "", ""},
+		{"int enum", "n=enum:1,2,3", ""},
+		{"float enum", "f=enum:0.5,1e3", ""},
+		{"varchar enum within max_length", "s=enum:ab,abcd", ""},
+		{"uniform", "n=uniform:0,10", ""},
+		{"uniform of one value", "f=uniform:2.5,2.5", ""},
+		{"normal", "f=normal:50,10", ""},
+		{"normal without spread", "n=normal:5,0", ""},
+		{"bare zipf", "n=zipf", ""},
+		{"zipf with args", "s=zipf:1.5,100", ""},
+		{"several fields", "n=zipf; f=uniform:0,1 ;s=enum:x", ""},
+		{"missing kind", "n=", "want field=kind[:args]"},
+		{"missing field", "=zipf", "want field=kind[:args]"},
+		{"no equals sign", "zipf", "want field=kind[:args]"},
+		{"undeclared field", "other=zipf", `field "other" is not declared`},
+		{"two generators", "n=zipf;n=uniform:0,1", "two generators"},
+		{"unknown kind", "n=poisson:3", `unknown generator "poisson"`},
+		{"empty enum", "n=enum", "at least one value"},
+		{"int enum not an int", "n=enum:1,x", `enum value "x"`},
+		{"int enum of a float", "n=enum:1.5", `enum value "1.5"`},
+		{"float enum not a number", "f=enum:abc", `enum value "abc"`},
+		{"varchar enum over max_length", "s=enum:abcde", "longer than max_length 4"},
+		{"uniform with one bound", "n=uniform:1", "wants 2 comma separated numbers"},
+		{"uniform bound not a number", "n=uniform:a,2", "uniform:"},
+		{"uniform low above high", "n=uniform:5,1", "low 5 is above high 1"},
+		{"normal negative stddev", "f=normal:0,-1", "stddev -1 must be >= 0"},
+		{"zipf exponent of 1", "n=zipf:1,100", "exponent > 1"},
+		{"zipf without values", "n=zipf:2,0", "exponent > 1"},
+		{"zipf fractional value count", "n=zipf:2,10.5", "exponent > 1"},
+		{"zipf with three args", "n=zipf:2,10,3", "wants 2 comma separated numbers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := parseScalarFields("n:int64,f:float,s:varchar:max_length=4")
+			if err != nil {
+				t.Fatalf("parseScalarFields() = %v", err)
+			}
+			err = parseScalarGens(tt.spec, fields)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("parseScalarGens(%q) = %v, want no error", tt.spec, err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("parseScalarGens(%q) = %v, want an error containing %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseValueGenArgs(t *testing.T) {
+	field := scalarField{name: "n", dataType: entity.FieldTypeInt64}
+	tests := []struct {
+		spec string
+		a, b float64
+	}{
+		{"uniform: 1 , 9", 1, 9},
+		{"normal:-3,0.5", -3, 0.5},
+		{"zipf", defaultZipfS, defaultZipfN},
+		{"zipf:3,7", 3, 7},
+	}
+	for _, tt := range tests {
+		gen, err := parseValueGen(tt.spec, field)
+		if err != nil {
+			t.Fatalf("parseValueGen(%q) = %v", tt.spec, err)
+		}
+		if gen.a != tt.a || gen.b != tt.b {
+			t.Errorf("parseValueGen(%q) args = %g,%g, want %g,%g", tt.spec, gen.a, gen.b, tt.a, tt.b)
+		}
+	}
+}
+
+func TestZipfGeneratorRange(t *testing.T) {
+	gen, err := parseValueGen("zipf:1.1,5", scalarField{name: "n", dataType: entity.FieldTypeInt64})
+	if err != nil {
+		t.Fatalf("parseValueGen() = %v", err)
+	}
+	g := newGenerator(gen)
+	for i := 0; i < 10000; i++ {
+		if v := g.integer(); v < 0 || v >= 5 {
+			t.Fatalf("zipf:1.1,5 drew %d, want 0..4", v)
+		}
+	}
+}
+
+func TestDistinctCap(t *testing.T) {
+	values := func(from, to int) []string {
+		var batch []string
+		for i := from; i < to; i++ {
+			batch = append(batch, strconv.Itoa(i))
+		}
+		return batch
+	}
+	d := &distinct{values: map[string]struct{}{}}
+	d.add(values(0, 10))
+	d.add(values(5, 15)) // half of them seen already
+	if n, capped := d.count(); n != 15 || capped {
+		t.Errorf("count() = %d, %t after 15 distinct values, want 15, false", n, capped)
+	}
+
+	d.add(values(0, maxTrackedDistinct+10))
+	if n, capped := d.count(); n != maxTrackedDistinct || !capped {
+		t.Errorf("count() = %d, %t past the cap, want %d, true", n, capped, maxTrackedDistinct)
+	}
+	d.add([]string{"new"})
+	if n, _ := d.count(); n != maxTrackedDistinct {
+		t.Errorf("count() = %d after the cap was hit, want it to stay %d", n, maxTrackedDistinct)
+	}
+}
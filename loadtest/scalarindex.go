@@ -0,0 +1,150 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// scalarIndexTypes maps the --scalar-index type names to their SDK index type and
+// the field types Milvus builds them on
+var scalarIndexTypes = map[string]struct {
+	indexType  entity.IndexType
+	fieldTypes []entity.FieldType
+}{
+	"INVERTED": {entity.Inverted, []entity.FieldType{entity.FieldTypeInt64, entity.FieldTypeFloat, entity.FieldTypeVarChar}},
+	"STL_SORT": {entity.Sorted, []entity.FieldType{entity.FieldTypeInt64, entity.FieldTypeFloat}},
+	"TRIE":     {entity.Trie, []entity.FieldType{entity.FieldTypeVarChar}},
+	"BITMAP":   {entity.Bitmap, []entity.FieldType{entity.FieldTypeInt64, entity.FieldTypeVarChar}},
+}
+
+// scalarIndex is an index built on a --scalar-fields field, declared with
+// --scalar-index as field:type
+type scalarIndex struct {
+	field     scalarField
+	indexType entity.IndexType
+}
+
+// ScalarIndexResult is the filtered search latency on one --scalar-index field
+// before and after its index was built
+type ScalarIndexResult struct {
+	Field        string        `json:"field"`
+	IndexType    string        `json:"index_type"`
+	Filter       string        `json:"filter"`
+	BuildTime    time.Duration `json:"build_time_ns"`
+	UnindexedP50 time.Duration `json:"unindexed_p50_ns"`
+	UnindexedP99 time.Duration `json:"unindexed_p99_ns"`
+	IndexedP50   time.Duration `json:"indexed_p50_ns"`
+	IndexedP99   time.Duration `json:"indexed_p99_ns"`
+}
+
+// parseScalarIndexes parses a comma separated list of field:type declarations,
+// each naming one of fields
+func parseScalarIndexes(spec string, fields []scalarField) ([]scalarIndex, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var indexes []scalarIndex
+	for _, decl := range strings.Split(spec, ",") {
+		name, typeName, ok := strings.Cut(strings.TrimSpace(decl), ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("index %q: want field:type", decl)
+		}
+		i := slices.IndexFunc(fields, func(f scalarField) bool { return f.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("index %q: field '%s' is not declared by --scalar-fields", decl, name)
+		}
+		if slices.ContainsFunc(indexes, func(idx scalarIndex) bool { return idx.field.name == name }) {
+			return nil, fmt.Errorf("index %q: field '%s' is already indexed", decl, name)
+		}
+		t, ok := scalarIndexTypes[strings.ToUpper(typeName)]
+		if !ok {
+			return nil, fmt.Errorf("index %q: unknown type %q (want INVERTED, STL_SORT, TRIE or BITMAP)", decl, typeName)
+		}
+		if !slices.Contains(t.fieldTypes, fields[i].dataType) {
+			return nil, fmt.Errorf("index %q: %s cannot index a %s field", decl, strings.ToUpper(typeName), fields[i].dataType.Name())
+		}
+		indexes = append(indexes, scalarIndex{field: fields[i], indexType: t.indexType})
+	}
+	return indexes, nil
+}
+
+// filter returns a search filter on the field matching about a tenth of the
+// random values scalar.go generates. Varchar values keep their "item-" prefix
+// only when max_length leaves room for it. A --scalar-gen field is filtered
+// on its own distribution instead.
+func (idx scalarIndex) filter() string {
+	if idx.field.gen != nil {
+		return idx.field.gen.filter(idx.field)
+	}
+	switch idx.field.dataType {
+	case entity.FieldTypeInt64:
+		return fmt.Sprintf("%s < %d", idx.field.name, scalarIntRange/10)
+	case entity.FieldTypeFloat:
+		return fmt.Sprintf("%s < 10", idx.field.name)
+	default:
+		return fmt.Sprintf(`%s like "item-1%%"`, idx.field.name)
+	}
+}
+
+// scalarIndexCompare times a filtered search batch on every --scalar-index field,
+// builds the scalar indexes on the first collection and times the same filters
+// again, to show what the indexes do for filtered search (step 7i)
+func (lt *loadTest) scalarIndexCompare(ctx context.Context) error {
+	cfg := lt.cfg
+	if len(cfg.scalarIndexes) == 0 {
+		return nil
+	}
+	name := lt.collections[0]
+	fmt.Printf("\n--- Step 7i: Filtered search without vs with scalar indexes (%d queries each) ---\n", cfg.CompareQueries)
+	results := make([]ScalarIndexResult, len(cfg.scalarIndexes))
+	for i, idx := range cfg.scalarIndexes {
+		before, err := timeFilteredBatch(ctx, lt.client, name, idx.filter(), cfg.metricType, cfg.CompareQueries)
+		if err != nil {
+			return fmt.Errorf("failed to search with filter on '%s': %w", idx.field.name, err)
+		}
+		results[i] = ScalarIndexResult{
+			Field:        idx.field.name,
+			IndexType:    string(idx.indexType),
+			Filter:       idx.filter(),
+			UnindexedP50: before.p50,
+			UnindexedP99: before.p99,
+		}
+	}
+
+	if err := lt.client.ReleaseCollection(ctx, name); err != nil {
+		return fmt.Errorf("failed to release collection: %w", err)
+	}
+	lt.phaseStart("scalar-index")
+	start := time.Now()
+	for i, idx := range cfg.scalarIndexes {
+		buildStart := time.Now()
+		if err := lt.client.CreateIndex(ctx, name, idx.field.name, entity.NewScalarIndexWithType(idx.indexType), false); err != nil {
+			return fmt.Errorf("failed to create %s index on '%s': %w", idx.indexType, idx.field.name, err)
+		}
+		results[i].BuildTime = time.Since(buildStart)
+		fmt.Printf("Built %s index on '%s' in %s\n", idx.indexType, idx.field.name, results[i].BuildTime.Round(time.Millisecond))
+	}
+	lt.phaseEnd("scalar-index", time.Since(start))
+	if err := lt.client.LoadCollection(ctx, name, false, lt.loadOptions()...); err != nil {
+		return fmt.Errorf("failed to reload collection: %w", err)
+	}
+
+	fmt.Printf("   %-16s %-9s %12s %12s %12s %12s\n", "Field", "Index", "Built in", "p50 before", "p50 after", "p99 after")
+	for i, idx := range cfg.scalarIndexes {
+		after, err := timeFilteredBatch(ctx, lt.client, name, idx.filter(), cfg.metricType, cfg.CompareQueries)
+		if err != nil {
+			return fmt.Errorf("failed to search with filter on '%s': %w", idx.field.name, err)
+		}
+		r := &results[i]
+		r.IndexedP50, r.IndexedP99 = after.p50, after.p99
+		fmt.Printf("   %-16s %-9s %12s %12s %12s %12s\n", r.Field, r.IndexType, r.BuildTime.Round(time.Millisecond), r.UnindexedP50, r.IndexedP50, r.IndexedP99)
+	}
+	lt.m.scalarIndexes = results
+	fmt.Println("✅ Scalar index comparison complete.")
+	return nil
+}
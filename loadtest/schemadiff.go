@@ -0,0 +1,57 @@
+package loadtest
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// schemaDiff compares the schema of an existing collection with the one the
+// configuration would create and returns one line per incompatible field. Fields
+// the collection has beyond the configured ones and differing max_length only
+// matter to runs that insert into it. AutoID is not compared: the existing
+// collection decides how keys are assigned.
+func schemaDiff(want, have *entity.Schema, inserting bool) []string {
+	var diff []string
+	existing := make(map[string]*entity.Field, len(have.Fields))
+	for _, field := range have.Fields {
+		existing[field.Name] = field
+	}
+	for _, field := range want.Fields {
+		got, ok := existing[field.Name]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("- field '%s': missing, configured as %s", field.Name, fieldTypeName(field)))
+			continue
+		}
+		if got.DataType != field.DataType {
+			diff = append(diff, fmt.Sprintf("~ field '%s': type %s, configured %s", field.Name, fieldTypeName(got), fieldTypeName(field)))
+			continue
+		}
+		if got.PrimaryKey != field.PrimaryKey {
+			diff = append(diff, fmt.Sprintf("~ field '%s': primary key %t, configured %t", field.Name, got.PrimaryKey, field.PrimaryKey))
+		}
+		if want, got := field.TypeParams[entity.TypeParamDim], got.TypeParams[entity.TypeParamDim]; want != got {
+			diff = append(diff, fmt.Sprintf("~ field '%s': dim %s, configured %s", field.Name, got, want))
+		}
+		if want, got := field.TypeParams[entity.TypeParamMaxLength], got.TypeParams[entity.TypeParamMaxLength]; inserting && want != "" && want != got {
+			diff = append(diff, fmt.Sprintf("~ field '%s': max_length %s, configured %s", field.Name, got, want))
+		}
+	}
+	if inserting {
+		for _, field := range have.Fields {
+			if !slices.ContainsFunc(want.Fields, func(f *entity.Field) bool { return f.Name == field.Name }) {
+				diff = append(diff, fmt.Sprintf("+ field '%s': %s in the collection, not configured", field.Name, fieldTypeName(field)))
+			}
+		}
+	}
+	return diff
+}
+
+// fieldTypeName names the type of a field, with the dimension of vectors
+func fieldTypeName(field *entity.Field) string {
+	if dim, ok := field.TypeParams[entity.TypeParamDim]; ok {
+		return fmt.Sprintf("%s(%s)", field.DataType.Name(), dim)
+	}
+	return field.DataType.Name()
+}
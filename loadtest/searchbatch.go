@@ -0,0 +1,42 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// randomQueryVector returns a single random query vector
+func randomQueryVector() []entity.Vector {
+	queryVectorData := make([]float32, embeddingDim)
+	for j := range queryVectorData {
+		queryVectorData[j] = rand.Float32()
+	}
+	return []entity.Vector{entity.FloatVector(queryVectorData)}
+}
+
+// timeSearchBatch runs n sequential searches with random query vectors and returns
+// their latency distribution. Running them one at a time keeps the numbers free of
+// client-side queuing so batches taken at different points of a run are comparable.
+func timeSearchBatch(ctx context.Context, milvusClient client.Client, collection string, metric entity.MetricType, n int) (latencySummary, error) {
+	return timeFilteredBatch(ctx, milvusClient, collection, "", metric, n)
+}
+
+// timeFilteredBatch is timeSearchBatch with the boolean filter expr, "" for none
+func timeFilteredBatch(ctx context.Context, milvusClient client.Client, collection, expr string, metric entity.MetricType, n int) (latencySummary, error) {
+	searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+	latencies := make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		queryVector := randomQueryVector()
+		start := time.Now()
+		if _, err := milvusClient.Search(ctx, collection, []string{}, expr, []string{}, queryVector, embeddingField, metric, searchTopK, searchParams); err != nil {
+			return latencySummary{}, fmt.Errorf("search %d failed: %w", i, err)
+		}
+		latencies = append(latencies, time.Since(start))
+	}
+	return summarizeLatencies(latencies), nil
+}
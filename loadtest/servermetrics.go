@@ -0,0 +1,179 @@
+package loadtest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultMetricsPort is the port Milvus serves its Prometheus metrics on
+	defaultMetricsPort = "9091"
+
+	// scrapeTimeout bounds a single metrics request so an unreachable endpoint can't stall the run
+	scrapeTimeout = 5 * time.Second
+
+	// Prometheus metric names used to estimate memory usage
+	queryNodeEntitySizeMetric = "milvus_querynode_entity_size"
+	residentMemoryMetric      = "process_resident_memory_bytes"
+)
+
+// promSample is a single sample of the Prometheus text exposition format
+type promSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// metricsURL returns the metrics endpoint to scrape: override when set, otherwise
+// the default metrics port on the Milvus host
+func metricsURL(milvusAddr, override string) string {
+	if override != "" {
+		return override
+	}
+	host := milvusAddr
+	if h, _, err := net.SplitHostPort(milvusAddr); err == nil {
+		host = h
+	}
+	return "http://" + net.JoinHostPort(host, defaultMetricsPort) + "/metrics"
+}
+
+// scrapeMetrics fetches and parses the Prometheus metrics served at url
+func scrapeMetrics(ctx context.Context, url string) ([]promSample, error) {
+	ctx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+	return parsePromText(resp.Body)
+}
+
+// parsePromText parses the Prometheus text exposition format, skipping comments
+// and lines it doesn't understand
+func parsePromText(r io.Reader) ([]promSample, error) {
+	var samples []promSample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if sample, ok := parsePromLine(line); ok {
+			samples = append(samples, sample)
+		}
+	}
+	return samples, scanner.Err()
+}
+
+// parsePromLine parses `name{label="value",...} value [timestamp]`
+func parsePromLine(line string) (promSample, bool) {
+	sample := promSample{labels: map[string]string{}}
+	i := strings.IndexAny(line, "{ ")
+	if i < 0 {
+		return sample, false
+	}
+	sample.name = line[:i]
+	rest := line[i:]
+
+	if strings.HasPrefix(rest, "{") {
+		end := -1
+		inQuotes := false
+		for i := 1; i < len(rest); i++ {
+			switch {
+			case rest[i] == '\\' && inQuotes:
+				i++
+			case rest[i] == '"':
+				inQuotes = !inQuotes
+			case rest[i] == '}' && !inQuotes:
+				end = i
+			}
+			if end >= 0 {
+				break
+			}
+		}
+		if end < 0 {
+			return sample, false
+		}
+		for _, pair := range splitLabels(rest[1:end]) {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				continue
+			}
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+			sample.labels[strings.TrimSpace(key)] = value
+		}
+		rest = rest[end+1:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return sample, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, false
+	}
+	sample.value = value
+	return sample, true
+}
+
+// splitLabels splits a label list on the commas that are not inside quoted values
+func splitLabels(s string) []string {
+	var parts []string
+	start, inQuotes := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && inQuotes:
+			i++
+		case s[i] == '"':
+			inQuotes = !inQuotes
+		case s[i] == ',' && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
+
+// sumMetric adds up every sample of name whose labels include all of match
+func sumMetric(samples []promSample, name string, match map[string]string) (float64, bool) {
+	total, found := 0.0, false
+	for _, s := range samples {
+		if s.name != name {
+			continue
+		}
+		matches := true
+		for k, v := range match {
+			if s.labels[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			total += s.value
+			found = true
+		}
+	}
+	return total, found
+}
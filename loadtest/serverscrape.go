@@ -0,0 +1,178 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Prometheus metrics sampled by --server-metrics-interval, besides residentMemoryMetric
+const (
+	processCPUMetric       = "process_cpu_seconds_total"
+	readTaskUnsolvedMetric = "milvus_querynode_read_task_unsolved_len"
+	readTaskReadyMetric    = "milvus_querynode_read_task_ready_len"
+	compactionTaskMetric   = "milvus_datacoord_compaction_task_num"
+)
+
+// ServerSample is one scrape of the Milvus metrics endpoint during the run, next
+// to the client throughput of the phase running at the time
+type ServerSample struct {
+	Time              time.Time     `json:"time"`
+	Elapsed           time.Duration `json:"elapsed_ns"` // since the start of the run
+	Phase             string        `json:"phase"`      // "" between phases
+	ClientOpsPerSec   float64       `json:"client_ops_per_sec"`
+	CPUCores          float64       `json:"cpu_cores"` // process CPU seconds per second since the previous scrape
+	ResidentBytes     float64       `json:"resident_memory_bytes"`
+	QueryQueueDepth   float64       `json:"query_queue_depth"`  // query node read tasks waiting and ready
+	CompactionBacklog float64       `json:"compaction_backlog"` // compaction tasks pending and executing
+}
+
+// serverMetricsEvent reports a ServerSample on the --event-stream
+type serverMetricsEvent struct {
+	eventHeader
+	ElapsedSeconds      float64 `json:"elapsed_seconds"`
+	ClientOpsPerSecond  float64 `json:"client_ops_per_second"`
+	CPUCores            float64 `json:"cpu_cores"`
+	ResidentMemoryBytes float64 `json:"resident_memory_bytes"`
+	QueryQueueDepth     float64 `json:"query_queue_depth"`
+	CompactionBacklog   float64 `json:"compaction_backlog"`
+}
+
+// serverScraper scrapes the Milvus metrics endpoint every --server-metrics-interval
+// on its own goroutine. The samples are only read once stop has returned.
+type serverScraper struct {
+	lt       *loadTest
+	url      string
+	cancel   context.CancelFunc
+	done     chan struct{}
+	failures int      // scrapes that failed, owned by run
+	seen     []string // metrics found in at least one scrape, owned by run
+}
+
+// startServerScraper starts scraping, nil without --server-metrics-interval
+func (lt *loadTest) startServerScraper(ctx context.Context) *serverScraper {
+	if lt.cfg.ServerMetrics == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &serverScraper{
+		lt:     lt,
+		url:    metricsURL(lt.cfg.addrs[lt.addrIndex], lt.cfg.MetricsURL),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go s.run(ctx)
+	fmt.Printf("📡 Scraping server metrics from %s every %s\n", s.url, lt.cfg.ServerMetrics)
+	return s
+}
+
+// run scrapes once per interval until ctx is done
+func (s *serverScraper) run(ctx context.Context) {
+	defer close(s.done)
+	ticker := time.NewTicker(s.lt.cfg.ServerMetrics)
+	defer ticker.Stop()
+	var prev ServerSample
+	var prevCPU, prevOps float64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		samples, err := scrapeMetrics(ctx, s.url)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.failures++
+			}
+			continue
+		}
+		now := time.Now()
+		sample := ServerSample{Time: now, Elapsed: now.Sub(s.lt.start), Phase: s.lt.currentPhase()}
+		sample.ResidentBytes = s.value(samples, residentMemoryMetric, nil)
+		sample.QueryQueueDepth = s.value(samples, readTaskUnsolvedMetric, nil) + s.value(samples, readTaskReadyMetric, nil)
+		sample.CompactionBacklog = s.value(samples, compactionTaskMetric, map[string]string{"status": "pending"}) +
+			s.value(samples, compactionTaskMetric, map[string]string{"status": "executing"})
+		cpu := s.value(samples, processCPUMetric, nil)
+		ops := float64(s.lt.clientOps.Load())
+		if !prev.Time.IsZero() {
+			dt := now.Sub(prev.Time).Seconds()
+			sample.CPUCores = max(cpu-prevCPU, 0) / dt
+			if sample.Phase == prev.Phase && ops >= prevOps {
+				sample.ClientOpsPerSec = (ops - prevOps) / dt
+			} else if sample.Phase != "" {
+				sample.ClientOpsPerSec = ops / dt // the phase started since the previous scrape
+			}
+		}
+		prev, prevCPU, prevOps = sample, cpu, ops
+		s.lt.m.serverSamples = append(s.lt.m.serverSamples, sample)
+		s.lt.events.emit(serverMetricsEvent{
+			eventHeader:         eventHeader{Type: eventServerMetrics, Time: now, Phase: sample.Phase},
+			ElapsedSeconds:      sample.Elapsed.Seconds(),
+			ClientOpsPerSecond:  sample.ClientOpsPerSec,
+			CPUCores:            sample.CPUCores,
+			ResidentMemoryBytes: sample.ResidentBytes,
+			QueryQueueDepth:     sample.QueryQueueDepth,
+			CompactionBacklog:   sample.CompactionBacklog,
+		})
+	}
+}
+
+// value sums the samples of name matching match and remembers that the endpoint
+// exposes it
+func (s *serverScraper) value(samples []promSample, name string, match map[string]string) float64 {
+	v, ok := sumMetric(samples, name, match)
+	if ok && !slices.Contains(s.seen, name) {
+		s.seen = append(s.seen, name)
+	}
+	return v
+}
+
+// stop ends the scraping and reports failed scrapes and metrics the endpoint
+// never exposed, which read as 0 in every sample
+func (s *serverScraper) stop() {
+	if s == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	if s.failures > 0 {
+		fmt.Printf("⚠️  %d server metrics scrapes of %s failed\n", s.failures, s.url)
+	}
+	if len(s.lt.m.serverSamples) == 0 {
+		return
+	}
+	var missing []string
+	for _, name := range []string{processCPUMetric, residentMemoryMetric, readTaskUnsolvedMetric, compactionTaskMetric} {
+		if !slices.Contains(s.seen, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Printf("⚠️  %s does not expose %s, reported as 0\n", s.url, strings.Join(missing, ", "))
+	}
+}
+
+// currentPhase returns the phase running now, "" between phases
+func (lt *loadTest) currentPhase() string {
+	lt.timelineMu.Lock()
+	defer lt.timelineMu.Unlock()
+	for i := len(lt.m.timeline) - 1; i >= 0; i-- {
+		if lt.m.timeline[i].End.IsZero() {
+			return lt.m.timeline[i].Phase
+		}
+	}
+	return ""
+}
+
+// peakServerSample returns the sample with the highest value of field
+func (m *metrics) peakServerSample(field func(ServerSample) float64) ServerSample {
+	var peak ServerSample
+	for i, sample := range m.serverSamples {
+		if i == 0 || field(sample) > field(peak) {
+			peak = sample
+		}
+	}
+	return peak
+}
@@ -0,0 +1,677 @@
+package loadtest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// printSummary prints the final summary table. Rows of steps the command did not run are omitted.
+func (lt *loadTest) printSummary(totalDuration time.Duration) {
+	cfg, m := lt.cfg, &lt.m
+	totalDataMB := float64(embeddingBytes(m.totalVectorsInserted)) / (1024 * 1024)
+	divider := "├" + strings.Repeat("─", 27) + "┼" + strings.Repeat("─", 52) + "┤"
+
+	fmt.Println("\n" + strings.Repeat("=", 80))
+	fmt.Println("                        LOAD TEST PERFORMANCE SUMMARY")
+	if lt.budgetExceeded {
+		fmt.Printf("          ⏱️  TIME BUDGET EXCEEDED (--max-runtime %s): partial results\n", cfg.MaxRuntime)
+	}
+	fmt.Println(strings.Repeat("=", 80))
+
+	// Configuration section
+	fmt.Printf("│ %-25s │ %-50s │\n", "Configuration", "Value")
+	fmt.Println(divider)
+	fmt.Printf("│ %-25s │ %-50s │\n", "Test Duration", cfg.Duration)
+	fmt.Printf("│ %-25s │ %-50s │\n", "Pressure Level", cfg.pressureLevel)
+	fmt.Printf("│ %-25s │ %-50s │\n", "Milvus Address", cfg.MilvusAddr)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Concurrent Workers", cfg.numWorkers)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Batch Size", cfg.batchSize)
+	if m.insertBatches > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Effective Batch Size", fmt.Sprintf("avg %.0f, final %d, range %d-%d", m.avgBatchSize(), m.finalBatchSize, m.minBatchSize, m.maxBatchSize))
+	}
+	fmt.Printf("│ %-25s │ %-50d │\n", "Vectors Inserted", m.totalVectorsInserted)
+	if m.insertBatchRows != m.totalVectorsInserted {
+		fmt.Printf("│ %-25s │ %-50d │\n", "Vectors Submitted", m.insertBatchRows)
+	}
+	if pool := lt.vectorPool; pool != nil {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Vector Duplication", fmt.Sprintf("%.1f%% of rows, %d of %d distinct vectors used",
+			100*pool.duplicationRatio(), pool.distinctDrawn(), len(pool.vectors)))
+	}
+	if lt.resumed != nil {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Resumed From", fmt.Sprintf("%d rows (%s)", lt.resumed.RowsInserted, cfg.Checkpoint))
+	}
+	fmt.Printf("│ %-25s │ %-50.2f MB │\n", "Data Size Inserted", totalDataMB)
+	if cfg.MaxDataMB > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Data Volume Reached", fmt.Sprintf("%.2f MB of %g MB target", float64(lt.volumeInserted())/(1024*1024), cfg.MaxDataMB))
+	}
+	if m.scalarBytesInserted > 0 {
+		fmt.Printf("│ %-25s │ %-47.2f MB │\n", "Scalar Data Inserted", float64(m.scalarBytesInserted)/(1024*1024))
+	}
+	fmt.Printf("│ %-25s │ %-50d │\n", "Searches Performed", m.totalSearchesPerformed)
+	fmt.Printf("│ %-25s │ %-50t │\n", "AutoID", cfg.AutoID)
+	if !cfg.AutoID {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Primary Key Strategy", cfg.PKStrategy)
+	}
+	if lt.ids != nil && lt.ids.total() > 0 {
+		label := "Primary Keys Tracked"
+		if cfg.AutoID {
+			label = "Primary Keys Captured"
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", label, fmt.Sprintf("%d (pool holds %d)", lt.ids.total(), lt.ids.len()))
+	}
+	fmt.Printf("│ %-25s │ %-50d │\n", "Max Retries", cfg.retry.maxRetries)
+	fmt.Printf("│ %-25s │ %-50s │\n", "Client GOMAXPROCS", gomaxprocsDescription())
+	fmt.Printf("│ %-25s │ %-50s │\n", "Consistency Level", cfg.Consistency)
+	if cfg.Shards > 0 {
+		fmt.Printf("│ %-25s │ %-50d │\n", "Shards per Collection", cfg.Shards)
+	}
+	if cfg.CollectionProps != "" {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Collection Properties", cfg.CollectionProps)
+	}
+	if cfg.TTLSeconds > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Collection TTL", (time.Duration(cfg.TTLSeconds) * time.Second).String())
+	}
+
+	fmt.Println(divider)
+
+	// Performance metrics section
+	fmt.Printf("│ %-25s │ %-50s │\n", "Performance Metrics", "Value")
+	fmt.Println(divider)
+	fmt.Printf("│ %-25s │ %-50s │\n", "Total Elapsed Time", totalDuration.String())
+	fmt.Printf("│ %-25s │ %-50s │\n", "Connection Time", m.connectionTime.String())
+	if m.ddlRetries > 0 || m.ddlRaces > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "DDL Races", fmt.Sprintf("%d retried calls, %d creates/drops done by another client", m.ddlRetries, m.ddlRaces))
+	}
+	if m.insertionTime > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Data Insertion Time", m.insertionTime.String())
+		fmt.Printf("│ %-25s │ %-50.2f │\n", "Insert Throughput", m.insertsPerSec)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Insert Stability", m.insertSeries.stability())
+		if m.insertBatchRows != m.totalVectorsInserted {
+			fmt.Printf("│ %-25s │ %-50.2f │\n", "Submitted Throughput", m.submittedPerSec())
+		}
+		if took := m.insertTime(); took.generation+took.rpc > 0 {
+			verdict := "Milvus-bound"
+			if took.share() > generationBoundShare {
+				verdict = "client-bound"
+			}
+			fmt.Printf("│ %-25s │ %-50s │\n", "Generation / RPC Time", fmt.Sprintf("%.1f%% / %.1f%% of worker time (%s)", 100*took.share(), 100*(1-took.share()), verdict))
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Flush Time", m.flushTime.String())
+		if len(lt.collections) > 1 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Flush Requests", fmt.Sprintf("%d in %s (%s)", m.flushRequests, m.flushRequestTime, cfg.FlushStrategy))
+		}
+		if cfg.FlushEvery > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Periodic Flushes", fmt.Sprintf("%d every %d batches, avg %s (%d coalesced)", m.periodicFlushes,
+				cfg.FlushEvery, m.avgPeriodicFlushTime(), m.periodicFlushCoalesced))
+		}
+		fmt.Printf("│ %-25s │ %-50d │\n", "Segments Sealed", m.segmentsSealed)
+		if m.flushedSegments > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Segments After Flush", fmt.Sprintf("%d (avg %d rows)", m.flushedSegments, m.flushedSegmentRows/int64(m.flushedSegments)))
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Index Creation Time", m.indexTime.String())
+	}
+	if m.searchTime > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Collection Load Time", m.loadTime.String())
+		for _, p := range m.partialLoads {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Partially Loaded", fmt.Sprintf("%s: %d%%", p.Collection, p.LoadedPercent))
+		}
+		switch {
+		case m.sentinelMissed:
+			fmt.Printf("│ %-25s │ %-50s │\n", "Time To Searchable", "not returned within --load-timeout "+cfg.LoadTimeout.String())
+		case m.timeToSearchable > 0:
+			fmt.Printf("│ %-25s │ %-50s │\n", "Time To Searchable", fmt.Sprintf("%s after insert (%s after load)",
+				m.timeToSearchable.Round(time.Millisecond), m.searchableAfterLoad.Round(time.Millisecond)))
+		}
+		if !slices.Equal(cfg.loadOrder, defaultLoadOrder) {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Load Order", strings.Join(cfg.loadOrder, " -> "))
+			if m.handoffTime > 0 {
+				fmt.Printf("│ %-25s │ %-50s │\n", "Index Handoff Time", m.handoffTime.String())
+			}
+		}
+		if m.reloadLatency.count > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("Reloads (%d) Min/Med/Max", m.reloadLatency.count),
+				fmt.Sprintf("%s / %s / %s", m.reloadLatency.min, m.reloadLatency.p50, m.reloadLatency.max))
+		}
+		if m.replicaCount > 0 {
+			fmt.Printf("│ %-25s │ %-50d │\n", "Loaded Replicas", m.replicaCount)
+		}
+		if cfg.Connections > 1 {
+			fmt.Printf("│ %-25s │ %-50d │\n", "Search Connections", cfg.Connections)
+		}
+		if m.preSearchWait > 0 {
+			settled := "not settled"
+			if m.preSearchSettled {
+				settled = "settled"
+			} else if !cfg.PreSearchSettle {
+				settled = "fixed delay"
+			}
+			fmt.Printf("│ %-25s │ %-50s │\n", "Pre-Search Delay", fmt.Sprintf("waited %s (%s)", m.preSearchWait.Round(time.Millisecond), settled))
+		}
+		if m.warmupTime > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Search Warm-up", fmt.Sprintf("%d queries in %s (%d failed, not counted)", cfg.SearchWarmup, m.warmupTime, m.warmupFailures))
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Execution Time", m.searchTime.String())
+		fmt.Printf("│ %-25s │ %-50.2f │\n", "Search Throughput", m.searchesPerSec)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Stability", m.searchSeries.stability())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency Avg", m.searchLatency.avg.String())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p50", m.searchLatency.p50.String())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p99", m.searchLatency.p99.String())
+		if cfg.ValidateResults {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Empty Results", fmt.Sprintf("%d of %d (%.2f%%)", m.emptyResults, m.validatedSearches, m.emptyResultRate()))
+			fmt.Printf("│ %-25s │ %-50d │\n", "Short Results", m.shortResults)
+		}
+		if m.recallQueries > 0 {
+			fmt.Printf("│ %-25s │ %-50.4f │\n", fmt.Sprintf("Recall@%d", searchTopK), m.recall())
+		}
+		if m.exactRecallQueries > 0 {
+			fmt.Printf("│ %-25s │ %-50.4f │\n", fmt.Sprintf("Exact %s Recall@%d", cfg.metricType, searchTopK), m.exactRecall)
+		}
+		if m.flatRecall > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("IVF_FLAT Recall@%d", searchTopK),
+				fmt.Sprintf("%.4f (%s costs %.4f)", m.flatRecall, cfg.indexType, m.flatRecall-m.exactRecall))
+		}
+		if cfg.indexType != entity.IvfFlat && len(cfg.indexMix) == 0 && m.totalVectorsInserted > 0 {
+			quantized := float64(indexVectorBytes(cfg.indexType, m.totalVectorsInserted))
+			flat := float64(indexVectorBytes(entity.IvfFlat, m.totalVectorsInserted))
+			fmt.Printf("│ %-25s │ %-50s │\n", "Index Vector Data (est.)",
+				fmt.Sprintf("%.2f MB vs %.2f MB IVF_FLAT (%.0f%% less)", quantized/(1024*1024), flat/(1024*1024), 100*(1-quantized/flat)))
+		}
+		if m.collectionMemoryKnown {
+			fmt.Printf("│ %-25s │ %-47.2f MB │\n", "Collection Memory", m.collectionMemoryBytes/(1024*1024))
+		}
+		if m.loadRSSDeltaKnown {
+			fmt.Printf("│ %-25s │ %-47.2f MB │\n", "Server RSS Growth on Load", m.loadRSSDeltaBytes/(1024*1024))
+		}
+	}
+	if m.cleanupAction != "" {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Cleanup Action", m.cleanupAction)
+	}
+	if m.cleanupTime > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Cleanup Time", m.cleanupTime.String())
+	}
+	if cfg.FlushCompare && m.postFlushLatency.count > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Avg (Pre-Flush)", m.preFlushLatency.avg.String())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Avg (Post-Flush)", m.postFlushLatency.avg.String())
+	}
+	if cfg.ProjectionBench && m.projectionIDsOnly.count > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Avg (IDs only)", m.projectionIDsOnly.avg.String())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Avg (All Fields)", m.projectionWithFields.avg.String())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Field Materialization", (m.projectionWithFields.avg - m.projectionIDsOnly.avg).String())
+	}
+
+	fmt.Println(divider)
+
+	// Reliability section
+	fmt.Printf("│ %-25s │ %-50s │\n", "Reliability", "Value")
+	fmt.Println(divider)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Insert Retries", m.insertRetries)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Inserts Recovered", m.recoveredInserts)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Inserts Failed", m.failedInserts)
+	if m.partialInserts > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Partial Inserts", fmt.Sprintf("%d batches, %d rows rejected", m.partialInserts, m.rowsRejected))
+	}
+	for _, f := range m.faults {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Injected "+f.Kind, fmt.Sprintf("%d sent, %d rejected, %d accepted: %s",
+			f.Injected, f.Rejected, f.Accepted, passFail(f.Accepted == 0)))
+	}
+	fmt.Printf("│ %-25s │ %-50d │\n", "Search Retries", m.searchRetries)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Searches Recovered", m.recoveredSearches)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Searches Failed", m.failedSearches)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Throttled Inserts", m.throttledInserts)
+	fmt.Printf("│ %-25s │ %-50d │\n", "Throttled Searches", m.throttledSearches)
+	if m.throttledInserts > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "First Insert Throttle", fmt.Sprintf("after %s at batch size %d", m.firstInsertThrottle.Round(time.Millisecond), m.firstThrottleBatchSize))
+	}
+	if m.throttledSearches > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "First Search Throttle", fmt.Sprintf("after %s", m.firstSearchThrottle.Round(time.Millisecond)))
+	}
+	if cfg.ThrottleBackoff > 0 {
+		fmt.Printf("│ %-25s │ %-50s │\n", "Throttle Pause (workers)", m.throttlePauseTime.String())
+	}
+
+	// Burst analysis section
+	if cfg.Burst && m.searchTime > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Burst Analysis", "Baseline / Burst")
+		fmt.Println(divider)
+		base, peak := &m.burstBaseline, &m.burstPeak
+		fmt.Printf("│ %-25s │ %-50s │\n", "Target QPS", fmt.Sprintf("%g / %g", cfg.TargetQPS, cfg.BurstQPS))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Achieved QPS", fmt.Sprintf("%.2f / %.2f", base.qps(), peak.qps()))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p50", fmt.Sprintf("%s / %s", base.latency.p50, peak.latency.p50))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p99", fmt.Sprintf("%s / %s", base.latency.p99, peak.latency.p99))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Error Rate", fmt.Sprintf("%.2f%% / %.2f%%", base.errorRate(), peak.errorRate()))
+	}
+
+	// Cool-down trajectory section
+	if steps := m.coolDownTrajectory(cfg); len(steps) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Cool-Down (workers)", "QPS / p50 / p99 / Error Rate")
+		fmt.Println(divider)
+		for _, s := range steps {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("+%s (%d)", s.Start.Round(time.Millisecond), s.Workers),
+				fmt.Sprintf("%.2f / %s / %s / %.2f%%", s.QPS, s.LatencyP50, s.LatencyP99, 100*float64(s.Failures)/float64(max(1, s.Searches))))
+		}
+	}
+
+	// Time window section
+	if cfg.TimestampField != "" && m.timeWindowTotal > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Time Window Filter", "Value")
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Rows in Last "+cfg.TimeWindow.String(), fmt.Sprintf("%d of %d", m.timeWindowRows, m.timeWindowTotal))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Range Query Time", m.timeWindowQueryTime.String())
+	}
+
+	// Insert latency by batch size section, when the batch size varied
+	if buckets := m.batchSizeBuckets(); len(buckets) > 1 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Insert by Batch Size", "Batches / p50 / p99 / Rows/sec per Call")
+		fmt.Println(divider)
+		for _, b := range buckets {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("%d-%d rows", b.MinRows, b.MaxRows),
+				fmt.Sprintf("%d / %s / %s / %.0f", b.Batches, b.LatencyP50, b.LatencyP99, b.RowsPerSec))
+		}
+	}
+
+	// Insert contention section
+	if len(m.hotSegment) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Insert Contention", "Vectors/sec / batch p50 / p99 / failed batches")
+		fmt.Println(divider)
+		for _, r := range m.hotSegment {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("%s (%dx%d segments)", r.Layout, r.Shards, max(r.Partitions, 1)),
+				fmt.Sprintf("%.2f / %s / %s / %d", r.VectorsPerSec, r.LatencyP50, r.LatencyP99, r.FailedBatches))
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Spread vs One Segment", fmt.Sprintf("%.2fx", m.hotSegmentSpeedup()))
+	}
+
+	// Batch size sweep section
+	if len(m.batchSweep) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Batch Size Sweep", "Vectors/sec / batch p50 / p99 / failed batches")
+		fmt.Println(divider)
+		for _, r := range m.batchSweep {
+			label := fmt.Sprintf("%d rows", r.BatchSize)
+			if r.Best {
+				label += " (best)"
+			}
+			fmt.Printf("│ %-25s │ %-50s │\n", label, fmt.Sprintf("%.2f / %s / %s / %d", r.VectorsPerSec, r.LatencyP50, r.LatencyP99, r.FailedBatches))
+		}
+	}
+
+	// Latency percentiles section
+	insertPercentiles := m.insertPercentiles(cfg.percentiles)
+	if len(insertPercentiles) > 0 || len(m.searchPercentiles) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Latency Percentiles", "Insert / Search")
+		fmt.Println(divider)
+		for i, p := range cfg.percentiles {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("p%g", p),
+				fmt.Sprintf("%s / %s", percentileCell(insertPercentiles, i), percentileCell(m.searchPercentiles, i)))
+		}
+	}
+
+	// Mixed workload section
+	if len(cfg.mix) > 0 && m.mixTime > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Mixed Workload", "Ops/sec / p50 / p99 / Failures")
+		fmt.Println(divider)
+		for _, op := range cfg.mix {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("%s (weight %d)", op.name, op.weight),
+				fmt.Sprintf("%.2f / %s / %s / %d", op.rate(m.mixTime), op.latency.p50, op.latency.p99, op.failures))
+		}
+	}
+
+	// DDL stress section
+	if m.ddlTime > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "DDL Stress", "Ops/sec / p50 / p99 / Failures")
+		fmt.Println(divider)
+		for _, op := range []struct {
+			name string
+			s    Snapshot
+		}{{"Create Collection", m.ddlCreates}, {"Drop Collection", m.ddlDrops}} {
+			fmt.Printf("│ %-25s │ %-50s │\n", op.name, fmt.Sprintf("%.2f / %s / %s / %d", op.s.Throughput, op.s.Latency.P50, op.s.Latency.P99, op.s.Failures))
+		}
+		fmt.Printf("│ %-25s │ %-50d │\n", "Leftovers Dropped", m.ddlLeftovers)
+	}
+
+	// Freshness section
+	if cfg.FreshnessProbes > 0 && (m.freshnessLag.count > 0 || m.freshnessMissed > 0) {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Freshness Lag", "Insert Acknowledged to Searchable")
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Lag p50", m.freshnessLag.p50.String())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Lag p99", m.freshnessLag.p99.String())
+		fmt.Printf("│ %-25s │ %-50s │\n", "Lag Max", m.freshnessLag.max.String())
+		fmt.Printf("│ %-25s │ %-50d │\n", "Probes Not Visible", m.freshnessMissed)
+	}
+
+	// Index rebuild section
+	if cfg.RebuildIndex && m.searchTime > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Index Rebuild", "Before / During / After")
+		fmt.Println(divider)
+		before, during, after := &m.rebuildBefore, &m.rebuildDuring, &m.rebuildAfter
+		fmt.Printf("│ %-25s │ %-50s │\n", "Rebuild Window", during.elapsed.Round(time.Millisecond).String())
+		if m.rebuildErr != nil {
+			fmt.Printf("│ %-25s │ %-50.50s │\n", "Rebuild Error", m.rebuildErr.Error())
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Achieved QPS", fmt.Sprintf("%.2f / %.2f / %.2f", before.qps(), during.qps(), after.qps()))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p50", fmt.Sprintf("%s / %s / %s", before.latency.p50, during.latency.p50, after.latency.p50))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p99", fmt.Sprintf("%s / %s / %s", before.latency.p99, during.latency.p99, after.latency.p99))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Error Rate", fmt.Sprintf("%.2f%% / %.2f%% / %.2f%%", before.errorRate(), during.errorRate(), after.errorRate()))
+	}
+
+	// Alias comparison section
+	if m.aliasLatency.count > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Alias Routing", "Collection / Alias")
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency Avg", fmt.Sprintf("%s / %s", m.directLatency.avg, m.aliasLatency.avg))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p99", fmt.Sprintf("%s / %s", m.directLatency.p99, m.aliasLatency.p99))
+	}
+
+	// Transport comparison section
+	if m.restLatency.count > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Transport", "gRPC / REST")
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Sequential Search QPS", fmt.Sprintf("%.1f / %.1f (%+.1f%%)", sequentialQPS(m.grpcLatency), sequentialQPS(m.restLatency), m.restThroughputChange()))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency Avg", fmt.Sprintf("%s / %s", m.grpcLatency.avg, m.restLatency.avg))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Latency p99", fmt.Sprintf("%s / %s", m.grpcLatency.p99, m.restLatency.p99))
+	}
+
+	// Scalar index section
+	if len(m.scalarIndexes) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Scalar Indexes", "Index, build time, filtered p50 before -> after")
+		fmt.Println(divider)
+		for _, r := range m.scalarIndexes {
+			fmt.Printf("│ %-25s │ %-50s │\n", r.Field, fmt.Sprintf("%s, %s, %s -> %s", r.IndexType, r.BuildTime.Round(time.Millisecond), r.UnindexedP50, r.IndexedP50))
+		}
+	}
+
+	// Field verification section
+	if len(m.fieldChecks) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Verified Fields", "Values checked, out of range, missing")
+		fmt.Println(divider)
+		for _, c := range m.fieldChecks {
+			fmt.Printf("│ %-25s │ %-50s │\n", c.Field, fmt.Sprintf("%d, %d, %d %s", c.Checked, c.OutOfRange, c.Missing, passFail(c.OutOfRange == 0 && c.Missing == 0)))
+		}
+	}
+
+	// Scalar generator section
+	if cardinality := lt.cfg.scalarCardinality(); len(cardinality) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Scalar Generators", "Generator, distinct values inserted")
+		fmt.Println(divider)
+		for _, c := range cardinality {
+			distinct := fmt.Sprint(c.Distinct)
+			if c.Capped {
+				distinct = ">= " + distinct
+			}
+			gen := c.Generator
+			if len(gen) > 36 {
+				gen = gen[:33] + "..."
+			}
+			fmt.Printf("│ %-25s │ %-50s │\n", c.Field, fmt.Sprintf("%s, %s", gen, distinct))
+		}
+	}
+
+	// Canary section
+	if len(m.canaryResults) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Canary Queries", "Searches, p50, p99, p50 drift")
+		fmt.Println(divider)
+		for _, r := range m.canaryResults {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("Canary %d", r.Canary),
+				fmt.Sprintf("%d, %s, %s, %.2fx", r.Searches, r.LatencyP50, r.LatencyP99, r.Drift))
+		}
+	}
+
+	// Index types section
+	if len(m.indexTypeSearch) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Index Types", "Searches/sec, search p50, p99")
+		fmt.Println(divider)
+		for _, r := range m.indexTypeSearch {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("%s (%d collections)", r.IndexType, len(r.Collections)),
+				fmt.Sprintf("%.2f, %s, %s", r.SearchesPerSec, r.LatencyP50, r.LatencyP99))
+		}
+	}
+
+	// Connections section
+	if len(m.connStats) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Connections", "Vectors/sec, searches/sec, search p99")
+		fmt.Println(divider)
+		for _, c := range m.connStats {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("Connection %d (%d workers)", c.Connection, c.Workers),
+				fmt.Sprintf("%.2f, %.2f, %s", c.InsertsPerSec, c.SearchesPerSec, c.SearchLatencyP99))
+		}
+	}
+
+	// Server metrics section
+	if len(m.serverSamples) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Server Metrics", "Peak (phase, client ops/s at the time)")
+		fmt.Println(divider)
+		at := func(s ServerSample) string {
+			phase := s.Phase
+			if phase == "" {
+				phase = "between phases"
+			}
+			return fmt.Sprintf("(%s, %.1f)", phase, s.ClientOpsPerSec)
+		}
+		cpu := m.peakServerSample(func(s ServerSample) float64 { return s.CPUCores })
+		rss := m.peakServerSample(func(s ServerSample) float64 { return s.ResidentBytes })
+		queue := m.peakServerSample(func(s ServerSample) float64 { return s.QueryQueueDepth })
+		compaction := m.peakServerSample(func(s ServerSample) float64 { return s.CompactionBacklog })
+		fmt.Printf("│ %-25s │ %-50d │\n", "Samples", len(m.serverSamples))
+		fmt.Printf("│ %-25s │ %-50s │\n", "CPU Cores", fmt.Sprintf("%.2f %s", cpu.CPUCores, at(cpu)))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Resident Memory", fmt.Sprintf("%.1f MB %s", rss.ResidentBytes/(1024*1024), at(rss)))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Query Queue Depth", fmt.Sprintf("%.0f %s", queue.QueryQueueDepth, at(queue)))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Compaction Backlog", fmt.Sprintf("%.0f %s", compaction.CompactionBacklog, at(compaction)))
+	}
+
+	// Cost estimate section
+	insertCost, searchCost := costPerMillion(cfg.CostPerHour, m.insertsPerSec), costPerMillion(cfg.CostPerHour, m.searchesPerSec)
+	if insertCost > 0 || searchCost > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Cost Estimate", fmt.Sprintf("At %g per hour and this run's throughput", cfg.CostPerHour))
+		fmt.Println(divider)
+		if insertCost > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Per 1M Vectors Inserted", fmt.Sprintf("~%.4f (estimate)", insertCost))
+		}
+		if searchCost > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Per 1M Searches", fmt.Sprintf("~%.4f (estimate)", searchCost))
+		}
+	}
+
+	// Timeline section
+	if len(m.timeline) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Timeline", "Start -> End (local time)")
+		fmt.Println(divider)
+		for _, span := range m.timeline {
+			fmt.Printf("│ %-25s │ %-50s │\n", span.Phase, formatSpan(span))
+		}
+	}
+
+	// Collection description section
+	if len(m.collectionInfo) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Collection", "Description")
+		fmt.Println(divider)
+		for _, info := range m.collectionInfo {
+			index := "no index"
+			if len(info.Indexes) > 0 {
+				index = fmt.Sprintf("%s %s", info.Indexes[0].Type, info.Indexes[0].Params["metric_type"])
+			}
+			fmt.Printf("│ %-25s │ %-50s │\n", "Name", info.Name)
+			fmt.Printf("│ %-25s │ %-50s │\n", "Layout", fmt.Sprintf("%d fields, %s, %d shards, %s", len(info.Fields), index, info.Shards, info.LoadState))
+			if len(info.Properties) > 0 {
+				fmt.Printf("│ %-25s │ %-50s │\n", "Properties", formatParams(info.Properties))
+			}
+			for _, mismatch := range info.Mismatches {
+				fmt.Printf("│ %-25s │ %-50s │\n", "Mismatch", mismatch)
+			}
+		}
+	}
+
+	// Latency vs throughput section
+	if len(m.sweep) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Throughput Sweep", "Achieved QPS / p50 / p99")
+		fmt.Println(divider)
+		for _, point := range m.sweep {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("Target %g qps", point.TargetQPS),
+				fmt.Sprintf("%.2f / %s / %s", point.AchievedQPS, point.LatencyP50, point.LatencyP99))
+		}
+	}
+
+	// Latency vs topK section
+	if len(m.topKSweep) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "topK Sweep", "Achieved QPS / p50 / p99")
+		fmt.Println(divider)
+		for _, point := range m.topKSweep {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("topK %d", point.TopK),
+				fmt.Sprintf("%.2f / %s / %s", point.AchievedQPS, point.LatencyP50, point.LatencyP99))
+		}
+		knee := "none"
+		if m.topKKnee > 0 {
+			knee = fmt.Sprintf("topK %d", m.topKKnee)
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Latency Knee", knee)
+	}
+
+	// Maximum sustainable rate section
+	if len(m.maxQPSProbes) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Max Sustainable QPS", fmt.Sprintf("p99 <= %s, no errors", cfg.MaxQPSP99))
+		fmt.Println(divider)
+		best := "none of the probed rates"
+		if m.maxQPS.TargetQPS > 0 {
+			best = fmt.Sprintf("%.1f qps (p50 %s, p99 %s)", m.maxQPS.TargetQPS, m.maxQPS.LatencyP50, m.maxQPS.LatencyP99)
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Maximum Rate", best)
+		fmt.Printf("│ %-25s │ %-50d │\n", "Probes", len(m.maxQPSProbes))
+	}
+
+	// Search iterator section
+	if m.iteratorPages.count > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Search Iterator", fmt.Sprintf("pages of %d, up to %d hits per query", cfg.IteratorPageSize, cfg.IteratorTotal))
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Open Latency p50 / p99", fmt.Sprintf("%s / %s", m.iteratorOpen.p50, m.iteratorOpen.p99))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Page Latency p50 / p99", fmt.Sprintf("%s / %s", m.iteratorPages.p50, m.iteratorPages.p99))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Retrieval p50 / p99", fmt.Sprintf("%s / %s", m.iteratorRetrievals.p50, m.iteratorRetrievals.p99))
+		fmt.Printf("│ %-25s │ %-50s │\n", "Hits", fmt.Sprintf("%d in %d pages (%.2f/s)", m.iteratorHits, m.iteratorPages.count, m.iteratorHitsPerSec()))
+		fmt.Printf("│ %-25s │ %-50d │\n", "Duplicate Hits", m.iteratorDuplicates)
+	}
+
+	// Failover section
+	if len(cfg.addrs) > 1 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Failover", "Value")
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50d │\n", "Failovers", len(m.failovers))
+		for _, event := range m.failovers {
+			fmt.Printf("│ %-25s │ %-50s │\n", "At "+event.at.Round(time.Millisecond).String(), event.from+" -> "+event.to)
+		}
+		for i, addr := range cfg.addrs {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Operations "+addr, fmt.Sprintf("%d", m.addrOps[i]))
+		}
+		fmt.Printf("│ %-25s │ %-50s │\n", "Busiest Address", lt.busiestAddr())
+	}
+
+	// Client GC section
+	if m.gc.collections > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Client GC", "Value")
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50d │\n", "Collections", m.gc.collections)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Pause Total / Avg / Max", fmt.Sprintf("%s / %s / %s", m.gc.pauseTotal, m.gc.pauseAvg(), m.gc.pauseMax))
+		if m.gc.explainsTail(m.searchLatency.p99) {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Search p99 Caveat", fmt.Sprintf("max GC pause is %.0f%% of p99, tail may be client GC", 100*float64(m.gc.pauseMax)/float64(m.searchLatency.p99)))
+		}
+	}
+
+	// Wire statistics section
+	if wire := lt.wire; wire != nil {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "Wire Statistics", "Value")
+		fmt.Println(divider)
+		for _, row := range []struct {
+			name    string
+			counter *wireCounter
+		}{{"Insert", &wire.insert}, {"Search", &wire.search}, {"Other", &wire.other}} {
+			fmt.Printf("│ %-25s │ %-50d │\n", row.name+" RPCs", row.counter.rpcs.Load())
+			fmt.Printf("│ %-25s │ %-47.2f MB │\n", row.name+" Bytes Sent", float64(row.counter.bytesSent.Load())/(1024*1024))
+			fmt.Printf("│ %-25s │ %-47.2f MB │\n", row.name+" Bytes Received", float64(row.counter.bytesReceived.Load())/(1024*1024))
+		}
+		if m.insertionTime > 0 {
+			insertSentMB := float64(wire.insert.bytesSent.Load()) / (1024 * 1024)
+			fmt.Printf("│ %-25s │ %-45.2f MB/s │\n", "Insert Bandwidth", insertSentMB/m.insertionTime.Seconds())
+		}
+		if cfg.Compression != "none" && wire.insert.payloadSent.Load() > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Insert Compression", fmt.Sprintf("%s: %.2f MB payload sent as %.2f MB (%.0f%%)", cfg.Compression,
+				float64(wire.insert.payloadSent.Load())/(1024*1024), float64(wire.insert.bytesSent.Load())/(1024*1024), 100*wire.insert.compressionRatio()))
+		}
+	}
+
+	// SLA assertions section
+	if cfg.MaxSearchP99 > 0 || cfg.MinSearchQPS > 0 || cfg.Strict || len(m.faults) > 0 {
+		fmt.Println(divider)
+		fmt.Printf("│ %-25s │ %-50s │\n", "SLA Assertions", "Result")
+		fmt.Println(divider)
+		if cfg.MaxSearchP99 > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", "Search p99 <= "+cfg.MaxSearchP99.String(), passFail(m.searchLatency.p99 <= cfg.MaxSearchP99))
+		}
+		if cfg.MinSearchQPS > 0 {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("Search QPS >= %g", cfg.MinSearchQPS), passFail(m.searchesPerSec >= cfg.MinSearchQPS))
+		}
+		if cfg.Strict {
+			fmt.Printf("│ %-25s │ %-50s │\n", fmt.Sprintf("Empty Results <= %g%%", maxEmptyResultRate), passFail(m.emptyResultRate() <= maxEmptyResultRate))
+		}
+		if len(m.faults) > 0 {
+			accepted := slices.ContainsFunc(m.faults, func(f FaultResult) bool { return f.Accepted > 0 })
+			fmt.Printf("│ %-25s │ %-50s │\n", "Malformed Batches Refused", passFail(!accepted))
+		}
+	}
+
+	fmt.Println(strings.Repeat("=", 80))
+
+	if cfg.Chart {
+		printChart("Insert throughput over time", "vectors/s", &m.insertSeries, m.insertionTime)
+		printChart("Search throughput over time", "searches/s", &m.searchSeries, m.searchTime)
+	}
+}
+
+// passFail renders an assertion outcome
+func passFail(ok bool) string {
+	if ok {
+		return "PASS"
+	}
+	return "FAIL"
+}
+
+// percentileCell formats the i-th of percentiles for the summary, marking one
+// computed from too few samples to tell it from the maximum
+func percentileCell(percentiles []LatencyPercentile, i int) string {
+	if i >= len(percentiles) {
+		return "-"
+	}
+	p := percentiles[i]
+	if p.Samples < p.Needed {
+		return fmt.Sprintf("%s (max, %d samples)", p.Latency, p.Samples)
+	}
+	return p.Latency.String()
+}
@@ -0,0 +1,186 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// A sweep stops after the first level that achieves less than sweepMinAchieved of
+// its target rate, or whose p99 exceeds sweepMaxP99Growth times that of the first level
+const (
+	sweepMinAchieved  = 0.9
+	sweepMaxP99Growth = 10
+)
+
+// SweepPoint is one level of a latency vs throughput sweep, or of a topK sweep
+type SweepPoint struct {
+	TargetQPS   float64       `json:"target_qps"` // 0 for unpaced
+	TopK        int           `json:"top_k"`
+	AchievedQPS float64       `json:"achieved_qps"`
+	Searches    int64         `json:"searches"`
+	Failures    int64         `json:"failures"`
+	LatencyP50  time.Duration `json:"latency_p50_ns"`
+	LatencyP99  time.Duration `json:"latency_p99_ns"`
+}
+
+// parseSweepLevels parses a comma separated list of ascending target rates
+func parseSweepLevels(spec string) ([]float64, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var levels []float64
+	for _, field := range strings.Split(spec, ",") {
+		qps, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || qps <= 0 {
+			return nil, fmt.Errorf("level %q: must be a positive number", field)
+		}
+		if len(levels) > 0 && qps <= levels[len(levels)-1] {
+			return nil, fmt.Errorf("level %g: levels must be ascending", qps)
+		}
+		levels = append(levels, qps)
+	}
+	return levels, nil
+}
+
+// sweep runs the search workers paced at every --sweep level in turn for
+// --sweep-step each and records the achieved rate and latency of each level,
+// stopping once the server can no longer keep up (step 7d)
+func (lt *loadTest) sweep(ctx context.Context) error {
+	cfg := lt.cfg
+	if len(cfg.sweepLevels) == 0 {
+		return nil
+	}
+	fmt.Printf("\n--- Step 7d: Latency vs throughput sweep (%d levels, %s each) ---\n", len(cfg.sweepLevels), cfg.SweepStep)
+	lt.phaseStart("sweep")
+	sweepStart := time.Now()
+	fmt.Printf("   %12s %14s %10s %10s %12s %12s\n", "Target QPS", "Achieved QPS", "Searches", "Failures", "p50", "p99")
+	for _, qps := range cfg.sweepLevels {
+		if ctx.Err() != nil {
+			break
+		}
+		point := lt.searchAtRate(ctx, qps, searchTopK, cfg.SweepStep)
+		lt.m.sweep = append(lt.m.sweep, point)
+		fmt.Printf("   %12g %14.2f %10d %10d %12s %12s\n", point.TargetQPS, point.AchievedQPS,
+			point.Searches, point.Failures, point.LatencyP50, point.LatencyP99)
+
+		if point.AchievedQPS < sweepMinAchieved*qps {
+			fmt.Printf("   -> Stopping: achieved %.0f%% of the %g qps target\n", 100*point.AchievedQPS/qps, qps)
+			break
+		}
+		if first := lt.m.sweep[0].LatencyP99; first > 0 && point.LatencyP99 > sweepMaxP99Growth*first {
+			fmt.Printf("   -> Stopping: p99 %s is over %dx the %s of the first level\n", point.LatencyP99, sweepMaxP99Growth, first)
+			break
+		}
+	}
+	lt.phaseEnd("sweep", time.Since(sweepStart))
+
+	if cfg.SweepCSV != "" {
+		if err := writeSweepCSV(cfg.SweepCSV, lt.m.sweep); err != nil {
+			return fmt.Errorf("failed to write sweep CSV: %w", err)
+		}
+		fmt.Printf("   -> Curve written to %s\n", cfg.SweepCSV)
+	}
+	fmt.Println("✅ Sweep complete.")
+	return nil
+}
+
+// searchAtRate runs the search workers, asking for topK results, paced to qps (or
+// as fast as they go if qps is 0) for duration
+func (lt *loadTest) searchAtRate(ctx context.Context, qps float64, topK int, duration time.Duration) SweepPoint {
+	cfg := lt.cfg
+	var wg sync.WaitGroup
+	collector := NewCollector()
+	var operations, failures int64
+	agg := startAggregator(func(r searchResult) (int64, int64) {
+		collector.Record(r.latency, r.err)
+		operations++
+		if r.err != nil {
+			failures++
+		}
+		return operations, failures
+	}, nil)
+	searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+	var ratePacer *pacer
+	if qps > 0 {
+		ratePacer = newPacer(constantRate(qps))
+	}
+	start := time.Now()
+	end := start.Add(duration)
+	for i := 0; i < cfg.numWorkers; i++ {
+		wg.Add(1)
+		go func(goroutineID int) {
+			defer wg.Done()
+			rand.Seed(time.Now().UnixNano() + int64(goroutineID))
+			conn := lt.conns[goroutineID%len(lt.conns)]
+			collection := lt.dataTarget(goroutineID)
+			for time.Now().Before(end) && ctx.Err() == nil {
+				if ratePacer != nil {
+					if _, ok := ratePacer.wait(ctx, end); !ok {
+						return
+					}
+				}
+				var queryVector []entity.Vector
+				if lt.queries != nil {
+					_, queryVector = lt.queries.pick()
+				} else {
+					queryVector = randomQueryVector()
+				}
+				searchStart := time.Now()
+				_, err := conn.Search(ctx, collection, []string{}, "", []string{}, queryVector, embeddingField, cfg.metricType, topK, searchParams)
+				agg.send(searchResult{start: searchStart, latency: time.Since(searchStart), err: err})
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	agg.close()
+
+	snapshot := collector.Snapshot(elapsed)
+	return SweepPoint{
+		TargetQPS:   qps,
+		TopK:        topK,
+		AchievedQPS: snapshot.Throughput,
+		Searches:    snapshot.Operations,
+		Failures:    snapshot.Failures,
+		LatencyP50:  snapshot.Latency.P50,
+		LatencyP99:  snapshot.Latency.P99,
+	}
+}
+
+// writeSweepCSV writes the sweep curve with latencies in milliseconds
+func writeSweepCSV(path string, points []SweepPoint) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.Write([]string{"target_qps", "achieved_qps", "searches", "failures", "p50_ms", "p99_ms"})
+	ms := func(d time.Duration) string {
+		return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+	}
+	for _, p := range points {
+		w.Write([]string{
+			strconv.FormatFloat(p.TargetQPS, 'g', -1, 64),
+			strconv.FormatFloat(p.AchievedQPS, 'f', 2, 64),
+			strconv.FormatInt(p.Searches, 10),
+			strconv.FormatInt(p.Failures, 10),
+			ms(p.LatencyP50),
+			ms(p.LatencyP99),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,45 @@
+package loadtest
+
+import (
+	"fmt"
+	"time"
+)
+
+// PhaseSpan is when one phase of the run happened by the wall clock, to line the
+// run up with server dashboards
+type PhaseSpan struct {
+	Phase string    `json:"phase"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"` // zero if the phase did not end, e.g. under --max-runtime
+}
+
+// phaseStart records that phase began and reports it on the event stream
+func (lt *loadTest) phaseStart(phase string) {
+	lt.timelineMu.Lock()
+	lt.m.timeline = append(lt.m.timeline, PhaseSpan{Phase: phase, Start: time.Now()})
+	lt.timelineMu.Unlock()
+	lt.clientOps.Store(0)
+	lt.events.phaseStart(phase)
+}
+
+// phaseEnd records that phase completed in took and reports it on the event stream
+func (lt *loadTest) phaseEnd(phase string, took time.Duration) {
+	lt.timelineMu.Lock()
+	for i := len(lt.m.timeline) - 1; i >= 0; i-- {
+		if span := &lt.m.timeline[i]; span.Phase == phase && span.End.IsZero() {
+			span.End = time.Now()
+			break
+		}
+	}
+	lt.timelineMu.Unlock()
+	lt.events.phaseEnd(phase, took)
+}
+
+// formatSpan formats the span for the summary table in local time
+func formatSpan(span PhaseSpan) string {
+	start := span.Start.Format("2006-01-02 15:04:05.000")
+	if span.End.IsZero() {
+		return start + " -> (did not end)"
+	}
+	return fmt.Sprintf("%s -> %s (%s)", start, span.End.Format("15:04:05.000"), span.End.Sub(span.Start).Round(time.Millisecond))
+}
@@ -0,0 +1,61 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// countOutput is the output field Milvus answers a count query with
+const countOutput = "count(*)"
+
+// timeWindow counts the rows whose --timestamp-field falls in the --time-window up
+// to the end of the insert phase (or now, when this run inserted nothing), next
+// to the total row count (step 6c)
+func (lt *loadTest) timeWindow(ctx context.Context) error {
+	cfg := lt.cfg
+	if cfg.TimestampField == "" {
+		return nil
+	}
+	hi := lt.insertEnd
+	if hi.IsZero() {
+		hi = time.Now()
+	}
+	lo := hi.Add(-cfg.TimeWindow)
+	expr := fmt.Sprintf("%s >= %d && %s <= %d", cfg.TimestampField, lo.UnixMilli(), cfg.TimestampField, hi.UnixMilli())
+	fmt.Printf("\n--- Step 6c: Count rows in the last %s of inserts ---\n", cfg.TimeWindow)
+	fmt.Printf("Filter: %s\n", expr)
+
+	start := time.Now()
+	inWindow, err := lt.count(ctx, expr)
+	if err != nil {
+		return fmt.Errorf("failed to count rows in time window: %w", err)
+	}
+	lt.m.timeWindowQueryTime = time.Since(start)
+	total, err := lt.count(ctx, fmt.Sprintf("%s >= 0", cfg.TimestampField))
+	if err != nil {
+		return fmt.Errorf("failed to count rows: %w", err)
+	}
+	lt.m.timeWindowRows, lt.m.timeWindowTotal = inWindow, total
+	fmt.Printf("✅ %d of %d rows fall in the window (range query took %s)\n", inWindow, total, lt.m.timeWindowQueryTime.Round(time.Microsecond))
+	return nil
+}
+
+// count returns the number of rows matching expr across the test collections
+func (lt *loadTest) count(ctx context.Context, expr string) (int64, error) {
+	var total int64
+	for _, name := range lt.collections {
+		result, err := lt.client.Query(ctx, name, []string{}, expr, []string{countOutput})
+		if err != nil {
+			return 0, err
+		}
+		column, ok := result.GetColumn(countOutput).(*entity.ColumnInt64)
+		if !ok || column.Len() == 0 {
+			return 0, fmt.Errorf("collection '%s': count query returned no count", name)
+		}
+		total += column.Data()[0]
+	}
+	return total, nil
+}
@@ -0,0 +1,92 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxTopK is the largest topK Milvus accepts
+const maxTopK = 16384
+
+// topKKneeGrowth is the p50 growth over the first topK level that marks the knee
+const topKKneeGrowth = 2
+
+// parseTopKLevels parses a comma separated list of ascending topK values
+func parseTopKLevels(spec string) ([]int, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var levels []int
+	for _, field := range strings.Split(spec, ",") {
+		k, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || k <= 0 || k > maxTopK {
+			return nil, fmt.Errorf("level %q: must be an integer between 1 and %d", field, maxTopK)
+		}
+		if len(levels) > 0 && k <= levels[len(levels)-1] {
+			return nil, fmt.Errorf("level %d: levels must be ascending", k)
+		}
+		levels = append(levels, k)
+	}
+	return levels, nil
+}
+
+// formatTopKLevels formats topK levels like --topk-sweep
+func formatTopKLevels(levels []int) string {
+	fields := make([]string, len(levels))
+	for i, k := range levels {
+		fields[i] = strconv.Itoa(k)
+	}
+	return strings.Join(fields, ",")
+}
+
+// topKSweep runs the search workers for --sweep-step at every --topk-sweep level,
+// unpaced or at --target-qps, and reports where latency starts climbing (step 7g)
+func (lt *loadTest) topKSweep(ctx context.Context) error {
+	cfg := lt.cfg
+	if len(cfg.topKLevels) == 0 {
+		return nil
+	}
+	levels := cfg.topKLevels
+	if rows, err := lt.rowsPerCollection(ctx); err != nil {
+		fmt.Printf("⚠️  Could not count the rows (%v), topK levels are not capped\n", err)
+	} else if levels = clampTopKLevels(cfg.topKLevels, rows); levels[len(levels)-1] < cfg.topKLevels[len(cfg.topKLevels)-1] {
+		fmt.Printf("⚠️  topK levels above the %d rows per collection return %d hits at most: sweeping %s instead\n", rows, rows, formatTopKLevels(levels))
+	}
+	fmt.Printf("\n--- Step 7g: Search cost vs topK (%d levels, %s each) ---\n", len(levels), cfg.SweepStep)
+	lt.phaseStart("topk-sweep")
+	start := time.Now()
+	fmt.Printf("   %8s %14s %10s %10s %12s %12s %10s\n", "topK", "Achieved QPS", "Searches", "Failures", "p50", "p99", "p50 Growth")
+	for _, k := range levels {
+		if ctx.Err() != nil {
+			break
+		}
+		point := lt.searchAtRate(ctx, cfg.TargetQPS, k, cfg.SweepStep)
+		lt.m.topKSweep = append(lt.m.topKSweep, point)
+		growth := lt.m.topKGrowth(point)
+		fmt.Printf("   %8d %14.2f %10d %10d %12s %12s %9.1fx\n", k, point.AchievedQPS,
+			point.Searches, point.Failures, point.LatencyP50, point.LatencyP99, growth)
+		if lt.m.topKKnee == 0 && growth >= topKKneeGrowth {
+			lt.m.topKKnee = k
+		}
+	}
+	lt.phaseEnd("topk-sweep", time.Since(start))
+	if lt.m.topKKnee > 0 {
+		fmt.Printf("   -> Latency knee at topK %d: p50 at least %dx that of topK %d\n", lt.m.topKKnee, topKKneeGrowth, levels[0])
+	} else {
+		fmt.Printf("   -> No knee: p50 stayed under %dx that of topK %d\n", topKKneeGrowth, levels[0])
+	}
+	fmt.Println("✅ topK sweep complete.")
+	return nil
+}
+
+// topKGrowth returns the p50 of point relative to that of the first topK level
+func (m *metrics) topKGrowth(point SweepPoint) float64 {
+	first := m.topKSweep[0].LatencyP50
+	if first <= 0 {
+		return 0
+	}
+	return float64(point.LatencyP50) / float64(first)
+}
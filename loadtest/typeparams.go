@@ -0,0 +1,100 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// Type params Milvus requires on some field types
+const (
+	typeParamDim       = "dim"
+	typeParamMaxLength = "max_length"
+)
+
+// maxVarCharLength is the largest max_length Milvus accepts for a VarChar field
+const maxVarCharLength = 65535
+
+// indexNlist is the default IVF_FLAT nlist, --index-params can override it
+const indexNlist = 16
+
+// requiredTypeParams lists the type params a field of each type cannot be created without
+var requiredTypeParams = map[entity.FieldType][]string{
+	entity.FieldTypeFloatVector: {typeParamDim},
+	entity.FieldTypeVarChar:     {typeParamMaxLength},
+}
+
+// parseParams parses key=value pairs separated by sep
+func parseParams(spec, sep string) (map[string]string, error) {
+	params := map[string]string{}
+	if spec == "" {
+		return params, nil
+	}
+	for _, pair := range strings.Split(spec, sep) {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("param %q: want key=value", pair)
+		}
+		if _, dup := params[key]; dup {
+			return nil, fmt.Errorf("param %q set twice", key)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+// checkTypeParams verifies that field carries the type params its type requires,
+// with usable values
+func checkTypeParams(field *entity.Field) error {
+	for _, key := range requiredTypeParams[field.DataType] {
+		if _, ok := field.TypeParams[key]; !ok {
+			return fmt.Errorf("field %q: %s fields require the %s type param", field.Name, field.DataType.Name(), key)
+		}
+	}
+	if value, ok := field.TypeParams[typeParamMaxLength]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxVarCharLength {
+			return fmt.Errorf("field %q: invalid %s %q: must be an integer between 1 and %d", field.Name, typeParamMaxLength, value, maxVarCharLength)
+		}
+	}
+	if value, ok := field.TypeParams[typeParamDim]; ok {
+		if n, err := strconv.Atoi(value); err != nil || n < 1 {
+			return fmt.Errorf("field %q: invalid %s %q: must be a positive integer", field.Name, typeParamDim, value)
+		}
+	}
+	return nil
+}
+
+// newIndex returns the index of the embedding field of the named collection, of
+// its --index-mix type or --index-type
+func (lt *loadTest) newIndex(name string) entity.Index {
+	return lt.newIndexOf(lt.cfg.indexTypeOf(name))
+}
+
+// newIndexOf returns an index of indexType on the embedding field, with the
+// --index-params build params applied over the defaults
+func (lt *loadTest) newIndexOf(indexType entity.IndexType) entity.Index {
+	build := map[string]string{"nlist": strconv.Itoa(indexNlist)}
+	for key, value := range lt.cfg.indexParams {
+		build[key] = value
+	}
+	encoded, _ := json.Marshal(build)
+	return entity.NewGenericIndex("", indexType, map[string]string{
+		"metric_type": string(lt.cfg.metricType),
+		"params":      string(encoded),
+	})
+}
+
+// formatParams formats params as key=value,... ordered by key, the inverse of parseParams
+func formatParams(params map[string]string) string {
+	pairs := make([]string, 0, len(params))
+	for _, key := range slices.Sorted(maps.Keys(params)) {
+		pairs = append(pairs, key+"="+params[key])
+	}
+	return strings.Join(pairs, ",")
+}
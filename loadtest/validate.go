@@ -0,0 +1,90 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+)
+
+// maxEmptyResultRate is the percentage of validated searches that may come back
+// empty before --strict fails the run
+const maxEmptyResultRate = 1.0
+
+// resultHits returns the number of hits of the first query of a search
+func resultHits(results []client.SearchResult) int {
+	if len(results) == 0 {
+		return 0
+	}
+	return results[0].ResultCount
+}
+
+// expectedHits returns how many hits a search of the test collections for topK
+// neighbours should return: topK, unless fewer rows exist. It is -1 when the row
+// count cannot be found, and only empty results are flagged then.
+func (lt *loadTest) expectedHits(ctx context.Context, topK int) int {
+	rows, err := lt.rowsPerCollection(ctx)
+	if err != nil {
+		fmt.Printf("⚠️  --validate-results: could not count the rows (%v), only empty results are flagged\n", err)
+		return -1
+	}
+	expected := expectedHitCount(topK, rows)
+	if expected < topK {
+		fmt.Printf("⚠️  topK %d exceeds the %d rows per collection: expecting %d hits per search\n", topK, rows, expected)
+	}
+	return expected
+}
+
+// rowsPerCollection returns the rows inserted by this run, or counted in the
+// collections when it inserted none, per test collection. Every collection is
+// searched separately, so the smallest one bounds a search; with the rows spread
+// evenly that is about the per-collection share.
+func (lt *loadTest) rowsPerCollection(ctx context.Context) (int64, error) {
+	rows := lt.m.totalVectorsInserted
+	if rows == 0 {
+		var err error
+		if rows, err = lt.count(ctx, ""); err != nil {
+			return 0, err
+		}
+	}
+	return rows / int64(len(lt.collections)), nil
+}
+
+// expectedHitCount returns the hits a topK search of a collection of rows returns
+func expectedHitCount(topK int, rows int64) int {
+	return int(min(int64(topK), max(rows, 0)))
+}
+
+// classifyHits reports whether a search returning hits is empty or short of the
+// expected hits. With expected -1 (unknown) only empty results are flagged, with
+// 0 (an empty collection) none are.
+func classifyHits(hits, expected int) (empty, short bool) {
+	if expected == 0 {
+		return false, false
+	}
+	if hits == 0 {
+		return true, false
+	}
+	return false, hits < expected
+}
+
+// clampTopKLevels caps the --topk-sweep levels at the rows of a collection, since
+// Milvus returns no more hits than that, and drops the levels the cap repeats
+func clampTopKLevels(levels []int, rows int64) []int {
+	var clamped []int
+	for _, k := range levels {
+		k = max(1, expectedHitCount(k, rows))
+		if len(clamped) == 0 || k > clamped[len(clamped)-1] {
+			clamped = append(clamped, k)
+		}
+	}
+	return clamped
+}
+
+// emptyResultRate returns the percentage of validated searches with no hits
+func (m *metrics) emptyResultRate() float64 {
+	if m.validatedSearches == 0 {
+		return 0
+	}
+	return 100 * float64(m.emptyResults) / float64(m.validatedSearches)
+}
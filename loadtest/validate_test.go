@@ -0,0 +1,75 @@
+package loadtest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpectedHitCount(t *testing.T) {
+	tests := []struct {
+		name string
+		topK int
+		rows int64
+		want int
+	}{
+		{"large collection returns topK", 3, 1000000, 3},
+		{"topK equal to the rows", 10, 10, 10},
+		{"small collection caps topK", 100, 7, 7},
+		{"empty collection returns nothing", 3, 0, 0},
+		{"negative rows count as empty", 3, -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expectedHitCount(tt.topK, tt.rows); got != tt.want {
+				t.Errorf("expectedHitCount(%d, %d) = %d, want %d", tt.topK, tt.rows, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyHitsSmallCollection(t *testing.T) {
+	// topK 100 over a collection of 7 rows: 7 hits is a full result, not a short one
+	expected := expectedHitCount(100, 7)
+	tests := []struct {
+		name         string
+		hits         int
+		expected     int
+		empty, short bool
+	}{
+		{"all rows returned", 7, expected, false, false},
+		{"fewer than the rows", 5, expected, false, true},
+		{"no hits", 0, expected, true, false},
+		{"empty collection flags nothing", 0, 0, false, false},
+		{"unknown row count flags only empty results", 1, -1, false, false},
+		{"unknown row count, no hits", 0, -1, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			empty, short := classifyHits(tt.hits, tt.expected)
+			if empty != tt.empty || short != tt.short {
+				t.Errorf("classifyHits(%d, %d) = (%t, %t), want (%t, %t)", tt.hits, tt.expected, empty, short, tt.empty, tt.short)
+			}
+		})
+	}
+}
+
+func TestClampTopKLevels(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []int
+		rows   int64
+		want   []int
+	}{
+		{"levels under the rows are kept", []int{1, 10, 100}, 1000, []int{1, 10, 100}},
+		{"levels above the rows collapse to it", []int{1, 10, 100, 1000}, 50, []int{1, 10, 50}},
+		{"every level above the rows", []int{100, 1000}, 5, []int{5}},
+		{"empty collection keeps topK 1", []int{10, 100}, 0, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clampTopKLevels(tt.levels, tt.rows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clampTopKLevels(%v, %d) = %v, want %v", tt.levels, tt.rows, got, tt.want)
+			}
+		})
+	}
+}
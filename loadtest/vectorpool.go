@@ -0,0 +1,58 @@
+package loadtest
+
+import (
+	"context"
+	"math/bits"
+	"math/rand"
+	"sync/atomic"
+)
+
+// vectorPool holds the distinct vectors --distinct-vectors draws the inserted rows
+// from, with repetition, and tracks which of them were drawn
+type vectorPool struct {
+	vectors [][]float32
+	used    []atomic.Uint64 // bitset of the vectors drawn at least once
+	drawn   atomic.Int64
+}
+
+// newVectorPool generates n random vectors, scaled to unit length if normalize is set
+func newVectorPool(ctx context.Context, n int, normalize bool) (*vectorPool, bool) {
+	c := newFloatVectorColumn(embeddingField, embeddingDim, n)
+	if !fillRandomVectors(ctx, c, n, normalize) {
+		return nil, false
+	}
+	return &vectorPool{vectors: c.rows, used: make([]atomic.Uint64, (n+63)/64)}, true
+}
+
+// fill appends n rows drawn from the pool to c. The rows alias the pool vectors,
+// which are never modified. It returns false as soon as ctx is done.
+func (p *vectorPool) fill(ctx context.Context, c *floatVectorColumn, n int) bool {
+	for k := 0; k < n; k++ {
+		if k%generationCheckInterval == 0 && ctx.Err() != nil {
+			return false
+		}
+		i := rand.Intn(len(p.vectors))
+		p.used[i/64].Or(1 << (i % 64))
+		c.rows = append(c.rows, p.vectors[i])
+	}
+	p.drawn.Add(int64(n))
+	return true
+}
+
+// distinctDrawn returns how many pool vectors were drawn at least once
+func (p *vectorPool) distinctDrawn() int {
+	distinct := 0
+	for i := range p.used {
+		distinct += bits.OnesCount64(p.used[i].Load())
+	}
+	return distinct
+}
+
+// duplicationRatio returns the fraction of drawn rows that repeat a vector drawn before
+func (p *vectorPool) duplicationRatio() float64 {
+	drawn := p.drawn.Load()
+	if drawn == 0 {
+		return 0
+	}
+	return 1 - float64(p.distinctDrawn())/float64(drawn)
+}
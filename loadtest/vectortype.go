@@ -0,0 +1,37 @@
+package loadtest
+
+import (
+	"fmt"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// vectorTypeInfo is how a vector field type constrains its dimension and how
+// many bytes a vector of it takes, so the data volume is computed in one place
+type vectorTypeInfo struct {
+	name        string
+	fieldType   entity.FieldType
+	bitsPerDim  int
+	dimMultiple int // the dimension must be a multiple of this
+}
+
+// embeddingType is the type of the embedding field: float32 values
+var embeddingType = vectorTypeInfo{name: "float", fieldType: entity.FieldTypeFloatVector, bitsPerDim: 32, dimMultiple: 1}
+
+// checkDim returns an error if dim is not valid for the type
+func (t vectorTypeInfo) checkDim(dim int) error {
+	if dim <= 0 || dim%t.dimMultiple != 0 {
+		return fmt.Errorf("dim %d is invalid for %s vectors: must be a positive multiple of %d", dim, t.name, t.dimMultiple)
+	}
+	return nil
+}
+
+// vectorBytes returns the size of one vector of dim dimensions
+func (t vectorTypeInfo) vectorBytes(dim int) int64 {
+	return int64(dim*t.bitsPerDim) / 8
+}
+
+// embeddingBytes returns the size of rows embeddings
+func embeddingBytes(rows int64) int64 {
+	return rows * embeddingType.vectorBytes(embeddingDim)
+}
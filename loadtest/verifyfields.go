@@ -0,0 +1,231 @@
+package loadtest
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// timestampSkew is how far in the future a --timestamp-field value may lie, for
+// the clock of the inserting client running ahead of this one
+const timestampSkew = time.Minute
+
+// FieldCheck is what --verify-fields found in the values search returned for one
+// field. A value is out of range when this run could not have generated it;
+// Missing counts searches whose results lacked the field altogether.
+type FieldCheck struct {
+	Field      string `json:"field"`
+	Checked    int64  `json:"checked"`
+	OutOfRange int64  `json:"out_of_range"`
+	Missing    int64  `json:"missing"`
+	Example    string `json:"example,omitempty"` // the first value out of range
+}
+
+// parseVerifyFields parses --verify-fields, a comma separated list of scalar
+// fields or "all" for all of them
+func parseVerifyFields(spec string, fields []scalarField) ([]scalarField, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	if spec == "all" {
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("all: the collection has no scalar fields")
+		}
+		return slices.Clone(fields), nil
+	}
+	var verify []scalarField
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		i := slices.IndexFunc(fields, func(f scalarField) bool { return f.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("field %q is not declared by --scalar-fields or --timestamp-field", name)
+		}
+		if slices.ContainsFunc(verify, func(f scalarField) bool { return f.name == name }) {
+			return nil, fmt.Errorf("field %q given twice", name)
+		}
+		verify = append(verify, fields[i])
+	}
+	return verify, nil
+}
+
+// verifyFieldNames returns the output fields searches request for --verify-fields
+func (cfg *Config) verifyFieldNames() []string {
+	names := make([]string, len(cfg.verifyFields))
+	for i, f := range cfg.verifyFields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// verifyResults checks the --verify-fields values of the first query of a
+// search, returning one check per field
+func (cfg *Config) verifyResults(results []client.SearchResult) []FieldCheck {
+	checks := make([]FieldCheck, len(cfg.verifyFields))
+	for i, f := range cfg.verifyFields {
+		checks[i].Field = f.name
+		if len(results) == 0 || results[0].ResultCount == 0 {
+			continue
+		}
+		column := results[0].Fields.GetColumn(f.name)
+		if column == nil {
+			checks[i].Missing++
+			continue
+		}
+		f.check(column, &checks[i])
+	}
+	return checks
+}
+
+// check counts the values of column and those out of the field's range
+func (f scalarField) check(column entity.Column, c *FieldCheck) {
+	flag := func(value string) {
+		c.OutOfRange++
+		if c.Example == "" {
+			c.Example = value
+		}
+	}
+	switch col := column.(type) {
+	case *entity.ColumnInt64:
+		for _, v := range col.Data() {
+			c.Checked++
+			if f.dataType != entity.FieldTypeInt64 || !f.validInt(v) {
+				flag(strconv.FormatInt(v, 10))
+			}
+		}
+	case *entity.ColumnFloat:
+		for _, v := range col.Data() {
+			c.Checked++
+			if f.dataType != entity.FieldTypeFloat || !f.validFloat(v) {
+				flag(strconv.FormatFloat(float64(v), 'g', -1, 32))
+			}
+		}
+	case *entity.ColumnVarChar:
+		for _, v := range col.Data() {
+			c.Checked++
+			if f.dataType != entity.FieldTypeVarChar || !f.validVarChar(v) {
+				flag(strconv.Quote(v))
+			}
+		}
+	default:
+		c.Checked += int64(column.Len())
+		c.OutOfRange += int64(column.Len())
+		if c.Example == "" {
+			c.Example = fmt.Sprintf("a %s column", column.Type())
+		}
+	}
+}
+
+// validInt reports whether an Int64 field could have been given v
+func (f scalarField) validInt(v int64) bool {
+	if f.timestamp {
+		return v > 0 && v <= time.Now().Add(timestampSkew).UnixMilli()
+	}
+	g := f.gen
+	if g == nil {
+		return v >= 0 && v < scalarIntRange
+	}
+	switch g.kind {
+	case "enum":
+		return slices.ContainsFunc(g.enum, func(e string) bool {
+			n, _ := strconv.ParseInt(e, 10, 64) // checked by parseValueGen
+			return n == v
+		})
+	case "uniform":
+		return v >= int64(math.Ceil(g.a)) && v <= int64(math.Floor(g.b))
+	case "zipf":
+		return v >= 0 && v < int64(g.b)
+	default:
+		return true // normal values are unbounded
+	}
+}
+
+// validFloat reports whether a Float field could have been given v
+func (f scalarField) validFloat(v float32) bool {
+	x := float64(v)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return false
+	}
+	g := f.gen
+	if g == nil {
+		return v >= 0 && v <= 100
+	}
+	switch g.kind {
+	case "enum":
+		return slices.ContainsFunc(g.enum, func(e string) bool {
+			n, _ := strconv.ParseFloat(e, 32) // checked by parseValueGen
+			return float32(n) == v
+		})
+	case "uniform":
+		return v >= float32(g.a) && v <= float32(g.b)
+	case "zipf":
+		return x == math.Trunc(x) && x >= 0 && x < g.b
+	default:
+		return true
+	}
+}
+
+// validVarChar reports whether a VarChar field could have been given v: an enum
+// value, or "item-<n>" with n in the field's range, cut to max_length from the
+// front as appendRandom does
+func (f scalarField) validVarChar(v string) bool {
+	maxLength, _ := strconv.Atoi(f.typeParams[typeParamMaxLength]) // checked by parseScalarFields
+	if len(v) > maxLength {
+		return false
+	}
+	if f.gen != nil && f.gen.kind == "enum" {
+		return slices.Contains(f.gen.enum, v)
+	}
+	start := len(v)
+	for start > 0 && v[start-1] >= '0' && v[start-1] <= '9' {
+		start--
+	}
+	if start == len(v) {
+		return false // the number is never cut off entirely
+	}
+	// what precedes the digits is the end of "item-", or "item--" for a negative
+	// normal value
+	if prefix := v[:start]; !strings.HasSuffix("item-", prefix) && !strings.HasSuffix("item--", prefix) {
+		return false
+	}
+	if !strings.HasPrefix(v, "item-") {
+		return true // cut to max_length, the number is incomplete
+	}
+	n, err := strconv.ParseInt(v[len("item-"):], 10, 64)
+	if err != nil {
+		return false
+	}
+	if f.gen == nil {
+		return n >= 0 && n < scalarIntRange
+	}
+	return f.validInt(n)
+}
+
+// addFieldChecks folds the checks of one search into the run's totals, warning
+// on the first value out of range of each field
+func (m *metrics) addFieldChecks(checks []FieldCheck) {
+	if m.fieldChecks == nil {
+		m.fieldChecks = make([]FieldCheck, len(checks))
+		for i, c := range checks {
+			m.fieldChecks[i].Field = c.Field
+		}
+	}
+	for i, c := range checks {
+		total := &m.fieldChecks[i]
+		if total.Example == "" && c.Example != "" {
+			total.Example = c.Example
+			fmt.Printf("⚠️  --verify-fields: search returned %s for '%s', outside the values this run generates\n", c.Example, c.Field)
+		}
+		if total.Missing == 0 && c.Missing > 0 {
+			fmt.Printf("⚠️  --verify-fields: search results lack the output field '%s'\n", c.Field)
+		}
+		total.Checked += c.Checked
+		total.OutOfRange += c.OutOfRange
+		total.Missing += c.Missing
+	}
+}
@@ -0,0 +1,48 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+// warmSearch runs --search-warmup unmeasured queries before the search phase, so
+// the first touches of every segment do not inflate the reported latency (step 6d)
+func (lt *loadTest) warmSearch(ctx context.Context) {
+	cfg := lt.cfg
+	if cfg.SearchWarmup == 0 {
+		return
+	}
+	fmt.Printf("\n--- Step 6d: Warm up the index with %d unmeasured searches ---\n", cfg.SearchWarmup)
+	searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
+	var issued, failed atomic.Int64
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < min(cfg.numWorkers, cfg.SearchWarmup); i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			conn := lt.conns[worker%len(lt.conns)]
+			target := lt.dataTarget(worker)
+			for issued.Add(1) <= int64(cfg.SearchWarmup) && ctx.Err() == nil {
+				var queryVector []entity.Vector
+				if lt.queries != nil {
+					_, queryVector = lt.queries.pick()
+				} else {
+					queryVector = randomQueryVector()
+				}
+				if _, err := conn.Search(ctx, target, []string{}, "", []string{}, queryVector, embeddingField, cfg.metricType, searchTopK, searchParams); err != nil {
+					failed.Add(1)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	lt.m.warmupTime = time.Since(start)
+	lt.m.warmupFailures = failed.Load()
+	fmt.Printf("✅ Warm-up done in %s (%d failed)\n", lt.m.warmupTime.Round(time.Millisecond), lt.m.warmupFailures)
+}
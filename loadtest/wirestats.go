@@ -0,0 +1,82 @@
+package loadtest
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/stats"
+)
+
+// compressions lists the --compression values, named like their gRPC compressors
+var compressions = []string{"none", gzip.Name}
+
+// wireCounter tallies the RPCs of one method family and the bytes they put on the wire
+type wireCounter struct {
+	rpcs          atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	payloadSent   atomic.Int64 // bytesSent before --compression
+}
+
+// compressionRatio returns the bytes sent on the wire per byte of payload
+func (c *wireCounter) compressionRatio() float64 {
+	if c.payloadSent.Load() == 0 {
+		return 0
+	}
+	return float64(c.bytesSent.Load()) / float64(c.payloadSent.Load())
+}
+
+// wireStats is a gRPC stats handler counting RPCs and wire bytes for inserts,
+// searches and everything else (DDL, flush, index, load). Every RPC is counted,
+// including the ones the SDK retries transparently.
+type wireStats struct {
+	insert wireCounter
+	search wireCounter
+	other  wireCounter
+}
+
+type wireCounterKey struct{}
+
+// counterFor maps a full gRPC method name like /milvus.proto.milvus.MilvusService/Insert to its counter
+func (w *wireStats) counterFor(fullMethod string) *wireCounter {
+	switch fullMethod[strings.LastIndex(fullMethod, "/")+1:] {
+	case "Insert":
+		return &w.insert
+	case "Search":
+		return &w.search
+	default:
+		return &w.other
+	}
+}
+
+// TagRPC implements stats.Handler
+func (w *wireStats) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, wireCounterKey{}, w.counterFor(info.FullMethodName))
+}
+
+// HandleRPC implements stats.Handler
+func (w *wireStats) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	counter, ok := ctx.Value(wireCounterKey{}).(*wireCounter)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.Begin:
+		counter.rpcs.Add(1)
+	case *stats.OutPayload:
+		counter.bytesSent.Add(int64(s.WireLength))
+		counter.payloadSent.Add(int64(s.Length))
+	case *stats.InPayload:
+		counter.bytesReceived.Add(int64(s.WireLength))
+	}
+}
+
+// TagConn implements stats.Handler
+func (w *wireStats) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements stats.Handler
+func (w *wireStats) HandleConn(context.Context, stats.ConnStats) {}
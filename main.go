@@ -2,66 +2,36 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
-	"math/rand"
+	"os"
+	"runtime"
 	"strings"
-	"sync"
-	"time"
 
-	"github.com/milvus-io/milvus-sdk-go/v2/client"
-	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/ariswibono/milvus-stress-test/loadtest"
 )
 
-const (
-	// Collection settings
-	collectionName  = "go_high_throughput_collection"
-	embeddingDim    = 8
-	primaryKeyField = "id"
-	embeddingField  = "embedding"
-)
-
-// calculateDynamicLoad calculates the current load based on elapsed time (like a real dyno)
-func calculateDynamicLoad(elapsed time.Duration, totalDuration time.Duration, maxWorkers int, maxBatchSize int) (int, int) {
-	if elapsed >= totalDuration {
-		return maxWorkers, maxBatchSize
-	}
-
-	// Linear ramp-up: start at 10% of max, reach 100% at the end
-	progress := float64(elapsed) / float64(totalDuration)
-	if progress > 1.0 {
-		progress = 1.0
-	}
-
-	// Start at 10% of max capacity
-	startWorkers := max(1, maxWorkers/10)
-	startBatchSize := max(100, maxBatchSize/10)
-
-	currentWorkers := startWorkers + int(float64(maxWorkers-startWorkers)*progress)
-	currentBatchSize := startBatchSize + int(float64(maxBatchSize-startBatchSize)*progress)
-
-	return currentWorkers, currentBatchSize
-}
-
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
 func showDetailedHelp() {
 	fmt.Println("Milvus Load Testing Tool")
 	fmt.Println("=======================")
 	fmt.Println()
 	fmt.Println("USAGE:")
-	fmt.Println("  go run main.go [OPTIONS]")
+	fmt.Println("  go run . [COMMAND] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("COMMANDS:")
+	for _, c := range loadtest.Commands() {
+		fmt.Printf("  %-16s %s\n", c.Name, c.Description)
+	}
 	fmt.Println()
 	fmt.Println("OPTIONS:")
 	fmt.Println("  --milvus-addr string")
 	fmt.Println("        Milvus server address (default: localhost:19530)")
 	fmt.Println("        Example: --milvus-addr 192.168.1.100:19530")
+	fmt.Println("        A comma-separated list enables failover: the first reachable address is used,")
+	fmt.Println("        and inserts and searches move to the next one when theirs becomes unreachable")
+	fmt.Println("        Example: --milvus-addr proxy-a:19530,proxy-b:19530")
 	fmt.Println()
 	fmt.Println("  --duration duration")
 	fmt.Println("        Test duration (default: 30s)")
@@ -76,343 +46,662 @@ func showDetailedHelp() {
 	fmt.Println("        - extreme: 100 workers, 10000 vectors/batch")
 	fmt.Println()
 	fmt.Println("  --ramp-up")
-	fmt.Println("        Gradually increase load from 10% to 100% over duration")
+	fmt.Println("        Gradually increase load from --ramp-start-pct to 100% over duration")
+	fmt.Println("        Ramps insert workers and batch size, then search workers over the search phase")
 	fmt.Println("        Useful for finding performance limits")
 	fmt.Println()
+	fmt.Println("  --ramp-start-pct float")
+	fmt.Println("        Load at the start of --ramp-up, in percent of the workers and batch size, each")
+	fmt.Println("        at least 1 (default: 10). Use 1 for a gentler ramp")
+	fmt.Println()
+	fmt.Println("  --cool-down duration")
+	fmt.Println("        After the search phase, ramp the search workers from all of them down to")
+	fmt.Println("        none over this long, the --ramp-up curve in reverse, and report QPS and")
+	fmt.Println("        latency per tenth of it to show how quickly latency recovers (default: 0, off)")
+	fmt.Println()
+	fmt.Println("  --fault-injection-rate float")
+	fmt.Println("        Robustness testing: alongside the insert batches, send this many malformed")
+	fmt.Println("        batches of 10 rows per batch, e.g. 0.01 for one per hundred, with a wrong")
+	fmt.Println("        dimension, no embedding values, a NaN in a vector or a VarChar value over")
+	fmt.Println("        max_length, and report how many Milvus rejected. They bypass the SDK's own")
+	fmt.Println("        checks, rejected ones count as failed inserts and an accepted one fails")
+	fmt.Println("        the run with status 4 (default: 0, off)")
+	fmt.Println()
+	fmt.Println("  --batch-jitter float")
+	fmt.Println("        Draw every insert batch size uniformly within this percentage around the")
+	fmt.Println("        target, e.g. 25 for 750-1250 rows at batch size 1000 (default: 0 = fixed).")
+	fmt.Println("        Applied on top of --ramp-up; the summary shows the effective average and range")
+	fmt.Println()
 	fmt.Println("  --real-time")
 	fmt.Println("        Display real-time throughput metrics during test")
 	fmt.Println()
+	fmt.Println("  --retries int")
+	fmt.Println("        Max retries per failed insert/search (default: 0)")
+	fmt.Println("        Only transient errors (unavailable, rate limited, leader switch) are retried")
+	fmt.Println()
+	fmt.Println("  --ddl-retries int")
+	fmt.Println("        Max retries of a collection existence check, create or drop that failed")
+	fmt.Println("        transiently or collided with another client's DDL (default: 3). A drop of a")
+	fmt.Println("        collection already gone and a create of one another client just created with")
+	fmt.Println("        the same schema succeed; the summary counts both, so several instances can")
+	fmt.Println("        share a cluster")
+	fmt.Println()
+	fmt.Println("  --retry-backoff duration")
+	fmt.Println("        Initial backoff between retries, doubled each attempt up to 30s; 0 retries at once (default: 100ms)")
+	fmt.Println()
+	fmt.Println("  --projection-bench")
+	fmt.Println("        After the search phase, run the same queries returning only IDs and")
+	fmt.Println("        returning all output fields, and report the latency delta")
+	fmt.Println()
+	fmt.Println("  --projection-queries int")
+	fmt.Println("        Queries per variant for --projection-bench (default: 100)")
+	fmt.Println()
+	fmt.Println("  --wire-stats")
+	fmt.Println("        Count RPCs and bytes sent/received for inserts, searches and other calls")
+	fmt.Println("        and report them in the summary (adds a small per-RPC overhead)")
+	fmt.Println()
+	fmt.Println("  --compression string")
+	fmt.Println("        Compress every gRPC call: none or gzip (default: none). Compare the insert")
+	fmt.Println("        throughput of runs with and without it; with --wire-stats the summary also")
+	fmt.Println("        shows the insert payload and the bytes it took on the wire")
+	fmt.Println()
+	fmt.Println("  --transport string")
+	fmt.Println("        Send inserts and searches over grpc or rest (default: grpc). rest uses the")
+	fmt.Println("        Milvus RESTful API on the --milvus-addr port; collection setup, flush, index")
+	fmt.Println("        and load still go over gRPC. After the search phase the same --compare-queries")
+	fmt.Println("        batch runs over both transports and the summary shows the throughput difference")
+	fmt.Println()
+	fmt.Println("  --metrics-url string")
+	fmt.Println("        Milvus Prometheus metrics endpoint used to report the memory footprint")
+	fmt.Println("        of the loaded collection (default: http://<milvus-host>:9091/metrics)")
+	fmt.Println()
+	fmt.Println("  --server-metrics-interval duration")
+	fmt.Println("        Scrape --metrics-url at this interval for the whole run and record server")
+	fmt.Println("        CPU, resident memory, query node queue depth and compaction backlog next to")
+	fmt.Println("        the client throughput of the running phase (default: 0, off). The samples")
+	fmt.Println("        go to --event-stream and --summary-json, the summary shows the peaks")
+	fmt.Println()
+	fmt.Println("  --target-qps float")
+	fmt.Println("        Pace searches to this many queries per second across all workers")
+	fmt.Println("        (default: 0, unpaced)")
+	fmt.Println()
+	fmt.Println("  --burst")
+	fmt.Println("        Spike the search rate from --target-qps to --burst-qps for --burst-duration")
+	fmt.Println("        every --burst-interval, and compare latency/errors in bursts vs baseline")
+	fmt.Println()
+	fmt.Println("  --burst-qps float")
+	fmt.Println("        Search rate during bursts, must exceed --target-qps")
+	fmt.Println()
+	fmt.Println("  --burst-duration duration")
+	fmt.Println("        Length of each burst (default: 5s)")
+	fmt.Println()
+	fmt.Println("  --burst-interval duration")
+	fmt.Println("        Time at baseline rate between bursts (default: 20s)")
+	fmt.Println()
+	fmt.Println("  --flush-compare")
+	fmt.Println("        Run a search batch before flush (growing segments) and after")
+	fmt.Println("        flush/index/load (sealed segments) and compare latency (full command only)")
+	fmt.Println()
+	fmt.Println("  --compare-queries int")
+	fmt.Println("        Queries per --flush-compare, --alias, --transport rest or --scalar-index comparison batch (default: 100)")
+	fmt.Println()
+	fmt.Println("  --ttl int")
+	fmt.Println("        Collection data TTL in seconds, set when the collection is created")
+	fmt.Println("        (default: 0, data never expires)")
+	fmt.Println()
+	fmt.Println("  --consistency string")
+	fmt.Println("        Default consistency level of the collection (default: bounded)")
+	fmt.Println("        Options: strong, bounded, session, eventually")
+	fmt.Println()
+	fmt.Println("  --distinct-vectors int")
+	fmt.Println("        Draw every inserted vector, with repetition, from a pool of this many distinct")
+	fmt.Println("        random vectors, to see how duplicated data affects index build and search")
+	fmt.Println("        (default: 0, every vector random). The duplication ratio is reported;")
+	fmt.Println("        duplicates tie in --recall-check, which lowers the measured recall")
+	fmt.Println()
+	fmt.Println("  --max-data-mb float")
+	fmt.Println("        Insert until this many MB of raw vector and scalar data are written, however")
+	fmt.Println("        long it takes, then flush, index and search as usual (default: 0, insert for")
+	fmt.Println("        --duration). Useful for \"fill to 10GB and benchmark search\" runs")
+	fmt.Println()
+	fmt.Println("  --scalar-fields string")
+	fmt.Println("        Add scalar fields filled with random values to the collection, as a comma")
+	fmt.Println("        separated list of name:type (types: int64, float, varchar), each optionally")
+	fmt.Println("        followed by :key=value type params. varchar fields require max_length")
+	fmt.Println("        Example: --scalar-fields category:varchar:max_length=32,price:float")
+	fmt.Println("        Nullable fields and default values need a newer Milvus Go SDK than the one")
+	fmt.Println("        this tool is built with and are not supported yet")
+	fmt.Println()
+	fmt.Println("  --scalar-gen string")
+	fmt.Println("        Draw the values of --scalar-fields fields from a distribution instead of the")
+	fmt.Println("        default random ones, as a semicolon separated list of field=kind[:args]:")
+	fmt.Println("        enum:a,b,c (equally likely values), uniform:low,high, normal:mean,stddev and")
+	fmt.Println("        zipf[:s,n] (values 0..n-1, exponent s > 1, default 1.2,1000). varchar fields")
+	fmt.Println("        get the numeric kinds as item-<n>. The distinct values each field received")
+	fmt.Println("        are reported in the summary, counted up to 100000")
+	fmt.Println("        Example: --scalar-fields tag:varchar:max_length=16,price:float")
+	fmt.Println("                 --scalar-gen 'tag=enum:red,green,blue;price=normal:50,10'")
+	fmt.Println()
+	fmt.Println("  --scalar-index string")
+	fmt.Println("        Index --scalar-fields fields, as a comma separated list of field:type (types:")
+	fmt.Println("        INVERTED, STL_SORT for numbers, TRIE for varchar, BITMAP). After the search")
+	fmt.Println("        phase a --compare-queries batch filtered on each field runs, the indexes are")
+	fmt.Println("        built and timed, and the batch runs again (full command only)")
+	fmt.Println("        Example: --scalar-fields price:float --scalar-index price:STL_SORT")
+	fmt.Println()
+	fmt.Println("  --auto-id")
+	fmt.Println("        Let Milvus generate primary keys (default: true). With --auto-id=false the")
+	fmt.Println("        tool assigns the keys itself and remembers them for by-key operations")
+	fmt.Println()
+	fmt.Println("  --pk-strategy string")
+	fmt.Println("        How the tool assigns keys with --auto-id=false (default: sequential)")
+	fmt.Println("        Options: sequential (segments fill in key order), random (uniform, may repeat),")
+	fmt.Println("        hashed (uniform and unique); compare the insert throughput of each")
+	fmt.Println()
+	fmt.Println("  --checkpoint string")
+	fmt.Println("        Record how far an 'insert' run got in this file, every 5s and when the insert")
+	fmt.Println("        phase ends. Needs --auto-id=false and sequential or hashed keys")
+	fmt.Println()
+	fmt.Println("  --resume")
+	fmt.Println("        Continue the 'insert' run recorded in --checkpoint: keep the collection and")
+	fmt.Println("        assign keys from the first row not yet attempted. With --max-data-mb only the")
+	fmt.Println("        remaining volume is inserted. Batches in flight when the run was interrupted")
+	fmt.Println("        count as not inserted and are not retried")
+	fmt.Println()
+	fmt.Println("  --id-pool-size int")
+	fmt.Println("        Number of recently inserted primary keys to remember (default: 100000, 0 = off)")
+	fmt.Println("        With AutoID on, the keys Milvus generates are captured from the insert results")
+	fmt.Println("        Older keys are evicted once the pool is full, bounding memory")
+	fmt.Println()
+	fmt.Println("  --flush-every-n-batches int")
+	fmt.Println("        Flush the test collections after every N insert batches, counted across all")
+	fmt.Println("        workers (default: 0 = only at the end). The flushes run beside the workers,")
+	fmt.Println("        which keep inserting; the summary shows their count and average time")
+	fmt.Println()
+	fmt.Println("  --hot-segment duration")
+	fmt.Println("        After the insert phase, insert with all workers for this long into a fresh")
+	fmt.Println("        collection with one shard and one partition, where every row lands in the same")
+	fmt.Println("        growing segment, then as long into one with 4 shards and 4 partitions, and")
+	fmt.Println("        compare the throughput (default: 0 = skip). Both collections are dropped after")
+	fmt.Println("        Example: --hot-segment 30s")
+	fmt.Println()
+	fmt.Println("  --batch-sweep string")
+	fmt.Println("        After the insert phase, insert with all workers at each of these comma-separated")
+	fmt.Println("        batch sizes for --batch-sweep-duration, each into a fresh collection that is")
+	fmt.Println("        dropped after, and report vectors/sec and batch latency per size with the")
+	fmt.Println("        fastest one highlighted (full and insert commands)")
+	fmt.Println("        Example: --batch-sweep 500,1000,2000,5000,10000")
+	fmt.Println()
+	fmt.Println("  --batch-sweep-duration duration")
+	fmt.Println("        How long --batch-sweep inserts at each batch size (default: 10s)")
+	fmt.Println()
+	fmt.Println("  --load-order string")
+	fmt.Println("        Order of the flush, index and load steps of the full command, comma-separated")
+	fmt.Println("        (default: flush,index,load). Milvus only loads indexed collections, so load")
+	fmt.Println("        must follow index; index,load,flush searches the growing segments loaded before")
+	fmt.Println("        the flush and times the handoff of the sealed segments once it indexes them")
+	fmt.Println()
+	fmt.Println("  --flush-strategy string")
+	fmt.Println("        How the --collections are flushed: per-collection (one request each, at most")
+	fmt.Println("        --admin-concurrency at a time) or batched (one request naming them all)")
+	fmt.Println("        (default: per-collection). The flush time and the time spent in the flush")
+	fmt.Println("        requests are reported, to compare the coordinator load of the two")
+	fmt.Println()
+	fmt.Println("  --load-timeout duration")
+	fmt.Println("        Stop waiting for the load of a collection after this long (default: 30m).")
+	fmt.Println("        Progress is printed every 5s while waiting. A collection only partly loaded")
+	fmt.Println("        by then is reported with its loaded percentage and searched as it is, with a")
+	fmt.Println("        warning; the load fails only when nothing of it loaded")
+	fmt.Println()
+	fmt.Println("  --flush-timeout duration")
+	fmt.Println("        Fail the flush if the sealed segments are not all persisted within this time")
+	fmt.Println("        (default: 10m). Progress is printed every 5s while waiting")
+	fmt.Println()
+	fmt.Println("  --chart")
+	fmt.Println("        Draw insert and search throughput over time as a sparkline after the summary,")
+	fmt.Println("        sampled every second, to spot ramp-up and degradation without external tools")
+	fmt.Println()
+	fmt.Println("  --replicas int")
+	fmt.Println("        Load the collection with this many in-memory replicas (default: 1). Needs at")
+	fmt.Println("        least as many query nodes; the replica layout is printed after the load")
+	fmt.Println()
+	fmt.Println("  --connections int")
+	fmt.Println("        Spread the insert and search workers across this many gRPC connections,")
+	fmt.Println("        worker i on connection i % N (default: 1), and report throughput and search")
+	fmt.Println("        latency per connection. Compare runs with 1, 2, 4, ... connections to find")
+	fmt.Println("        where multiplexing stops limiting throughput (the SDK does not tell which")
+	fmt.Println("        replica served a search, so per-replica latency is not available)")
+	fmt.Println()
+	fmt.Println("  --query-file string")
+	fmt.Println("        Draw search query vectors from an .fvecs file instead of generating random ones")
+	fmt.Println("        The vectors must have the collection dimension")
+	fmt.Println()
+	fmt.Println("  --ground-truth-file string")
+	fmt.Println("        Ground-truth neighbour IDs (.ivecs) for --query-file, one row per query.")
+	fmt.Println("        Searches then report recall@k; the IDs must be primary keys of the collection")
+	fmt.Println()
+	fmt.Println("  --export-vectors string")
+	fmt.Println("        Write the ID and vector of every row the insert phase inserted to this file,")
+	fmt.Println("        one {\"id\":...,\"embedding\":[...]} JSON object per line, streamed as the")
+	fmt.Println("        batches are acknowledged. Workers only wait for the disk when it falls behind")
+	fmt.Println()
+	fmt.Println("  --event-stream string")
+	fmt.Println("        Write newline-delimited JSON events to this file (\"-\" for stdout) for dashboards")
+	fmt.Println("        and test harnesses: phase-start, phase-end, snapshot (every second during")
+	fmt.Println("        insert and search) and error (operations that failed after their retries)")
+	fmt.Println()
+	fmt.Println("  --gomaxprocs int")
+	fmt.Println("        Number of OS threads running Go code in the client (default: 0, runtime")
+	fmt.Println("        default, which follows container CPU limits). Pin it for results that are")
+	fmt.Println("        comparable across machines")
+	fmt.Println()
+	fmt.Println("  --throttle-backoff duration")
+	fmt.Println("        When Milvus rejects an operation with a rate-limit or quota error, pause")
+	fmt.Println("        the worker for this long, doubling while throttling persists (default: 0, off)")
+	fmt.Println("        Throttled operations are always counted separately in the summary")
+	fmt.Println()
+	fmt.Println("  --metric string")
+	fmt.Println("        Distance metric of the index and of every search: L2, IP or COSINE (default: L2)")
+	fmt.Println("        'search' checks it against the metric the existing index was built with and")
+	fmt.Println("        stops before searching if they differ")
+	fmt.Println()
+	fmt.Println("  --normalize string")
+	fmt.Println("        Scale inserted vectors to unit length: auto, on or off (default: auto)")
+	fmt.Println("        auto normalizes for IP and COSINE; IP on raw random vectors ranks the longest")
+	fmt.Println("        vectors first whatever the query, so a warning is printed when it is off")
+	fmt.Println()
+	fmt.Println("  --recall-check int")
+	fmt.Println("        After the search phase of 'full', run this many random queries and compare the")
+	fmt.Println("        results with an exact search over the inserted vectors, computed in the client")
+	fmt.Println("        with the same metric (COSINE normalizes the vectors first). Needs every inserted")
+	fmt.Println("        row in the primary key pool, so --id-pool-size must cover the data (default: 0)")
+	fmt.Println()
+	fmt.Println("  --collections int")
+	fmt.Println("        Create this many test collections and spread the insert and search workers")
+	fmt.Println("        across them (default: 1). Flush, index and load run on all of them concurrently")
+	fmt.Println("        and report per-collection timings; the flush-compare and projection batches")
+	fmt.Println("        use the first collection")
+	fmt.Println()
+	fmt.Println("  --admin-concurrency int")
+	fmt.Println("        Maximum number of collections flushed, indexed or loaded at once (default: 4)")
+	fmt.Println()
+	fmt.Println("  --vector-params string")
+	fmt.Println("        Extra type params of the embedding field, as key=value,... (dim is fixed at 8)")
+	fmt.Println()
+	fmt.Println("  --index-type string")
+	fmt.Println("        Vector index built on the embedding field: IVF_FLAT or IVF_SQ8 (default: IVF_FLAT)")
+	fmt.Println("        IVF_SQ8 quantizes each value to a byte. With --recall-check the first collection")
+	fmt.Println("        is also rebuilt with IVF_FLAT and the same queries rerun, to report the recall")
+	fmt.Println("        cost of quantization; the summary estimates the vector memory saved")
+	fmt.Println()
+	fmt.Println("  --index-mix string")
+	fmt.Println("        Index types spread over the --collections (>= 2) by weight, as type=weight,...,")
+	fmt.Println("        e.g. IVF_FLAT=1,IVF_SQ8=1; replaces --index-type. Each type gets its share of the")
+	fmt.Println("        collections, picked at random, and the search phase reports latency per type.")
+	fmt.Println("        With 'search' it names the types an earlier 'insert' run built")
+	fmt.Println()
+	fmt.Println("  --index-params string")
+	fmt.Println("        Index build params, as key=value,..., applied over the default nlist=16")
+	fmt.Println("        Example: --index-params nlist=128")
+	fmt.Println()
+	fmt.Println("  --shards int")
+	fmt.Println("        Shards (DML channels) per collection (default: 0, the server default)")
+	fmt.Println()
+	fmt.Println("  --collection-properties string")
+	fmt.Println("        Extra collection properties set at creation, as key=value,...; the summary")
+	fmt.Println("        reports the segments the flush produced so their effect can be compared")
+	fmt.Println("        Example: --collection-properties mmap.enabled=true")
+	fmt.Println()
+	fmt.Println("  --sweep string")
+	fmt.Println("        After the search phase, run the search workers paced at each of these ascending")
+	fmt.Println("        rates in turn (e.g. 100,500,1000,2000) and report achieved QPS and p50/p99 per")
+	fmt.Println("        rate: the latency vs throughput curve. Stops early once less than 90% of a")
+	fmt.Println("        target is achieved or p99 grows past 10x that of the first rate")
+	fmt.Println()
+	fmt.Println("  --sweep-step duration")
+	fmt.Println("        Time spent at each --sweep rate and --topk-sweep value (default: 10s)")
+	fmt.Println()
+	fmt.Println("  --topk-sweep string")
+	fmt.Println("        After the search phase, run the search workers for --sweep-step at each of")
+	fmt.Println("        these ascending topK values (e.g. 1,10,50,100,500), unpaced or at --target-qps,")
+	fmt.Println("        and report QPS and p50/p99 per value. The knee is the first topK whose p50 is")
+	fmt.Println("        at least twice that of the first value")
+	fmt.Println()
+	fmt.Println("  --find-max-qps")
+	fmt.Println("        After the search phase, find the highest search rate whose p99 stays within")
+	fmt.Println("        --find-max-qps-p99 with no errors: starting at half the search phase QPS, the")
+	fmt.Println("        rate doubles until a --sweep-step probe misses the bound, then bisects until")
+	fmt.Println("        passing and failing rates are within 5% (at most 16 probes)")
+	fmt.Println()
+	fmt.Println("  --find-max-qps-p99 duration")
+	fmt.Println("        p99 latency bound of --find-max-qps, required with it")
+	fmt.Println("        Example: --find-max-qps --find-max-qps-p99 50ms")
+	fmt.Println()
+	fmt.Println("  --percentiles string")
+	fmt.Println("        Ascending latency percentiles reported for inserts and searches, in the summary")
+	fmt.Println("        and --summary-json (default: 50,90,99,99.9). Every latency is kept, so they are")
+	fmt.Println("        exact, but p99.9 needs 1000 samples and p99.99 10000 to rank below the maximum")
+	fmt.Println("        Example: --percentiles 50,90,99,99.9,99.99")
+	fmt.Println()
+	fmt.Println("  --iterator")
+	fmt.Println("        After the search phase, page through the nearest neighbours of --compare-queries")
+	fmt.Println("        query vectors with the SDK search iterator and report the latency of opening")
+	fmt.Println("        the iterator, of every page and of every full retrieval")
+	fmt.Println()
+	fmt.Println("  --iterator-page-size int")
+	fmt.Println("        Hits per --iterator page (default: 1000)")
+	fmt.Println()
+	fmt.Println("  --iterator-total int")
+	fmt.Println("        Hits --iterator fetches per query vector, fewer if it runs out (default: 10000)")
+	fmt.Println("        Example: --iterator --iterator-page-size 500 --iterator-total 50000")
+	fmt.Println()
+	fmt.Println("  --sweep-csv string")
+	fmt.Println("        Also write the --sweep curve to this CSV file")
+	fmt.Println()
+	fmt.Println("  --alias string")
+	fmt.Println("        Create this alias for the test collection and send every insert and search")
+	fmt.Println("        through it. After the search phase the same --compare-queries batch runs by")
+	fmt.Println("        collection name and by alias to show the redirection cost. The alias is")
+	fmt.Println("        dropped when the run ends")
+	fmt.Println()
+	fmt.Println("  --rebuild-index")
+	fmt.Println("        A third into the search phase, rebuild the index while the searches continue:")
+	fmt.Println("        release, drop and recreate the index, then reload (Milvus cannot drop the")
+	fmt.Println("        index of a loaded collection). Reports searches, errors and p50/p99 before,")
+	fmt.Println("        during and after the rebuild window")
+	fmt.Println()
+	fmt.Println("  --freshness int")
+	fmt.Println("        After the search phase, insert this many probe rows one at a time and search")
+	fmt.Println("        for each (filtered on its primary key) until it is returned, reporting the")
+	fmt.Println("        p50/p99 lag from insert acknowledgment to visibility. The lag depends on")
+	fmt.Println("        --consistency; probes not visible within 30s are counted separately")
+	fmt.Println()
+	fmt.Println("  --time-to-searchable")
+	fmt.Println("        After the bulk insert, insert one sentinel row and, once the collection is")
+	fmt.Println("        flushed, indexed and loaded, search for its vector until it is returned,")
+	fmt.Println("        reporting the time from its insert and from the end of the load: the")
+	fmt.Println("        ingest-to-serve latency. Gives up after --load-timeout (full command only)")
+	fmt.Println()
+	fmt.Println("  --mix string")
+	fmt.Println("        Replace the search phase with a mixed workload: every worker picks one")
+	fmt.Println("        operation per iteration with the given weights and the summary reports")
+	fmt.Println("        throughput and p50/p99 per operation. Queries, deletes and upserts target 10")
+	fmt.Println("        keys from the primary key pool; while it is empty they insert instead.")
+	fmt.Println("        Milvus cannot upsert into AutoID collections, so upsert needs --auto-id=false")
+	fmt.Println("        Example: --mix insert=50,search=40,query=5,delete=5")
+	fmt.Println()
+	fmt.Println("  --ddl-stress")
+	fmt.Println("        Stress the coordinators' DDL handling instead of the data path: the insert")
+	fmt.Println("        workers create and drop small collections back to back for --duration and")
+	fmt.Println("        the summary reports create/drop throughput, latency and errors. Leftover")
+	fmt.Println("        collections are dropped at the end, also after Ctrl-C (full command only)")
+	fmt.Println()
+	fmt.Println("  --timestamp-field string")
+	fmt.Println("        Add an Int64 field with each row's insert time in unix milliseconds, for")
+	fmt.Println("        time-range filters and TTL expiry tests. After load, the rows in the")
+	fmt.Println("        --time-window before the end of the inserts are counted with a range query")
+	fmt.Println()
+	fmt.Println("  --timestamp-spread duration")
+	fmt.Println("        Spread the timestamps at random over this much of the past instead of using")
+	fmt.Println("        the exact, monotonically increasing insert time (default: 0)")
+	fmt.Println()
+	fmt.Println("  --time-window duration")
+	fmt.Println("        Width of the counted time window (default: 10s)")
+	fmt.Println()
+	fmt.Println("  --max-runtime duration")
+	fmt.Println("        Hard ceiling on the whole run, flush, index build and load included. When it")
+	fmt.Println("        expires the current phase is stopped, the collection cleaned up and a partial")
+	fmt.Println("        summary printed, and the tool exits with status 5 (default: 0, unbounded)")
+	fmt.Println()
+	fmt.Println("  --cost-per-hour float")
+	fmt.Println("        Hourly price of the Milvus deployment, in any currency. The summary then")
+	fmt.Println("        estimates the cost of a million inserted vectors and of a million searches,")
+	fmt.Println("        as if the deployment ran an hour at the measured throughput (default: 0, none)")
+	fmt.Println()
+	fmt.Println("  --load-repeats int")
+	fmt.Println("        After the first load, release and reload the collection this many times and")
+	fmt.Println("        report min/median/max reload time next to the first, cold load (default: 0)")
+	fmt.Println()
+	fmt.Println("  --search-warmup int")
+	fmt.Println("        Run this many unmeasured searches before the search phase, so the reported")
+	fmt.Println("        latency reflects a warm index rather than first segment touches (default: 0)")
+	fmt.Println()
+	fmt.Println("  --pre-search-delay duration")
+	fmt.Println("        Wait this long after the load before the search phase and any warm-up, so")
+	fmt.Println("        index handoff still running in the background does not skew the first")
+	fmt.Println("        searches (default: 0 = none). The summary reports how long it waited")
+	fmt.Println()
+	fmt.Println("  --pre-search-settle")
+	fmt.Println("        Make --pre-search-delay the longest wait: poll every second and start")
+	fmt.Println("        searching once the vector index covers every row and loading is at 100%")
+	fmt.Println("        Example: --pre-search-delay 2m --pre-search-settle")
+	fmt.Println()
+	fmt.Println("  --canary-queries int")
+	fmt.Println("        Fixed query vectors (the first of --query-file, random otherwise) searched one")
+	fmt.Println("        at a time every --canary-interval during the search phase, next to the search")
+	fmt.Println("        workers (default: 0 = none). Their latency is reported per canary with its")
+	fmt.Println("        drift, the p50 of the last quarter of the phase over that of the first")
+	fmt.Println()
+	fmt.Println("  --canary-interval duration")
+	fmt.Println("        How often each canary vector is searched (default: 1s)")
+	fmt.Println()
+	fmt.Println("  --canary-csv string")
+	fmt.Println("        Also write the canary series to this CSV file, one row per search")
+	fmt.Println("        (elapsed_s,canary,latency_ms,failed)")
+	fmt.Println()
+	fmt.Println("  --list-filter string")
+	fmt.Println("        Only list the collections whose name matches this glob (list command; default:")
+	fmt.Println("        all). Every collection the tool creates matches 'go_high_throughput_collection*'")
+	fmt.Println("        With cleanup-orphans it narrows the tool's collections down further")
+	fmt.Println("        Example: go run . list --list-filter 'go_high_throughput_collection*'")
+	fmt.Println()
+	fmt.Println("  --orphan-age duration")
+	fmt.Println("        cleanup-orphans only drops the tool's collections created longer ago than this")
+	fmt.Println("        (default: 1h), so the collections of runs still going are left alone")
+	fmt.Println()
+	fmt.Println("  --confirm")
+	fmt.Println("        Let cleanup-orphans drop what it found. Without it the command is a dry run")
+	fmt.Println("        that prints the collections it would drop")
+	fmt.Println("        Example: go run . cleanup-orphans --orphan-age 6h --confirm")
+	fmt.Println()
+	fmt.Println("  --cleanup-mode string")
+	fmt.Println("        What the cleanup step does with the collection (default: drop)")
+	fmt.Println("        Options: drop, release (free query node memory, keep the data), none")
+	fmt.Println()
+	fmt.Println("  --max-search-p99 duration")
+	fmt.Println("        SLA assertion: exit with status 4 when the search p99 latency is above this")
+	fmt.Println("        (default: 0, no assertion)")
+	fmt.Println()
+	fmt.Println("  --min-search-qps float")
+	fmt.Println("        SLA assertion: exit with status 4 when the search throughput is below this")
+	fmt.Println("        (default: 0, no assertion)")
+	fmt.Println()
+	fmt.Println("  --validate-results")
+	fmt.Println("        Check that every search of the search phase returned hits, topK of them when")
+	fmt.Println("        the collection holds that many rows, and report empty and short results, so a")
+	fmt.Println("        search that is not really working cannot pass for a fast one")
+	fmt.Println()
+	fmt.Println("  --verify-fields string")
+	fmt.Println("        Have every search of the search phase return these scalar fields, as a comma")
+	fmt.Println("        separated list or all, and check each returned value against what the tool")
+	fmt.Println("        generates for the field (its --scalar-gen distribution, the default random")
+	fmt.Println("        range, or a past timestamp), reporting values out of range and results missing")
+	fmt.Println("        the field. A search of a collection filled with other settings reports those.")
+	fmt.Println("        Not available with --transport rest")
+	fmt.Println("        Example: --scalar-fields price:float --verify-fields all")
+	fmt.Println()
+	fmt.Println("  --strict")
+	fmt.Println("        With --validate-results, exit with status 4 when more than 1% of the searches")
+	fmt.Println("        returned no results")
+	fmt.Println()
+	fmt.Println("  --summary-json string")
+	fmt.Println("        Also write the run result (the loadtest.Result fields, durations in")
+	fmt.Println("        nanoseconds) as a JSON document to this file, \"-\" for stdout")
+	fmt.Println()
+	fmt.Println("  --json-only")
+	fmt.Println("        Print nothing on stdout but the run result as one JSON document, as")
+	fmt.Println("        --summary-json writes it; progress, the summary table and errors go to stderr.")
+	fmt.Println("        For Kubernetes Jobs and CI, which can parse stdout without filtering it")
+	fmt.Println()
+	fmt.Println("  --webhook-url string")
+	fmt.Println("        When the run finishes, also on an SLA assertion failure or --max-runtime, POST")
+	fmt.Println("        the run result as --summary-json writes it plus a \"status\" of pass or fail")
+	fmt.Println("        to this URL; a run that fails posts only the status and its \"error\". Failed")
+	fmt.Println("        requests are retried twice, then only logged")
+	fmt.Println()
+	fmt.Println("  --cpuprofile string")
+	fmt.Println("        Write a CPU profile of the run to this file, to check whether the client")
+	fmt.Println("        itself caps throughput. Sampling costs a few percent of one core")
+	fmt.Println()
+	fmt.Println("  --memprofile string")
+	fmt.Println("        Write a heap profile to this file once the run has finished")
+	fmt.Println("        Analyze either with: go tool pprof -http=:8080 milvus-stress-test <file>")
+	fmt.Println()
+	fmt.Println("  --preset string")
+	fmt.Println("        Fill every option not given on the command line or in the environment from")
+	fmt.Println("        the named preset, the file <name>.conf in --presets-dir")
+	fmt.Println("        Example: --preset nightly --milvus-addr milvus:19530")
+	fmt.Println()
+	fmt.Println("  --presets-dir string")
+	fmt.Println("        Directory holding the presets (default: presets)")
+	fmt.Println()
+	fmt.Println("  --list-presets")
+	fmt.Println("        List the presets in --presets-dir with their description and exit")
+	fmt.Println()
 	fmt.Println("  --help")
 	fmt.Println("        Show this help information")
 	fmt.Println()
+	fmt.Println("EXIT CODES:")
+	fmt.Println("  0  Success, every SLA assertion met")
+	fmt.Println("  1  Runtime error: an operation failed during the run")
+	fmt.Println("  2  Configuration error: unknown command, invalid flag or setting, or a 'search'")
+	fmt.Println("     collection indexed with another --metric")
+	fmt.Println("  3  Connection failure: Milvus could not be reached")
+	fmt.Println("  4  SLA assertion failed (--max-search-p99, --min-search-qps, --strict,")
+	fmt.Println("     or a malformed --fault-injection-rate batch Milvus accepted)")
+	fmt.Println("  5  Time budget exceeded: --max-runtime stopped the run, partial summary printed")
+	fmt.Println()
+	fmt.Println("ENVIRONMENT:")
+	fmt.Println("  Every option can also be set with a MILVUS_STRESS_* environment variable,")
+	fmt.Println("  named after the option in upper case with dashes replaced by underscores.")
+	fmt.Println("  Command-line options take precedence over environment variables, and both")
+	fmt.Println("  over the --preset.")
+	fmt.Println("  Example: MILVUS_STRESS_MILVUS_ADDR=milvus:19530 MILVUS_STRESS_PRESSURE=high")
+	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Println("  # Basic 30-second medium load test")
-	fmt.Println("  go run main.go")
+	fmt.Println("  go run .")
 	fmt.Println()
 	fmt.Println("  # High load test for 2 minutes")
-	fmt.Println("  go run main.go --duration 2m --pressure high")
+	fmt.Println("  go run . --duration 2m --pressure high")
 	fmt.Println()
 	fmt.Println("  # Gradual load increase with real-time monitoring")
-	fmt.Println("  go run main.go --duration 1m --pressure high --ramp-up --real-time")
+	fmt.Println("  go run . --duration 1m --pressure high --ramp-up --real-time")
 	fmt.Println()
 	fmt.Println("  # Extreme endurance test")
-	fmt.Println("  go run main.go --duration 1h --pressure extreme --real-time")
+	fmt.Println("  go run . --duration 1h --pressure extreme --real-time")
+	fmt.Println()
+	fmt.Println("  # Populate once, then run repeated search benchmarks against the same data")
+	fmt.Println("  go run . insert --duration 5m --pressure high")
+	fmt.Println("  go run . search --duration 1m --pressure medium")
+	fmt.Println("  go run . cleanup")
+	fmt.Println()
+	fmt.Println("  # Find collections left behind by interrupted runs")
+	fmt.Println("  go run . list --list-filter 'go_high_throughput_collection*'")
+	fmt.Println("  go run . cleanup-orphans --orphan-age 6h --confirm")
 	fmt.Println()
 	fmt.Println("  # Custom Milvus server")
-	fmt.Println("  go run main.go --milvus-addr 192.168.1.100:19530 --duration 5m")
+	fmt.Println("  go run . --milvus-addr 192.168.1.100:19530 --duration 5m")
 }
 
 func main() {
-	// --- Command-line flags for load testing ---
-	milvusAddr := flag.String("milvus-addr", "localhost:19530", "Milvus server address (host:port)")
-	duration := flag.Duration("duration", 30*time.Second, "Test duration (e.g., 30s, 2m, 1h)")
-	pressure := flag.String("pressure", "medium", "Load intensity: low, medium, high, extreme")
-	rampUp := flag.Bool("ramp-up", false, "Gradually increase load from 10% to 100% over duration")
-	realTime := flag.Bool("real-time", false, "Display real-time throughput metrics")
-	showHelp := flag.Bool("help", false, "Show detailed help information")
-	flag.Parse()
-
-	// Show help if requested
-	if *showHelp {
-		showDetailedHelp()
-		return
-	}
-
-	// --- Pressure Level Settings ---
-	var numConcurrentGoroutines, batchSize int
-	var pressureLevel string
-
-	switch *pressure {
-	case "low":
-		pressureLevel = "LOW"
-		numConcurrentGoroutines = 5
-		batchSize = 500
-	case "medium":
-		pressureLevel = "MEDIUM"
-		numConcurrentGoroutines = 20
-		batchSize = 2000
-	case "high":
-		pressureLevel = "HIGH"
-		numConcurrentGoroutines = 50
-		batchSize = 5000
-	case "extreme":
-		pressureLevel = "EXTREME"
-		numConcurrentGoroutines = 100
-		batchSize = 10000
-	default:
-		pressureLevel = "MEDIUM (default)"
-		numConcurrentGoroutines = 20
-		batchSize = 2000
+	// Pick the subcommand, defaulting to the full pipeline when the first argument is a flag
+	commands := loadtest.Commands()
+	name, args := commands[0].Name, os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		found := false
+		for _, c := range commands {
+			if c.Name == args[0] {
+				name, args, found = c.Name, args[1:], true
+				break
+			}
+		}
+		if !found {
+			fatal(exitConfig, fmt.Sprintf("Unknown command %q, run with --help for the list of commands", args[0]))
+		}
 	}
 
-	// --- Load Test Configuration ---
-	fmt.Printf(">> Starting Milvus Load Test: %s intensity for %s <<\n", pressureLevel, *duration)
-	fmt.Println("\n--- Test Configuration ---")
-	fmt.Printf(" - Milvus Address:                  %s\n", *milvusAddr)
-	fmt.Printf(" - Test Duration:                   %s\n", *duration)
-	fmt.Printf(" - Load Intensity:                  %s\n", pressureLevel)
-	fmt.Printf(" - Concurrent Workers:              %d\n", numConcurrentGoroutines)
-	fmt.Printf(" - Batch Size (Vectors per Insert): %d\n", batchSize)
-	fmt.Printf(" - Test Mode:                       Continuous load until duration expires\n")
-	fmt.Println("----------------------------------------")
-
-	totalStartTime := time.Now()
-	ctx := context.Background()
-
-	// Variables to track metrics for summary table
-	var (
-		connectionTime         time.Duration
-		insertionTime          time.Duration
-		flushTime              time.Duration
-		indexTime              time.Duration
-		loadTime               time.Duration
-		searchTime             time.Duration
-		cleanupTime            time.Duration
-		insertsPerSec          float64
-		searchesPerSec         float64
-		totalVectorsInserted   int64
-		totalSearchesPerformed int64
-	)
-
-	// 1. Connect to Milvus
-	fmt.Println("\n--- Step 1: Connect to Milvus ---")
-	fmt.Printf("Attempting to connect to Milvus at %s...\n", *milvusAddr)
-	connectStart := time.Now()
-	milvusClient, err := client.NewClient(ctx, client.Config{Address: *milvusAddr})
+	cfg, opts, err := parseConfig(name, args)
 	if err != nil {
-		log.Fatalf("Failed to connect to Milvus: %v", err)
+		if errors.Is(err, flag.ErrHelp) {
+			showDetailedHelp()
+			return
+		}
+		fatal(exitConfig, fmt.Sprintf("Invalid configuration: %v (run with --help for the list of options)", err))
 	}
-	defer milvusClient.Close()
-	connectionTime = time.Since(connectStart)
-	fmt.Println("✅ Connected to Milvus successfully!")
 
-	// 2. Clean up previous runs
-	fmt.Printf("\n--- Step 2: Check for and drop existing collection '%s' ---\n", collectionName)
-	has, err := milvusClient.HasCollection(ctx, collectionName)
-	if err != nil {
-		log.Fatalf("Failed to check if collection exists: %v", err)
+	// Show help if requested
+	if opts.showHelp {
+		showDetailedHelp()
+		return
 	}
-	if has {
-		fmt.Printf("Collection '%s' already exists. Dropping it...\n", collectionName)
-		if err := milvusClient.DropCollection(ctx, collectionName); err != nil {
-			log.Fatalf("Failed to drop collection: %v", err)
+	if opts.listPresets {
+		if err := printPresets(opts.presetsDir); err != nil {
+			fatal(exitConfig, err)
 		}
-		fmt.Println("✅ Dropped existing collection.")
-	} else {
-		fmt.Println("Collection does not exist, proceeding.")
+		return
 	}
 
-	// 3. Create a collection
-	fmt.Printf("\n--- Step 3: Create collection '%s' ---\n", collectionName)
-	schema := &entity.Schema{
-		CollectionName: collectionName,
-		Fields: []*entity.Field{
-			{Name: primaryKeyField, DataType: entity.FieldTypeInt64, PrimaryKey: true, AutoID: true},
-			{Name: embeddingField, DataType: entity.FieldTypeFloatVector, TypeParams: map[string]string{"dim": fmt.Sprintf("%d", embeddingDim)}},
-		},
-	}
-	if err := milvusClient.CreateCollection(ctx, schema, entity.DefaultShardNumber); err != nil {
-		log.Fatalf("Failed to create collection: %v", err)
+	// Pin client parallelism before any worker starts. Without the flag the Go runtime
+	// picks the value, honouring container CPU limits.
+	if opts.gomaxprocs > 0 {
+		runtime.GOMAXPROCS(opts.gomaxprocs)
 	}
-	fmt.Println("✅ Collection created successfully.")
 
-	// 4. Insert data continuously for the specified duration (with optional ramp-up)
-	fmt.Printf("\n--- Step 4: Starting continuous data insertion for %s ---\n", *duration)
-	if *rampUp {
-		fmt.Println("📈 RAMP-UP MODE: Gradually increasing load from 10% to 100%...")
+	// With --json-only everything the run prints goes to stderr, so stdout ends
+	// up holding the result document alone
+	if opts.jsonOnly {
+		os.Stdout = os.Stderr
 	}
 
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	insertionStartTime := time.Now()
-	testEndTime := insertionStartTime.Add(*duration)
-
-	// Start all worker goroutines
-	for i := 0; i < numConcurrentGoroutines; i++ {
-		wg.Add(1)
-		go func(goroutineID int) {
-			defer wg.Done()
-			fmt.Printf("[Worker %d] Starting continuous insertion...\n", goroutineID)
-			rand.Seed(time.Now().UnixNano() + int64(goroutineID))
-
-			batchCount := 0
-			lastThroughput := 0.0
-
-			for time.Now().Before(testEndTime) {
-				// Calculate dynamic load if ramp-up is enabled
-				currentBatchSize := batchSize
-				if *rampUp {
-					elapsed := time.Since(insertionStartTime)
-					_, currentBatchSize = calculateDynamicLoad(elapsed, *duration, numConcurrentGoroutines, batchSize)
-				}
-
-				vectors := make([][]float32, currentBatchSize)
-				for k := 0; k < currentBatchSize; k++ {
-					vec := make([]float32, embeddingDim)
-					for l := 0; l < embeddingDim; l++ {
-						vec[l] = rand.Float32()
-					}
-					vectors[k] = vec
-				}
-				embeddingColumn := entity.NewColumnFloatVector(embeddingField, embeddingDim, vectors)
-				_, err := milvusClient.Insert(ctx, collectionName, "", embeddingColumn)
-				if err != nil {
-					log.Printf("[Worker %d] Failed to insert batch %d: %v", goroutineID, batchCount, err)
-					continue
-				}
-
-				// Update counters atomically
-				mu.Lock()
-				totalVectorsInserted += int64(currentBatchSize)
-				mu.Unlock()
-
-				// Real-time monitoring
-				if *realTime && batchCount%10 == 0 {
-					elapsed := time.Since(insertionStartTime)
-					currentThroughput := float64(totalVectorsInserted) / elapsed.Seconds()
-					if currentThroughput != lastThroughput {
-						fmt.Printf("📊 [%s] Batch Size: %d, Throughput: %.1f ops/sec\n",
-							elapsed.Round(time.Second), currentBatchSize, currentThroughput)
-						lastThroughput = currentThroughput
-					}
-				}
-
-				batchCount++
-			}
-			fmt.Printf("[Worker %d] Finished after %d batches.\n", goroutineID, batchCount)
-		}(i)
+	runner, err := loadtest.NewRunner(*cfg)
+	if err != nil {
+		fatal(exitConfig, fmt.Sprintf("Invalid configuration: %v (run with --help for the list of options)", err))
 	}
-
-	wg.Wait()
-	insertionTime = time.Since(insertionStartTime)
-	insertsPerSec = float64(totalVectorsInserted) / insertionTime.Seconds()
-
-	fmt.Printf("✅ All workers finished inserting data in %s.\n", insertionTime)
-	fmt.Printf("   -> Total vectors inserted: %d\n", totalVectorsInserted)
-	fmt.Printf("   -> Throughput: %.2f inserts/second\n", insertsPerSec)
-
-	// Flush the collection
-	fmt.Println("\nFlushing collection to seal segments...")
-	flushStart := time.Now()
-	if err := milvusClient.Flush(ctx, collectionName, false); err != nil {
-		log.Fatalf("Failed to flush collection: %v", err)
+	stopProfiling, err := startProfiling(opts)
+	if err != nil {
+		fatal(exitRuntime, err)
 	}
-	flushTime = time.Since(flushStart)
-	fmt.Println("✅ Data flushed successfully.")
-
-	// 5. Create an index
-	fmt.Printf("\n--- Step 5: Create index on field '%s' ---\n", embeddingField)
-	index, _ := entity.NewIndexIvfFlat(entity.L2, 16)
-	fmt.Println("Waiting for index to be built (this may take a while)...")
-	indexStartTime := time.Now()
-	if err := milvusClient.CreateIndex(ctx, collectionName, embeddingField, index, false); err != nil {
-		log.Fatalf("Failed to create index: %v", err)
+	result, runErr := runner.Run(context.Background())
+	// Stop before the summary so the profiles only cover the run itself
+	if err := stopProfiling(); err != nil {
+		log.Print(err)
 	}
-	indexTime = time.Since(indexStartTime)
-	fmt.Printf("✅ Index created successfully in %s.\n", indexTime)
-
-	// 6. Load the collection
-	fmt.Println("\n--- Step 6: Load collection into memory ---")
-	loadStartTime := time.Now()
-	if err := milvusClient.LoadCollection(ctx, collectionName, false); err != nil {
-		log.Fatalf("Failed to load collection: %v", err)
+	if runErr != nil && !errors.Is(runErr, loadtest.ErrBudgetExceeded) {
+		notifyWebhook(opts.webhookURL, nil, runErr)
+		fatal(exitCode(runErr), runErr)
 	}
-	loadTime = time.Since(loadStartTime)
-	fmt.Printf("✅ Collection loaded successfully in %s.\n", loadTime)
 
-	// 7. Perform continuous searches for a shorter duration
-	searchDuration := *duration / 4 // Search for 1/4 of the total test duration
-	fmt.Printf("\n--- Step 7: Perform continuous searches for %s ---\n", searchDuration)
-
-	var searchWg sync.WaitGroup
-	var searchMu sync.Mutex
-	searchStartTime := time.Now()
-	searchEndTime := searchStartTime.Add(searchDuration)
-
-	for i := 0; i < numConcurrentGoroutines; i++ {
-		searchWg.Add(1)
-		go func(goroutineID int) {
-			defer searchWg.Done()
-			fmt.Printf("[Search Worker %d] Starting continuous searches...\n", goroutineID)
-			rand.Seed(time.Now().UnixNano() + int64(goroutineID))
-
-			searchCount := 0
-			for time.Now().Before(searchEndTime) {
-				queryVectorData := make([]float32, embeddingDim)
-				for j := range queryVectorData {
-					queryVectorData[j] = rand.Float32()
-				}
-				queryVector := []entity.Vector{entity.FloatVector(queryVectorData)}
-				searchParams, _ := entity.NewIndexIvfFlatSearchParam(10) // nprobe = 10
-
-				_, err := milvusClient.Search(ctx, collectionName, []string{}, "", []string{}, queryVector, embeddingField, entity.L2, 3, searchParams)
-				if err != nil {
-					log.Printf("[Search Worker %d] Failed to perform search %d: %v", goroutineID, searchCount, err)
-					continue
-				}
-
-				// Update counters atomically
-				searchMu.Lock()
-				totalSearchesPerformed++
-				searchMu.Unlock()
-
-				searchCount++
-			}
-			fmt.Printf("[Search Worker %d] Finished after %d searches.\n", goroutineID, searchCount)
-		}(i)
+	// --- Final Summary Table ---
+	runner.PrintSummary()
+	if opts.jsonPath != "" {
+		if err := writeSummaryJSON(opts.jsonPath, result); err != nil {
+			fatal(exitRuntime, err)
+		}
 	}
-	searchWg.Wait()
-	searchTime = time.Since(searchStartTime)
-	searchesPerSec = float64(totalSearchesPerformed) / searchTime.Seconds()
-
-	fmt.Printf("✅ All search workers finished in %s.\n", searchTime)
-	fmt.Printf("   -> Total searches performed: %d\n", totalSearchesPerformed)
-	fmt.Printf("   -> Throughput: %.2f searches/second\n", searchesPerSec)
-
-	// 8. Clean up
-	fmt.Printf("\n--- Step 8: Clean up by dropping collection '%s' ---\n", collectionName)
-	cleanupStart := time.Now()
-	if err := milvusClient.DropCollection(ctx, collectionName); err != nil {
-		log.Fatalf("Failed to drop collection: %v", err)
+	if opts.jsonOnly {
+		if err := writeSummaryJSON("-", result); err != nil {
+			fatal(exitRuntime, err)
+		}
+	}
+	notifyWebhook(opts.webhookURL, result, runErr)
+	if runErr != nil {
+		fatal(exitCode(runErr), runErr)
+	}
+	if len(result.AssertionFailures) > 0 {
+		for _, failure := range result.AssertionFailures {
+			log.Printf("SLA assertion failed: %s", failure)
+		}
+		os.Exit(exitSLA)
 	}
-	cleanupTime = time.Since(cleanupStart)
-	fmt.Println("✅ Cleanup successful!")
-
-	// --- Final Summary Table ---
-	totalDuration := time.Since(totalStartTime)
-	totalDataMB := float64(totalVectorsInserted*embeddingDim*4) / (1024 * 1024) // 4 bytes per float32
-
-	fmt.Println("\n" + strings.Repeat("=", 80))
-	fmt.Println("                        LOAD TEST PERFORMANCE SUMMARY")
-	fmt.Println(strings.Repeat("=", 80))
-
-	// Configuration section
-	fmt.Printf("│ %-25s │ %-50s │\n", "Configuration", "Value")
-	fmt.Println("├" + strings.Repeat("─", 27) + "┼" + strings.Repeat("─", 52) + "┤")
-	fmt.Printf("│ %-25s │ %-50s │\n", "Test Duration", *duration)
-	fmt.Printf("│ %-25s │ %-50s │\n", "Pressure Level", pressureLevel)
-	fmt.Printf("│ %-25s │ %-50s │\n", "Milvus Address", *milvusAddr)
-	fmt.Printf("│ %-25s │ %-50d │\n", "Concurrent Workers", numConcurrentGoroutines)
-	fmt.Printf("│ %-25s │ %-50d │\n", "Batch Size", batchSize)
-	fmt.Printf("│ %-25s │ %-50d │\n", "Vectors Inserted", totalVectorsInserted)
-	fmt.Printf("│ %-25s │ %-50.2f MB │\n", "Data Size Inserted", totalDataMB)
-	fmt.Printf("│ %-25s │ %-50d │\n", "Searches Performed", totalSearchesPerformed)
-
-	fmt.Println("├" + strings.Repeat("─", 27) + "┼" + strings.Repeat("─", 52) + "┤")
-
-	// Performance metrics section
-	fmt.Printf("│ %-25s │ %-50s │\n", "Performance Metrics", "Value")
-	fmt.Println("├" + strings.Repeat("─", 27) + "┼" + strings.Repeat("─", 52) + "┤")
-	fmt.Printf("│ %-25s │ %-50s │\n", "Total Elapsed Time", totalDuration.String())
-	fmt.Printf("│ %-25s │ %-50s │\n", "Connection Time", connectionTime.String())
-	fmt.Printf("│ %-25s │ %-50s │\n", "Data Insertion Time", insertionTime.String())
-	fmt.Printf("│ %-25s │ %-50.2f │\n", "Insert Throughput", insertsPerSec)
-	fmt.Printf("│ %-25s │ %-50s │\n", "Flush Time", flushTime.String())
-	fmt.Printf("│ %-25s │ %-50s │\n", "Index Creation Time", indexTime.String())
-	fmt.Printf("│ %-25s │ %-50s │\n", "Collection Load Time", loadTime.String())
-	fmt.Printf("│ %-25s │ %-50s │\n", "Search Execution Time", searchTime.String())
-	fmt.Printf("│ %-25s │ %-50.2f │\n", "Search Throughput", searchesPerSec)
-	fmt.Printf("│ %-25s │ %-50s │\n", "Cleanup Time", cleanupTime.String())
-
-	fmt.Println(strings.Repeat("=", 80))
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ariswibono/milvus-stress-test/loadtest"
+)
+
+// stdout is where "-" writes the result, the real standard output even once
+// --json-only has pointed os.Stdout at stderr
+var stdout = os.Stdout
+
+// writeSummaryJSON writes the run result as an indented JSON document to path,
+// "-" meaning stdout
+func writeSummaryJSON(path string, result *loadtest.Result) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode summary JSON: %w", err)
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write summary JSON: %w", err)
+	}
+	return nil
+}
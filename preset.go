@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// defaultPresetsDir is where --preset looks unless --presets-dir says otherwise
+const defaultPresetsDir = "presets"
+
+// presetExt ends the file name of every preset: presets/nightly.conf is "nightly"
+const presetExt = ".conf"
+
+// presetFlags cannot be set from a preset, they pick the preset
+var presetFlags = []string{"preset", "presets-dir", "list-presets"}
+
+// presetSetting is one flag=value line of a preset file
+type presetSetting struct {
+	name, value string
+	line        int
+}
+
+// readPreset reads a preset file: one flag=value per line, with or without the
+// leading dashes, blank lines and # comments ignored. Its description is the
+// text of the first comment line.
+func readPreset(path string) ([]presetSetting, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+	var settings []presetSetting
+	var description string
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			if description == "" && len(settings) == 0 {
+				description = strings.TrimSpace(comment)
+			}
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, "", fmt.Errorf("%s:%d: want flag=value, got %q", path, n, line)
+		}
+		name = strings.TrimLeft(strings.TrimSpace(name), "-")
+		settings = append(settings, presetSetting{name: name, value: strings.TrimSpace(value), line: n})
+	}
+	return settings, description, scanner.Err()
+}
+
+// applyPreset fills every flag of fs that neither the command line nor the
+// environment set from the named preset in dir. It must be called after
+// applyEnvOverrides.
+func applyPreset(fs *flag.FlagSet, dir, name string) error {
+	if name == "" {
+		return nil
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid --preset %q: a name, not a path", name)
+	}
+	path := filepath.Join(dir, name+presetExt)
+	settings, _, err := readPreset(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unknown --preset %q: no %s (see --list-presets)", name, path)
+	}
+	if err != nil {
+		return err
+	}
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+	for _, s := range settings {
+		if slices.Contains(presetFlags, s.name) || fs.Lookup(s.name) == nil {
+			return fmt.Errorf("%s:%d: unknown option --%s", path, s.line, s.name)
+		}
+		if explicit[s.name] {
+			continue
+		}
+		if err := fs.Set(s.name, s.value); err != nil {
+			return fmt.Errorf("%s:%d: invalid value %q for --%s: %w", path, s.line, s.value, s.name, err)
+		}
+	}
+	return nil
+}
+
+// printPresets lists the presets in dir with their description
+func printPresets(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+presetExt))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Printf("No presets in %s\n", dir)
+		return nil
+	}
+	fmt.Printf("Presets in %s:\n", dir)
+	for _, path := range paths {
+		settings, description, err := readPreset(path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %-16s %s (%d options)\n", strings.TrimSuffix(filepath.Base(path), presetExt), description, len(settings))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writePreset writes a preset named name to a new presets directory, which it
+// returns
+func writePreset(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name+presetExt), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestReadPreset(t *testing.T) {
+	dir := writePreset(t, "p", "# Example preset\n\n--duration = 1m\n# not the description\npressure=high\n")
+	settings, description, err := readPreset(filepath.Join(dir, "p"+presetExt))
+	if err != nil {
+		t.Fatalf("readPreset() = %v", err)
+	}
+	if description != "Example preset" {
+		t.Errorf("description = %q, want %q", description, "Example preset")
+	}
+	want := []presetSetting{{"duration", "1m", 3}, {"pressure", "high", 5}}
+	if len(settings) != len(want) {
+		t.Fatalf("settings = %v, want %v", settings, want)
+	}
+	for i := range want {
+		if settings[i] != want[i] {
+			t.Errorf("setting %d = %v, want %v", i, settings[i], want[i])
+		}
+	}
+}
+
+func TestPresetPrecedence(t *testing.T) {
+	const preset = "# Precedence\nduration=10m\npressure=high\nretries=7\n"
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		duration time.Duration
+		pressure string
+		retries  int
+	}{
+		{"preset alone", nil, nil, 10 * time.Minute, "high", 7},
+		{"environment over preset", nil, map[string]string{"MILVUS_STRESS_PRESSURE": "low"}, 10 * time.Minute, "low", 7},
+		{
+			"flag over environment over preset",
+			[]string{"--pressure", "extreme", "--retries", "1"},
+			map[string]string{"MILVUS_STRESS_PRESSURE": "low", "MILVUS_STRESS_DURATION": "2m"},
+			2 * time.Minute, "extreme", 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writePreset(t, "p", preset)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			cfg, _, err := parseConfig("insert", append([]string{"--presets-dir", dir, "--preset", "p"}, tt.args...))
+			if err != nil {
+				t.Fatalf("parseConfig() = %v", err)
+			}
+			if cfg.Duration != tt.duration || cfg.Pressure != tt.pressure || cfg.Retries != tt.retries {
+				t.Errorf("duration, pressure, retries = %s, %s, %d, want %s, %s, %d",
+					cfg.Duration, cfg.Pressure, cfg.Retries, tt.duration, tt.pressure, tt.retries)
+			}
+		})
+	}
+}
+
+func TestApplyPresetErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  string // "" for a name that has no file
+		content string
+		wantErr string
+	}{
+		{"path for a name", "../p", "", "a name, not a path"},
+		{"missing preset", "", "", `unknown --preset "p"`},
+		{"not flag=value", "p", "duration 1m\n", "p.conf:1: want flag=value"},
+		{"unknown option", "p", "# x\nno-such-flag=1\n", "p.conf:2: unknown option --no-such-flag"},
+		{"preset picks a preset", "p", "preset=other\n", "unknown option --preset"},
+		{"invalid value", "p", "retries=many\n", `p.conf:1: invalid value "many" for --retries`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, name := t.TempDir(), "p"
+			if tt.preset != "" {
+				dir, name = writePreset(t, "p", tt.content), tt.preset
+			}
+			_, _, err := parseConfig("insert", []string{"--presets-dir", dir, "--preset", name})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseConfig() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShippedPresets(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join(defaultPresetsDir, "*"+presetExt))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no presets in %s: %v", defaultPresetsDir, err)
+	}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), presetExt)
+		if _, _, err := parseConfig("insert", []string{"--preset", name}); err != nil {
+			t.Errorf("--preset %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiling starts the --cpuprofile CPU profile and returns a function that
+// stops it and writes the --memprofile heap profile. Both files are created up
+// front so a bad path fails before the run rather than after it.
+func startProfiling(opts *cliOptions) (func() error, error) {
+	var cpuFile, memFile *os.File
+	var err error
+	if opts.memProfile != "" {
+		if memFile, err = os.Create(opts.memProfile); err != nil {
+			return nil, fmt.Errorf("failed to create memory profile: %w", err)
+		}
+	}
+	if opts.cpuProfile != "" {
+		if cpuFile, err = os.Create(opts.cpuProfile); err != nil {
+			return nil, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			cpuFile.Close()
+			return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+	}
+
+	return func() error {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				return fmt.Errorf("failed to write CPU profile: %w", err)
+			}
+			fmt.Printf("CPU profile written to %s\n", opts.cpuProfile)
+		}
+		if memFile != nil {
+			defer memFile.Close()
+			runtime.GC() // report live objects as of the end of the run
+			if err := pprof.WriteHeapProfile(memFile); err != nil {
+				return fmt.Errorf("failed to write memory profile: %w", err)
+			}
+			fmt.Printf("Memory profile written to %s\n", opts.memProfile)
+		}
+		return nil
+	}, nil
+}
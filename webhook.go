@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ariswibono/milvus-stress-test/loadtest"
+)
+
+// webhookAttempts is how many times the result is posted before giving up
+const webhookAttempts = 3
+
+// webhookBackoff is the wait before the second attempt, doubled before each later one
+const webhookBackoff = time.Second
+
+// webhookTimeout bounds a single POST
+const webhookTimeout = 10 * time.Second
+
+// webhookPayload is the document --webhook-url receives: the run result with the
+// outcome the exit code reports next to it. A run that failed has no result,
+// only its error.
+type webhookPayload struct {
+	Status string `json:"status"`          // pass, or fail on an SLA assertion or the run stopping early
+	Error  string `json:"error,omitempty"` // why the run failed or stopped early
+	*loadtest.Result
+}
+
+// checkWebhookURL rejects a --webhook-url the result could not be posted to
+func checkWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an http or https URL", raw)
+	}
+	return nil
+}
+
+// notifyWebhook posts the outcome of the run to rawURL when --webhook-url is set.
+// An undelivered notification does not change the outcome of the run, it is
+// only logged.
+func notifyWebhook(rawURL string, result *loadtest.Result, runErr error) {
+	if rawURL == "" {
+		return
+	}
+	if err := postWebhook(rawURL, result, runErr); err != nil {
+		log.Print(err)
+	}
+}
+
+// postWebhook POSTs the outcome of the run as JSON to rawURL, retrying failed
+// requests and non-2xx responses up to webhookAttempts times in all. Result is
+// nil when the run failed.
+func postWebhook(rawURL string, result *loadtest.Result, runErr error) error {
+	payload := webhookPayload{Status: "pass", Result: result}
+	if runErr != nil || result == nil || len(result.AssertionFailures) > 0 {
+		payload.Status = "fail"
+	}
+	if runErr != nil {
+		payload.Error = runErr.Error()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode the webhook payload: %w", err)
+	}
+	wait := webhookBackoff
+	for attempt := 1; ; attempt++ {
+		err = postOnce(rawURL, body)
+		if err == nil || attempt == webhookAttempts {
+			break
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+	if err != nil {
+		return fmt.Errorf("failed to post the result to --webhook-url after %d attempts: %w", webhookAttempts, err)
+	}
+	return nil
+}
+
+// postOnce sends one webhook request
+func postOnce(rawURL string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s responded %s", rawURL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ariswibono/milvus-stress-test/loadtest"
+)
+
+func TestPostWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     *loadtest.Result
+		runErr     error
+		wantStatus string
+		wantError  string
+	}{
+		{"passed", &loadtest.Result{Command: "full"}, nil, "pass", ""},
+		{"SLA assertion failed", &loadtest.Result{Command: "full", AssertionFailures: []string{"p99 too high"}}, nil, "fail", ""},
+		{"run failed", nil, errors.New("connect: connection refused"), "fail", "connect: connection refused"},
+		{"budget exceeded", &loadtest.Result{Command: "full"}, loadtest.ErrBudgetExceeded, "fail", loadtest.ErrBudgetExceeded.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decoding the payload: %v", err)
+				}
+			}))
+			defer server.Close()
+			if err := postWebhook(server.URL, tt.result, tt.runErr); err != nil {
+				t.Fatalf("postWebhook() = %v", err)
+			}
+			if got["status"] != tt.wantStatus {
+				t.Errorf("status = %v, want %s", got["status"], tt.wantStatus)
+			}
+			if errText, _ := got["error"].(string); errText != tt.wantError {
+				t.Errorf("error = %q, want %q", errText, tt.wantError)
+			}
+			if _, ok := got["command"]; ok != (tt.result != nil) {
+				t.Errorf("payload has the result: %t, want %t", ok, tt.result != nil)
+			}
+		})
+	}
+}